@@ -33,7 +33,7 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			"Issue",
 			encodeIssueRequest,
 			decodeIssueResponse,
-			mainflux.UserID{},
+			mainflux.Token{},
 		).Endpoint()),
 		identify: kitot.TraceClient(tracer, "identify")(kitgrpc.NewClient(
 			conn,
@@ -56,8 +56,8 @@ func (client grpcClient) Issue(ctx context.Context, req *mainflux.IssueReq, _ ..
 		return nil, err
 	}
 
-	ir := res.(identityRes)
-	return &mainflux.Token{Value: ir.id}, ir.err
+	ir := res.(issueRes)
+	return &mainflux.Token{Value: ir.value, Refresh: ir.refresh}, ir.err
 }
 
 func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -66,15 +66,15 @@ func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, er
 }
 
 func decodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(*mainflux.UserID)
-	return identityRes{res.GetValue(), nil}, nil
+	res := grpcRes.(*mainflux.Token)
+	return issueRes{res.GetValue(), res.GetRefresh(), nil}, nil
 }
 
 func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserID, error) {
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	res, err := client.identify(ctx, identityReq{token: token.GetValue()})
+	res, err := client.identify(ctx, identityReq{token: token.GetValue(), scope: token.GetScope()})
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +85,7 @@ func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(identityReq)
-	return &mainflux.Token{Value: req.token}, nil
+	return &mainflux.Token{Value: req.token, Scope: req.scope}, nil
 }
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
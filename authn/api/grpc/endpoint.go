@@ -26,10 +26,10 @@ func issueEndpoint(svc authn.Service) endpoint.Endpoint {
 
 		k, err := svc.Issue(ctx, req.issuer, key)
 		if err != nil {
-			return identityRes{}, err
+			return issueRes{}, err
 		}
 
-		return identityRes{k.Secret, nil}, nil
+		return issueRes{k.Secret, k.Refresh, nil}, nil
 	}
 }
 
@@ -40,7 +40,7 @@ func identifyEndpoint(svc authn.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.Identify(ctx, req.token)
+		id, err := svc.Identify(ctx, req.token, req.scope)
 		if err != nil {
 			return identityRes{}, err
 		}
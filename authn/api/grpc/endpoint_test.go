@@ -55,18 +55,20 @@ func TestIssue(t *testing.T) {
 	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
 
 	cases := []struct {
-		desc string
-		id   string
-		kind uint32
-		err  error
-		code codes.Code
+		desc    string
+		id      string
+		kind    uint32
+		refresh bool
+		err     error
+		code    codes.Code
 	}{
 		{
-			desc: "issue for user with valid token",
-			id:   email,
-			kind: authn.UserKey,
-			err:  nil,
-			code: codes.OK,
+			desc:    "issue for user with valid token",
+			id:      email,
+			kind:    authn.UserKey,
+			refresh: true,
+			err:     nil,
+			code:    codes.OK,
 		},
 		{
 			desc: "issue recovery key",
@@ -99,10 +101,11 @@ func TestIssue(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		_, err := client.Issue(context.Background(), &mainflux.IssueReq{Issuer: tc.id, Type: tc.kind})
+		token, err := client.Issue(context.Background(), &mainflux.IssueReq{Issuer: tc.id, Type: tc.kind})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "gRPC status can't be extracted from the error")
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		assert.Equal(t, tc.refresh, token.GetRefresh() != "", fmt.Sprintf("%s: expected refresh token %t", tc.desc, tc.refresh))
 	}
 }
 
@@ -116,6 +119,14 @@ func TestIdentify(t *testing.T) {
 	apiKey, err := svc.Issue(context.Background(), userKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
 	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
 
+	scopedKey, err := svc.Issue(context.Background(), userKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Scopes: []string{"things:read"}})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	loggedOutKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	err = svc.Logout(context.Background(), loggedOutKey.Secret)
+	assert.Nil(t, err, fmt.Sprintf("Logout expected to succeed: %s", err))
+
 	authAddr := fmt.Sprintf("localhost:%d", port)
 	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
 	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
@@ -123,10 +134,32 @@ func TestIdentify(t *testing.T) {
 	cases := []struct {
 		desc  string
 		token string
+		scope string
 		id    string
 		err   error
 		code  codes.Code
 	}{
+		{
+			desc:  "identify user with user token",
+			token: userKey.Secret,
+			id:    email,
+			err:   nil,
+			code:  codes.OK,
+		},
+		{
+			desc:  "identify user with user token of ended session",
+			token: loggedOutKey.Secret,
+			id:    "",
+			err:   status.Error(codes.Unauthenticated, "unauthorized access"),
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "identify user with refresh token",
+			token: userKey.Refresh,
+			id:    "",
+			err:   status.Error(codes.Unauthenticated, "unauthorized access"),
+			code:  codes.Unauthenticated,
+		},
 		{
 			desc:  "identify user with recovery token",
 			token: recoveryKey.Secret,
@@ -141,6 +174,22 @@ func TestIdentify(t *testing.T) {
 			err:   nil,
 			code:  codes.OK,
 		},
+		{
+			desc:  "identify user with scoped API token",
+			token: scopedKey.Secret,
+			scope: "things:read",
+			id:    email,
+			err:   nil,
+			code:  codes.OK,
+		},
+		{
+			desc:  "identify user with API token of insufficient scope",
+			token: scopedKey.Secret,
+			scope: "things:write",
+			id:    "",
+			err:   status.Error(codes.PermissionDenied, "insufficient key scope"),
+			code:  codes.PermissionDenied,
+		},
 		{
 			desc:  "identify user with invalid user token",
 			token: "invalid",
@@ -158,7 +207,7 @@ func TestIdentify(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		id, err := client.Identify(context.Background(), &mainflux.Token{Value: tc.token})
+		id, err := client.Identify(context.Background(), &mainflux.Token{Value: tc.token, Scope: tc.scope})
 		assert.Equal(t, tc.id, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, id.GetValue()))
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "gRPC status can't be extracted from the error")
@@ -8,6 +8,7 @@ import "github.com/mainflux/mainflux/authn"
 type identityReq struct {
 	token string
 	kind  uint32
+	scope string
 }
 
 func (req identityReq) validate() error {
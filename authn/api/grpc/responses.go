@@ -3,6 +3,12 @@
 
 package grpc
 
+type issueRes struct {
+	value   string
+	refresh string
+	err     error
+}
+
 type identityRes struct {
 	id  string
 	err error
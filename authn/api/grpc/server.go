@@ -60,13 +60,13 @@ func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, er
 }
 
 func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(identityRes)
-	return &mainflux.Token{Value: res.id}, encodeError(res.err)
+	res := grpcRes.(issueRes)
+	return &mainflux.Token{Value: res.value, Refresh: res.refresh}, encodeError(res.err)
 }
 
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.Token)
-	return identityReq{token: req.GetValue()}, nil
+	return identityReq{token: req.GetValue(), scope: req.GetScope()}, nil
 }
 
 func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -84,6 +84,8 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, authn.ErrKeyExpired):
 		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, authn.ErrInsufficientScope):
+		return status.Error(codes.PermissionDenied, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -5,6 +5,10 @@ package http
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
@@ -23,6 +27,7 @@ func issueEndpoint(svc authn.Service) endpoint.Endpoint {
 			Issuer:   req.issuer,
 			IssuedAt: now,
 			Type:     req.Type,
+			Scopes:   req.Scopes,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -39,7 +44,9 @@ func issueEndpoint(svc authn.Service) endpoint.Endpoint {
 		res := issueKeyRes{
 			ID:       key.ID,
 			Value:    key.Secret,
+			Refresh:  key.Refresh,
 			IssuedAt: key.IssuedAt,
+			Scopes:   key.Scopes,
 		}
 		if !key.ExpiresAt.IsZero() {
 			res.ExpiresAt = &key.ExpiresAt
@@ -48,6 +55,59 @@ func issueEndpoint(svc authn.Service) endpoint.Endpoint {
 	}
 }
 
+func refreshEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(refreshReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key, err := svc.Refresh(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		res := issueKeyRes{
+			ID:        key.ID,
+			Value:     key.Secret,
+			Refresh:   key.Refresh,
+			IssuedAt:  key.IssuedAt,
+			ExpiresAt: &key.ExpiresAt,
+		}
+		return res, nil
+	}
+}
+
+func logoutEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Logout(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
+
+func revokeSessionsEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sessionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSessions(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
+
 func revokeEndpoint(svc authn.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(keyReq)
@@ -78,6 +138,107 @@ func retrieveEndpoint(svc authn.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return key, nil
+		return toKeyRes(key), nil
 	}
 }
+
+func listKeysEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.RetrieveAll(ctx, req.issuer, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := keyPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Keys: []keyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, toKeyRes(key))
+		}
+
+		return res, nil
+	}
+}
+
+func jwksEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		res := jwksRes{Keys: []jwkRes{}}
+		for _, key := range svc.PublicKeys(ctx) {
+			jwk, ok := toJWKRes(key)
+			if !ok {
+				continue
+			}
+			res.Keys = append(res.Keys, jwk)
+		}
+
+		return res, nil
+	}
+}
+
+func toKeyRes(key authn.Key) keyRes {
+	res := keyRes{
+		ID:       key.ID,
+		Type:     key.Type,
+		Issuer:   key.Issuer,
+		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
+	}
+	if !key.ExpiresAt.IsZero() {
+		res.ExpiresAt = &key.ExpiresAt
+	}
+	if !key.LastUsedAt.IsZero() {
+		res.LastUsedAt = &key.LastUsedAt
+	}
+
+	return res
+}
+
+func toJWKRes(key authn.PublicKey) (jwkRes, bool) {
+	res := jwkRes{
+		Use: "sig",
+		Alg: key.Algorithm,
+		Kid: key.ID,
+	}
+
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		res.Kty = "RSA"
+		res.N = encode(k.N.Bytes())
+		res.E = encode(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		res.Kty = "EC"
+		res.Crv = k.Curve.Params().Name
+		res.X = encode(pad(k.X.Bytes(), size))
+		res.Y = encode(pad(k.Y.Bytes(), size))
+	default:
+		return jwkRes{}, false
+	}
+
+	return res, true
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func pad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	ret := make([]byte, size)
+	copy(ret[size-len(b):], b)
+	return ret
+}
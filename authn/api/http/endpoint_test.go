@@ -5,6 +5,9 @@ package http_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +24,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -35,6 +39,17 @@ const (
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
+}
+
+type keysPageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []struct {
+		ID     string   `json:"id"`
+		Scopes []string `json:"scopes"`
+	} `json:"keys"`
 }
 
 type testRequest struct {
@@ -91,6 +106,9 @@ func TestIssue(t *testing.T) {
 	uk := issueRequest{Type: authn.UserKey}
 	ak := issueRequest{Type: authn.APIKey, Duration: time.Hour}
 	rk := issueRequest{Type: authn.RecoveryKey}
+	sk := issueRequest{Type: authn.APIKey, Scopes: []string{"things:read", "channels:write:1"}}
+	isk := issueRequest{Type: authn.APIKey, Scopes: []string{"things:delete"}}
+	usk := issueRequest{Type: authn.UserKey, Scopes: []string{"things:read"}}
 
 	cases := []struct {
 		desc   string
@@ -113,6 +131,27 @@ func TestIssue(t *testing.T) {
 			token:  userKey.Secret,
 			status: http.StatusCreated,
 		},
+		{
+			desc:   "issue API key with scopes",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  userKey.Secret,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(isk),
+			ct:     contentType,
+			token:  userKey.Secret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue user key with scopes",
+			req:    toJSON(usk),
+			ct:     contentType,
+			token:  "",
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue recovery key",
 			req:    toJSON(rk),
@@ -237,6 +276,98 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestRetrieveAll(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	n := uint64(12)
+	for i := uint64(0); i < n; i++ {
+		key := authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Scopes: []string{"things:read"}}
+		_, err := svc.Issue(context.Background(), loginKey.Secret, key)
+		assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "retrieve keys with default pagination",
+			query:  "",
+			token:  loginKey.Secret,
+			status: http.StatusOK,
+			size:   10,
+		},
+		{
+			desc:   "retrieve last page of keys",
+			query:  "?offset=10&limit=5",
+			token:  loginKey.Secret,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "retrieve keys with zero limit",
+			query:  "?limit=0",
+			token:  loginKey.Secret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve keys with limit greater than max",
+			query:  "?limit=101",
+			token:  loginKey.Secret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve keys with invalid offset",
+			query:  "?offset=-1",
+			token:  loginKey.Secret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve keys with empty token",
+			query:  "",
+			token:  "",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve keys unauthorized",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/keys%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body keysPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, n, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, n, body.Total))
+		assert.Equal(t, tc.size, len(body.Keys), fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.size, len(body.Keys)))
+		for _, k := range body.Keys {
+			assert.Equal(t, []string{"things:read"}, k.Scopes, fmt.Sprintf("%s: expected key scopes %v got %v", tc.desc, []string{"things:read"}, k.Scopes))
+		}
+	}
+}
+
 func TestRevoke(t *testing.T) {
 	svc := newService()
 	userKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
@@ -287,3 +418,261 @@ func TestRevoke(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	userKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	usedKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	_, err = svc.Refresh(context.Background(), usedKey.Refresh)
+	assert.Nil(t, err, fmt.Sprintf("Refreshing user key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		req    string
+		ct     string
+		status int
+	}{
+		{
+			desc:   "refresh with valid refresh token",
+			req:    toJSON(map[string]string{"refresh_token": userKey.Refresh}),
+			ct:     contentType,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "refresh with used refresh token",
+			req:    toJSON(map[string]string{"refresh_token": usedKey.Refresh}),
+			ct:     contentType,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "refresh with user key",
+			req:    toJSON(map[string]string{"refresh_token": userKey.Secret}),
+			ct:     contentType,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "refresh with invalid refresh token",
+			req:    toJSON(map[string]string{"refresh_token": "invalid"}),
+			ct:     contentType,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "refresh without refresh token",
+			req:    "{}",
+			ct:     contentType,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "refresh with invalid request",
+			req:    "{",
+			ct:     contentType,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "refresh with wrong content type",
+			req:    toJSON(map[string]string{"refresh_token": usedKey.Refresh}),
+			ct:     "",
+			status: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/keys/refresh", ts.URL),
+			contentType: tc.ct,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+
+		var body struct {
+			Value   string `json:"value"`
+			Refresh string `json:"refresh_token"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, body.Value, fmt.Sprintf("%s: expected user key", tc.desc))
+		assert.NotEmpty(t, body.Refresh, fmt.Sprintf("%s: expected refresh token", tc.desc))
+	}
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	userKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	apiKey, err := svc.Issue(context.Background(), userKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "logout with API key",
+			token:  apiKey.Secret,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "logout without token",
+			token:  "",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "logout with user key",
+			token:  userKey.Secret,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "logout with user key of ended session",
+			token:  userKey.Secret,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions/current", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	req := testRequest{
+		client: client,
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/keys", ts.URL),
+		token:  userKey.Secret,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, fmt.Sprintf("listing keys after logout expected status code %d got %d", http.StatusForbidden, res.StatusCode))
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc := newService()
+	userKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	sessionKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "revoke sessions with invalid token",
+			token:  "invalid",
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "revoke sessions without token",
+			token:  "",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "revoke sessions with user key",
+			token:  userKey.Secret,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke sessions with user key of revoked session",
+			token:  sessionKey.Secret,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/sessions", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric(ecKey)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	kid := tokenizer.PublicKeys()[0].ID
+
+	cases := []struct {
+		desc string
+		svc  authn.Service
+		keys []map[string]string
+	}{
+		{
+			desc: "retrieve JWKS of asymmetric tokenizer",
+			svc:  authn.New(mocks.NewKeyRepository(), uuid.NewMock(), tokenizer),
+			keys: []map[string]string{
+				{"kty": "EC", "use": "sig", "alg": "ES256", "kid": kid, "crv": "P-256"},
+			},
+		},
+		{
+			desc: "retrieve JWKS of shared secret tokenizer",
+			svc:  newService(),
+			keys: []map[string]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, http.StatusOK, res.StatusCode))
+
+		var body struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		require.Equal(t, len(tc.keys), len(body.Keys), fmt.Sprintf("%s: expected %d keys got %d", tc.desc, len(tc.keys), len(body.Keys)))
+		for i, key := range tc.keys {
+			for k, v := range key {
+				assert.Equal(t, v, body.Keys[i][k], fmt.Sprintf("%s: expected %s %s got %s", tc.desc, k, v, body.Keys[i][k]))
+			}
+			assert.NotEmpty(t, body.Keys[i]["x"], fmt.Sprintf("%s: expected x coordinate", tc.desc))
+			assert.NotEmpty(t, body.Keys[i]["y"], fmt.Sprintf("%s: expected y coordinate", tc.desc))
+		}
+		ts.Close()
+	}
+}
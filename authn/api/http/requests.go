@@ -9,21 +9,28 @@ import (
 	"github.com/mainflux/mainflux/authn"
 )
 
+const maxLimitSize = 100
+
 type issueKeyReq struct {
 	issuer   string
 	Type     uint32        `json:"type,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
+// Only API keys can be limited to scopes.
 func (req issueKeyReq) validate() error {
 	if req.Type == authn.UserKey {
+		if len(req.Scopes) > 0 {
+			return authn.ErrMalformedEntity
+		}
 		return nil
 	}
 	if req.issuer == "" || (req.Type != authn.APIKey) {
 		return authn.ErrMalformedEntity
 	}
-	return nil
+	return authn.ValidateScopes(req.Scopes)
 }
 
 type keyReq struct {
@@ -37,3 +44,45 @@ func (req keyReq) validate() error {
 	}
 	return nil
 }
+
+type listKeysReq struct {
+	issuer string
+	offset uint64
+	limit  uint64
+}
+
+func (req listKeysReq) validate() error {
+	if req.issuer == "" {
+		return authn.ErrMalformedEntity
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return authn.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type refreshReq struct {
+	Token string `json:"refresh_token"`
+}
+
+func (req refreshReq) validate() error {
+	if req.Token == "" {
+		return authn.ErrMalformedEntity
+	}
+	return nil
+}
+
+type sessionReq struct {
+	token string
+}
+
+func (req sessionReq) validate() error {
+	if req.token == "" {
+		return authn.ErrMalformedEntity
+	}
+	return nil
+}
+
+type jwksReq struct{}
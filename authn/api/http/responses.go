@@ -13,13 +13,18 @@ import (
 var (
 	_ mainflux.Response = (*issueKeyRes)(nil)
 	_ mainflux.Response = (*revokeKeyRes)(nil)
+	_ mainflux.Response = (*keyRes)(nil)
+	_ mainflux.Response = (*keyPageRes)(nil)
+	_ mainflux.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
 	ID        string     `json:"id,omitempty"`
 	Value     string     `json:"value,omitempty"`
+	Refresh   string     `json:"refresh_token,omitempty"`
 	IssuedAt  time.Time  `json:"issued_at,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
 }
 
 func (res issueKeyRes) Code() int {
@@ -34,6 +39,80 @@ func (res issueKeyRes) Empty() bool {
 	return res.Value == ""
 }
 
+type keyRes struct {
+	ID         string     `json:"id"`
+	Type       uint32     `json:"type"`
+	Issuer     string     `json:"issuer"`
+	IssuedAt   time.Time  `json:"issued_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Scopes     []string   `json:"scopes,omitempty"`
+}
+
+func (res keyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keyRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type keyPageRes struct {
+	pageRes
+	Keys []keyRes `json:"keys"`
+}
+
+func (res keyPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keyPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keyPageRes) Empty() bool {
+	return false
+}
+
+// jwkRes is the public key in JSON Web Key format (RFC 7517).
+type jwkRes struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwksRes struct {
+	Keys []jwkRes `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
+
 type revokeKeyRes struct {
 }
 
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	kitot "github.com/go-kit/kit/tracing/opentracing"
@@ -20,9 +21,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-const contentType = "application/json"
+const (
+	contentType = "application/json"
+	offset      = "offset"
+	limit       = "limit"
+	defOffset   = 0
+	defLimit    = 10
+)
 
-var errUnsupportedContentType = errors.New("unsupported content type")
+var (
+	errUnsupportedContentType = errors.New("unsupported content type")
+	errInvalidQueryParams     = errors.New("invalid query params")
+)
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
@@ -39,6 +49,20 @@ func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Post("/keys/refresh", kithttp.NewServer(
+		kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+		decodeRefresh,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list")(listKeysEndpoint(svc)),
+		decodeListKeys,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/keys/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "retrieve")(retrieveEndpoint(svc)),
 		decodeKeyReq,
@@ -53,6 +77,27 @@ func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Delete("/sessions/current", kithttp.NewServer(
+		kitot.TraceServer(tracer, "logout")(logoutEndpoint(svc)),
+		decodeSession,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/sessions", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_sessions")(revokeSessionsEndpoint(svc)),
+		decodeSession,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/.well-known/jwks.json", kithttp.NewServer(
+		kitot.TraceServer(tracer, "jwks")(jwksEndpoint(svc)),
+		decodeJWKS,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version("auth"))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -73,6 +118,25 @@ func decodeIssue(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeRefresh(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+	req := refreshReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(authn.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeSession(_ context.Context, r *http.Request) (interface{}, error) {
+	req := sessionReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
 func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := keyReq{
 		issuer: r.Header.Get("Authorization"),
@@ -81,6 +145,29 @@ func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := readUintQuery(r, offset, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readUintQuery(r, limit, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listKeysReq{
+		issuer: r.Header.Get("Authorization"),
+		offset: o,
+		limit:  l,
+	}
+	return req, nil
+}
+
+func decodeJWKS(_ context.Context, r *http.Request) (interface{}, error) {
+	return jwksReq{}, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -105,6 +192,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, authn.ErrUnauthorizedAccess):
 		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, authn.ErrKeyExpired):
+		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, authn.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, authn.ErrConflict):
@@ -115,6 +204,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, errInvalidQueryParams):
+		w.WriteHeader(http.StatusBadRequest)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -126,3 +217,22 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		}
 	}
 }
+
+func readUintQuery(r *http.Request, key string, def uint64) (uint64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	strval := vals[0]
+	val, err := strconv.ParseUint(strval, 10, 64)
+	if err != nil {
+		return 0, errInvalidQueryParams
+	}
+
+	return val, nil
+}
@@ -43,6 +43,45 @@ func (lm *loggingMiddleware) Issue(ctx context.Context, issuer string, newKey au
 	return lm.svc.Issue(ctx, issuer, newKey)
 }
 
+func (lm *loggingMiddleware) Refresh(ctx context.Context, token string) (key authn.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method refresh for the key with expiration date %v took %s to complete", key.ExpiresAt, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, token)
+}
+
+func (lm *loggingMiddleware) Logout(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method logout took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Logout(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_sessions took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeSessions(ctx, token)
+}
+
 func (lm *loggingMiddleware) Revoke(ctx context.Context, owner, id string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method revoke for key %s took %s to complete", id, time.Since(begin))
@@ -69,9 +108,9 @@ func (lm *loggingMiddleware) Retrieve(ctx context.Context, owner, id string) (ke
 	return lm.svc.Retrieve(ctx, owner, id)
 }
 
-func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id string, err error) {
+func (lm *loggingMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (page authn.KeyPage, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method identify took %s to complete", time.Since(begin))
+		message := fmt.Sprintf("Method retrieve_all took %s to complete", time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -79,5 +118,27 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Identify(ctx, key)
+	return lm.svc.RetrieveAll(ctx, owner, offset, limit)
+}
+
+func (lm *loggingMiddleware) Identify(ctx context.Context, key, scope string) (id string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify for scope %s took %s to complete", scope, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Identify(ctx, key, scope)
+}
+
+func (lm *loggingMiddleware) PublicKeys(ctx context.Context) []authn.PublicKey {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method public_keys took %s to complete", time.Since(begin))
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublicKeys(ctx)
 }
@@ -38,6 +38,33 @@ func (ms *metricsMiddleware) Issue(ctx context.Context, issuer string, key authn
 	return ms.svc.Issue(ctx, issuer, key)
 }
 
+func (ms *metricsMiddleware) Refresh(ctx context.Context, token string) (authn.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, token)
+}
+
+func (ms *metricsMiddleware) Logout(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "logout").Add(1)
+		ms.latency.With("method", "logout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Logout(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSessions(ctx, token)
+}
+
 func (ms *metricsMiddleware) Revoke(ctx context.Context, issuer, id string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "revoke").Add(1)
@@ -56,11 +83,29 @@ func (ms *metricsMiddleware) Retrieve(ctx context.Context, issuer, id string) (a
 	return ms.svc.Retrieve(ctx, issuer, id)
 }
 
-func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string, error) {
+func (ms *metricsMiddleware) RetrieveAll(ctx context.Context, issuer string, offset, limit uint64) (authn.KeyPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_all").Add(1)
+		ms.latency.With("method", "retrieve_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveAll(ctx, issuer, offset, limit)
+}
+
+func (ms *metricsMiddleware) Identify(ctx context.Context, key, scope string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify").Add(1)
 		ms.latency.With("method", "identify").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Identify(ctx, key)
+	return ms.svc.Identify(ctx, key, scope)
+}
+
+func (ms *metricsMiddleware) PublicKeys(ctx context.Context) []authn.PublicKey {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "public_keys").Add(1)
+		ms.latency.With("method", "public_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PublicKeys(ctx)
 }
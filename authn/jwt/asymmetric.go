@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const kidHeader = "kid"
+
+// ErrUnsupportedKey indicates that the key is neither RSA nor ECDSA P-256 key.
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+var methods = map[string]jwt.SigningMethod{
+	jwt.SigningMethodRS256.Alg(): jwt.SigningMethodRS256,
+	jwt.SigningMethodES256.Alg(): jwt.SigningMethodES256,
+}
+
+type asymmetricTokenizer struct {
+	signingKey crypto.PrivateKey
+	signing    authn.PublicKey
+	keys       []authn.PublicKey
+}
+
+// NewAsymmetric returns new JWT Tokenizer which signs the tokens with the
+// private key, using RS256 for RSA and ES256 for ECDSA P-256 keys. Tokens are
+// verified with the public key of the signing key, or with any of the
+// verification keys, which are the public keys of the previous signing keys
+// kept until the tokens they've signed are no longer in use. Keys are
+// identified by their JWK thumbprints, set in the kid header of the tokens.
+func NewAsymmetric(signingKey crypto.PrivateKey, verificationKeys ...crypto.PublicKey) (authn.Tokenizer, error) {
+	signer, ok := signingKey.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	signing, err := publicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []authn.PublicKey{signing}
+	for _, k := range verificationKeys {
+		pk, err := publicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(keys, pk.ID) {
+			keys = append(keys, pk)
+		}
+	}
+
+	return asymmetricTokenizer{
+		signingKey: signingKey,
+		signing:    signing,
+		keys:       keys,
+	}, nil
+}
+
+func (svc asymmetricTokenizer) Issue(key authn.Key) (string, error) {
+	token := jwt.NewWithClaims(methods[svc.signing.Algorithm], newClaims(key))
+	token.Header[kidHeader] = svc.signing.ID
+	return token.SignedString(svc.signingKey)
+}
+
+func (svc asymmetricTokenizer) Parse(token string) (authn.Key, error) {
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header[kidHeader].(string)
+		for _, k := range svc.keys {
+			// Algorithm is checked as well, so the token can't be
+			// verified with the key of the other type.
+			if k.ID == kid && k.Algorithm == token.Method.Alg() {
+				return k.Key, nil
+			}
+		}
+		return nil, authn.ErrUnauthorizedAccess
+	})
+}
+
+func (svc asymmetricTokenizer) PublicKeys() []authn.PublicKey {
+	return svc.keys
+}
+
+func publicKey(key crypto.PublicKey) (authn.PublicKey, error) {
+	var alg string
+	var members map[string]string
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		alg = jwt.SigningMethodRS256.Alg()
+		members = map[string]string{
+			"kty": "RSA",
+			"n":   encode(k.N.Bytes()),
+			"e":   encode(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return authn.PublicKey{}, ErrUnsupportedKey
+		}
+		alg = jwt.SigningMethodES256.Alg()
+		members = map[string]string{
+			"kty": "EC",
+			"crv": k.Curve.Params().Name,
+			"x":   encode(pad(k.X.Bytes(), 32)),
+			"y":   encode(pad(k.Y.Bytes(), 32)),
+		}
+	default:
+		return authn.PublicKey{}, ErrUnsupportedKey
+	}
+
+	// JWK thumbprint is the hash of the JSON object of the required key
+	// members, ordered lexicographically and without whitespace, which is
+	// how encoding/json encodes the maps (RFC 7638).
+	data, err := json.Marshal(members)
+	if err != nil {
+		return authn.PublicKey{}, err
+	}
+	sum := sha256.Sum256(data)
+
+	pk := authn.PublicKey{
+		ID:        encode(sum[:]),
+		Algorithm: alg,
+		Key:       key,
+	}
+	return pk, nil
+}
+
+func contains(keys []authn.PublicKey, id string) bool {
+	for _, k := range keys {
+		if k.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func pad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	ret := make([]byte, size)
+	copy(ret[size-len(b):], b)
+	return ret
+}
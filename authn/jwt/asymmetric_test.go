@@ -0,0 +1,232 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/authn/jwt"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAsymmetric(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		key    crypto.PrivateKey
+		verify []crypto.PublicKey
+		alg    string
+		size   int
+		err    error
+	}{
+		{
+			desc: "create RS256 tokenizer",
+			key:  rsaKey,
+			alg:  "RS256",
+			size: 1,
+			err:  nil,
+		},
+		{
+			desc: "create ES256 tokenizer",
+			key:  ecKey,
+			alg:  "ES256",
+			size: 1,
+			err:  nil,
+		},
+		{
+			desc:   "create tokenizer with verification keys",
+			key:    ecKey,
+			verify: []crypto.PublicKey{rsaKey.Public(), ecKey.Public()},
+			alg:    "ES256",
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc: "create tokenizer with unsupported curve",
+			key:  p384Key,
+			err:  jwt.ErrUnsupportedKey,
+		},
+		{
+			desc:   "create tokenizer with unsupported verification key",
+			key:    rsaKey,
+			verify: []crypto.PublicKey{p384Key.Public()},
+			err:    jwt.ErrUnsupportedKey,
+		},
+		{
+			desc: "create tokenizer with shared secret",
+			key:  []byte(secret),
+			err:  jwt.ErrUnsupportedKey,
+		},
+	}
+
+	for _, tc := range cases {
+		tokenizer, err := jwt.NewAsymmetric(tc.key, tc.verify...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		keys := tokenizer.PublicKeys()
+		require.Equal(t, tc.size, len(keys), fmt.Sprintf("%s expected %d keys, got %d", tc.desc, tc.size, len(keys)))
+		assert.Equal(t, tc.alg, keys[0].Algorithm, fmt.Sprintf("%s expected algorithm %s, got %s", tc.desc, tc.alg, keys[0].Algorithm))
+	}
+}
+
+func TestAsymmetricParse(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+
+	oldTokenizer, err := jwt.NewAsymmetric(oldKey)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric(newKey, oldKey.Public())
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	otherTokenizer, err := jwt.NewAsymmetric(otherKey)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	token, err := tokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	oldToken, err := oldTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	otherToken, err := otherTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	hmacToken, err := jwt.New(secret).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		key   authn.Key
+		token string
+		err   error
+	}{
+		{
+			desc:  "parse token signed with signing key",
+			key:   key(),
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "parse token signed with previous signing key",
+			key:   key(),
+			token: oldToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse token signed with unknown key",
+			key:   authn.Key{},
+			token: otherToken,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "parse token signed with shared secret",
+			key:   authn.Key{},
+			token: hmacToken,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "parse invalid token",
+			key:   authn.Key{},
+			token: "invalid",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := tokenizer.Parse(tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating ECDSA key expected to succeed: %s", err))
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	require.Nil(t, err, fmt.Sprintf("encoding ECDSA key expected to succeed: %s", err))
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.Nil(t, err, fmt.Sprintf("encoding ECDSA key expected to succeed: %s", err))
+	pkix, err := x509.MarshalPKIXPublicKey(ecKey.Public())
+	require.Nil(t, err, fmt.Sprintf("encoding ECDSA public key expected to succeed: %s", err))
+
+	sec1PEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})
+	pkcs8PEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	pkixPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})
+
+	cases := []struct {
+		desc    string
+		data    []byte
+		private bool
+		err     error
+	}{
+		{
+			desc:    "parse SEC 1 private key",
+			data:    sec1PEM,
+			private: true,
+			err:     nil,
+		},
+		{
+			desc:    "parse PKCS #8 private key",
+			data:    pkcs8PEM,
+			private: true,
+			err:     nil,
+		},
+		{
+			desc:    "parse public key as private key",
+			data:    pkixPEM,
+			private: true,
+			err:     jwt.ErrInvalidPEM,
+		},
+		{
+			desc: "parse PKIX public key",
+			data: pkixPEM,
+			err:  nil,
+		},
+		{
+			desc: "parse public key from private key",
+			data: sec1PEM,
+			err:  nil,
+		},
+		{
+			desc: "parse invalid PEM",
+			data: []byte("invalid"),
+			err:  jwt.ErrInvalidPEM,
+		},
+	}
+
+	for _, tc := range cases {
+		var pub crypto.PublicKey
+		if tc.private {
+			var key crypto.PrivateKey
+			key, err = jwt.ParsePrivateKey(tc.data)
+			if err == nil {
+				pub = key.(crypto.Signer).Public()
+			}
+		} else {
+			pub, err = jwt.ParsePublicKey(tc.data)
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, ecKey.Public(), pub, fmt.Sprintf("%s expected parsed key to match", tc.desc))
+		}
+	}
+}
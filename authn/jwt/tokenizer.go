@@ -17,7 +17,7 @@ type claims struct {
 }
 
 func (c claims) Valid() error {
-	if c.Type == nil || *c.Type > authn.APIKey {
+	if c.Type == nil || *c.Type > authn.RefreshKey {
 		return authn.ErrMalformedEntity
 	}
 
@@ -28,40 +28,33 @@ type tokenizer struct {
 	secret string
 }
 
-// New returns new JWT Tokenizer.
+// New returns new JWT Tokenizer which signs the tokens with the shared secret
+// using HS256.
 func New(secret string) authn.Tokenizer {
 	return tokenizer{secret: secret}
 }
 
 func (svc tokenizer) Issue(key authn.Key) (string, error) {
-	claims := claims{
-		StandardClaims: jwt.StandardClaims{
-			Issuer:   key.Issuer,
-			Subject:  key.Secret,
-			IssuedAt: key.IssuedAt.UTC().Unix(),
-		},
-		Type: &key.Type,
-	}
-
-	if !key.ExpiresAt.IsZero() {
-		claims.ExpiresAt = key.ExpiresAt.UTC().Unix()
-	}
-	if key.ID != "" {
-		claims.Id = key.ID
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims(key))
 	return token.SignedString([]byte(svc.secret))
 }
 
 func (svc tokenizer) Parse(token string) (authn.Key, error) {
-	c := claims{}
-	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (interface{}, error) {
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, authn.ErrUnauthorizedAccess
 		}
 		return []byte(svc.secret), nil
 	})
+}
+
+func (svc tokenizer) PublicKeys() []authn.PublicKey {
+	return nil
+}
+
+func parse(token string, keyFunc jwt.Keyfunc) (authn.Key, error) {
+	c := claims{}
+	_, err := jwt.ParseWithClaims(token, &c, keyFunc)
 
 	if err != nil {
 		if e, ok := err.(*jwt.ValidationError); ok && e.Errors == jwt.ValidationErrorExpired {
@@ -77,6 +70,26 @@ func (svc tokenizer) Parse(token string) (authn.Key, error) {
 	return c.toKey(), nil
 }
 
+func newClaims(key authn.Key) claims {
+	claims := claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:   key.Issuer,
+			Subject:  key.Secret,
+			IssuedAt: key.IssuedAt.UTC().Unix(),
+		},
+		Type: &key.Type,
+	}
+
+	if !key.ExpiresAt.IsZero() {
+		claims.ExpiresAt = key.ExpiresAt.UTC().Unix()
+	}
+	if key.ID != "" {
+		claims.Id = key.ID
+	}
+
+	return claims
+}
+
 func (c claims) toKey() authn.Key {
 	key := authn.Key{
 		ID:       c.Id,
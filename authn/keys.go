@@ -15,6 +15,10 @@ var (
 
 	// ErrKeyExpired indicates that the Key is expired.
 	ErrKeyExpired = errors.New("use of expired key")
+
+	// ErrInsufficientScope indicates that the Key is not granted the scope
+	// required by the operation.
+	ErrInsufficientScope = errors.New("insufficient key scope")
 )
 
 const (
@@ -24,6 +28,10 @@ const (
 	RecoveryKey
 	// APIKey enables the one to act on behalf of the user.
 	APIKey
+	// RefreshKey is used to issue the new User key once it expires. It's
+	// persisted, and it represents the login session the User keys issued
+	// along with it belong to.
+	RefreshKey
 )
 
 // Key represents API key.
@@ -34,6 +42,26 @@ type Key struct {
 	Secret    string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
+
+	// Scopes limit the operations API key can be used for. API key without
+	// scopes can be used for any operation on behalf of the issuer.
+	Scopes []string
+
+	// LastUsedAt is the time when API key was last used.
+	LastUsedAt time.Time
+
+	// Refresh is the refresh token issued along with the User key. Like
+	// Secret, it's never persisted.
+	Refresh string
+}
+
+// KeyPage contains page related metadata as well as a list of keys that
+// belong to this page.
+type KeyPage struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Keys   []Key
 }
 
 // Expired verifies if the key is expired.
@@ -53,6 +81,19 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(context.Context, string, string) (Key, error)
 
-	// Remove removes Key with provided ID.
+	// RetrieveAll retrieves the subset of API keys issued by the specified
+	// issuer.
+	RetrieveAll(context.Context, string, uint64, uint64) (KeyPage, error)
+
+	// UpdateLastUsed updates the time when Key with provided ID was last
+	// used.
+	UpdateLastUsed(context.Context, string, string, time.Time) error
+
+	// Remove removes Key with provided ID. ErrNotFound is returned if
+	// there is no such Key, e.g. if it's already removed.
 	Remove(context.Context, string, string) error
+
+	// RemoveAll removes all the keys of the given type issued by the
+	// specified issuer.
+	RemoveAll(context.Context, string, uint32) error
 }
@@ -58,3 +58,127 @@ func TestExpired(t *testing.T) {
 		assert.Equal(t, tc.expired, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.expired, res))
 	}
 }
+
+func TestValidateScopes(t *testing.T) {
+	cases := []struct {
+		desc   string
+		scopes []string
+		err    error
+	}{
+		{
+			desc:   "validate no scopes",
+			scopes: nil,
+			err:    nil,
+		},
+		{
+			desc:   "validate resource scopes",
+			scopes: []string{"things:read", "channels:write"},
+			err:    nil,
+		},
+		{
+			desc:   "validate scope limited to resource ID",
+			scopes: []string{"channels:read:c5747f2f-2a7c-4fe1-b41a-51a5ae290945"},
+			err:    nil,
+		},
+		{
+			desc:   "validate scope of unknown resource",
+			scopes: []string{"users:read"},
+			err:    authn.ErrMalformedEntity,
+		},
+		{
+			desc:   "validate scope of messages",
+			scopes: []string{"messages:write"},
+			err:    authn.ErrMalformedEntity,
+		},
+		{
+			desc:   "validate scope of unknown action",
+			scopes: []string{"things:delete"},
+			err:    authn.ErrMalformedEntity,
+		},
+		{
+			desc:   "validate scope without action",
+			scopes: []string{"things"},
+			err:    authn.ErrMalformedEntity,
+		},
+		{
+			desc:   "validate scope with empty resource ID",
+			scopes: []string{"things:read:"},
+			err:    authn.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := authn.ValidateScopes(tc.scopes)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAllows(t *testing.T) {
+	chanID := "c5747f2f-2a7c-4fe1-b41a-51a5ae290945"
+	cases := []struct {
+		desc    string
+		key     authn.Key
+		scope   string
+		allowed bool
+	}{
+		{
+			desc:    "key without scopes allows full access",
+			key:     authn.Key{},
+			scope:   "",
+			allowed: true,
+		},
+		{
+			desc:    "key without scopes allows resource scope",
+			key:     authn.Key{},
+			scope:   "things:write",
+			allowed: true,
+		},
+		{
+			desc:    "scoped key doesn't allow full access",
+			key:     authn.Key{Scopes: []string{"things:read"}},
+			scope:   "",
+			allowed: false,
+		},
+		{
+			desc:    "scoped key allows granted scope",
+			key:     authn.Key{Scopes: []string{"things:read"}},
+			scope:   "things:read",
+			allowed: true,
+		},
+		{
+			desc:    "scoped key doesn't allow other action",
+			key:     authn.Key{Scopes: []string{"things:read"}},
+			scope:   "things:write",
+			allowed: false,
+		},
+		{
+			desc:    "scoped key allows granted scope of resource ID",
+			key:     authn.Key{Scopes: []string{"channels:read"}},
+			scope:   "channels:read:" + chanID,
+			allowed: true,
+		},
+		{
+			desc:    "key scoped to resource ID allows scope of the same ID",
+			key:     authn.Key{Scopes: []string{"channels:read:" + chanID}},
+			scope:   "channels:read:" + chanID,
+			allowed: true,
+		},
+		{
+			desc:    "key scoped to resource ID doesn't allow scope of other ID",
+			key:     authn.Key{Scopes: []string{"channels:read:" + chanID}},
+			scope:   "channels:read:other",
+			allowed: false,
+		},
+		{
+			desc:    "key scoped to resource ID doesn't allow scope of all resources",
+			key:     authn.Key{Scopes: []string{"channels:read:" + chanID}},
+			scope:   "channels:read",
+			allowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := tc.key.Allows(tc.scope)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/authn"
 )
@@ -45,11 +47,70 @@ func (krm *keyRepositoryMock) Retrieve(ctx context.Context, issuer, id string) (
 
 	return authn.Key{}, authn.ErrNotFound
 }
+func (krm *keyRepositoryMock) RetrieveAll(ctx context.Context, issuer string, offset, limit uint64) (authn.KeyPage, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	keys := []authn.Key{}
+	for _, key := range krm.keys {
+		if key.Issuer == issuer && key.Type == authn.APIKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	page := authn.KeyPage{
+		Total:  uint64(len(keys)),
+		Offset: offset,
+		Limit:  limit,
+		Keys:   []authn.Key{},
+	}
+	if offset >= uint64(len(keys)) {
+		return page, nil
+	}
+	end := offset + limit
+	if end > uint64(len(keys)) {
+		end = uint64(len(keys))
+	}
+	page.Keys = keys[offset:end]
+
+	return page, nil
+}
+
+func (krm *keyRepositoryMock) UpdateLastUsed(ctx context.Context, issuer, id string, lastUsed time.Time) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	key, ok := krm.keys[id]
+	if !ok || key.Issuer != issuer {
+		return authn.ErrNotFound
+	}
+	key.LastUsedAt = lastUsed
+	krm.keys[id] = key
+
+	return nil
+}
+
 func (krm *keyRepositoryMock) Remove(ctx context.Context, issuer, id string) error {
 	krm.mu.Lock()
 	defer krm.mu.Unlock()
 	if key, ok := krm.keys[id]; ok && key.Issuer == issuer {
 		delete(krm.keys, id)
+		return nil
+	}
+	return authn.ErrNotFound
+}
+
+func (krm *keyRepositoryMock) RemoveAll(ctx context.Context, issuer string, keyType uint32) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for id, key := range krm.keys {
+		if key.Issuer == issuer && key.Type == keyType {
+			delete(krm.keys, id)
+		}
 	}
 	return nil
 }
@@ -57,6 +57,14 @@ func migrateDB(db *sqlx.DB) error {
 				},
 				Down: []string{"DROP TABLE IF EXISTS keys"},
 			},
+			{
+				Id: "authn_2",
+				Up: []string{
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS scopes TEXT[]`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS keys_issuer_idx ON keys (issuer, issued_at)`,
+				},
+			},
 		},
 	}
 
@@ -13,6 +13,7 @@ import (
 var (
 	errSave     = errors.New("failed to save key in database")
 	errRetrieve = errors.New("failed to retrieve key from database")
+	errUpdate   = errors.New("failed to update key in database")
 	errDelete   = errors.New("failed to delete key from database")
 )
 var _ authn.KeyRepository = (*repo)(nil)
@@ -34,8 +35,8 @@ func New(db Database) authn.KeyRepository {
 }
 
 func (kr repo) Save(ctx context.Context, key authn.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer, issued_at, expires_at, scopes)
+	      VALUES (:id, :type, :issuer, :issued_at, :expires_at, :scopes)`
 
 	dbKey := toDBKey(key)
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
@@ -54,7 +55,7 @@ func (kr repo) Save(ctx context.Context, key authn.Key) (string, error) {
 }
 
 func (kr repo) Retrieve(ctx context.Context, issuer, id string) (authn.Key, error) {
-	q := `SELECT id, type, issuer, issued_at, expires_at FROM keys WHERE issuer = $1 AND id = $2`
+	q := `SELECT id, type, issuer, issued_at, expires_at, scopes, last_used_at FROM keys WHERE issuer = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuer, id).StructScan(&key); err != nil {
 		pqErr, ok := err.(*pq.Error)
@@ -68,12 +69,89 @@ func (kr repo) Retrieve(ctx context.Context, issuer, id string) (authn.Key, erro
 	return toKey(key), nil
 }
 
+func (kr repo) RetrieveAll(ctx context.Context, issuer string, offset, limit uint64) (authn.KeyPage, error) {
+	q := `SELECT id, type, issuer, issued_at, expires_at, scopes, last_used_at FROM keys
+	      WHERE issuer = :issuer AND type = :type ORDER BY issued_at, id LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"issuer": issuer,
+		"type":   authn.APIKey,
+		"limit":  limit,
+		"offset": offset,
+	}
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	keys := []authn.Key{}
+	for rows.Next() {
+		key := dbKey{}
+		if err := rows.StructScan(&key); err != nil {
+			return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+		}
+		keys = append(keys, toKey(key))
+	}
+
+	cq := `SELECT COUNT(*) FROM keys WHERE issuer = $1 AND type = $2`
+	var total uint64
+	if err := kr.db.QueryRowxContext(ctx, cq, issuer, authn.APIKey).Scan(&total); err != nil {
+		return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	page := authn.KeyPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Keys:   keys,
+	}
+
+	return page, nil
+}
+
+func (kr repo) UpdateLastUsed(ctx context.Context, issuer, id string, lastUsed time.Time) error {
+	q := `UPDATE keys SET last_used_at = :last_used_at WHERE issuer = :issuer AND id = :id`
+	key := dbKey{
+		ID:         id,
+		Issuer:     issuer,
+		LastUsedAt: sql.NullTime{Time: lastUsed, Valid: true},
+	}
+	if _, err := kr.db.NamedExecContext(ctx, q, key); err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+
+	return nil
+}
+
 func (kr repo) Remove(ctx context.Context, issuer, id string) error {
 	q := `DELETE FROM keys WHERE issuer = :issuer AND id = :id`
 	key := dbKey{
 		ID:     id,
 		Issuer: issuer,
 	}
+	res, err := kr.db.NamedExecContext(ctx, q, key)
+	if err != nil {
+		return errors.Wrap(errDelete, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errDelete, err)
+	}
+	if cnt == 0 {
+		return authn.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr repo) RemoveAll(ctx context.Context, issuer string, keyType uint32) error {
+	q := `DELETE FROM keys WHERE issuer = :issuer AND type = :type`
+	key := dbKey{
+		Issuer: issuer,
+		Type:   keyType,
+	}
 	if _, err := kr.db.NamedExecContext(ctx, q, key); err != nil {
 		return errors.Wrap(errDelete, err)
 	}
@@ -82,12 +160,14 @@ func (kr repo) Remove(ctx context.Context, issuer, id string) error {
 }
 
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	Issuer    string       `db:"issuer"`
-	Revoked   bool         `db:"revoked"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at"`
+	ID         string         `db:"id"`
+	Type       uint32         `db:"type"`
+	Issuer     string         `db:"issuer"`
+	Revoked    bool           `db:"revoked"`
+	IssuedAt   time.Time      `db:"issued_at"`
+	ExpiresAt  sql.NullTime   `db:"expires_at"`
+	Scopes     pq.StringArray `db:"scopes"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
 }
 
 func toDBKey(key authn.Key) dbKey {
@@ -96,10 +176,14 @@ func toDBKey(key authn.Key) dbKey {
 		Type:     key.Type,
 		Issuer:   key.Issuer,
 		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
 	}
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
+	if !key.LastUsedAt.IsZero() {
+		ret.LastUsedAt = sql.NullTime{Time: key.LastUsedAt, Valid: true}
+	}
 
 	return ret
 }
@@ -110,10 +194,14 @@ func toKey(key dbKey) authn.Key {
 		Type:     key.Type,
 		Issuer:   key.Issuer,
 		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
 	}
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.LastUsedAt.Valid {
+		ret.LastUsedAt = key.LastUsedAt.Time
+	}
 
 	return ret
 }
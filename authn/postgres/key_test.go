@@ -135,7 +135,7 @@ func TestKeyRemove(t *testing.T) {
 			desc:   "remove key that does not exist",
 			id:     key.ID,
 			issuer: key.Issuer,
-			err:    nil,
+			err:    authn.ErrNotFound,
 		},
 	}
 
@@ -144,3 +144,136 @@ func TestKeyRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestKeyRemoveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-remove-all@example.com"
+	keys := map[uint32][]string{}
+	for _, keyType := range []uint32{authn.APIKey, authn.RefreshKey, authn.RefreshKey} {
+		id, _ := uuidProvider.New().ID()
+		key := authn.Key{
+			Type:      keyType,
+			Issuer:    email,
+			IssuedAt:  time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+			ID:        id,
+		}
+		_, err := repo.Save(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+		keys[keyType] = append(keys[keyType], id)
+	}
+
+	err := repo.RemoveAll(context.Background(), email, authn.RefreshKey)
+	assert.Nil(t, err, fmt.Sprintf("Removing keys expected to succeed: %s", err))
+
+	for _, id := range keys[authn.RefreshKey] {
+		_, err := repo.Retrieve(context.Background(), email, id)
+		assert.True(t, errors.Contains(err, authn.ErrNotFound), fmt.Sprintf("retrieve removed key: expected %s got %s\n", authn.ErrNotFound, err))
+	}
+	for _, id := range keys[authn.APIKey] {
+		_, err := repo.Retrieve(context.Background(), email, id)
+		assert.Nil(t, err, fmt.Sprintf("retrieve key of other type: expected no error got %s\n", err))
+	}
+}
+
+func TestKeyRetrieveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-retrieve-all@example.com"
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		id, _ := uuidProvider.New().ID()
+		key := authn.Key{
+			Type:     authn.APIKey,
+			Issuer:   email,
+			IssuedAt: time.Now(),
+			ID:       id,
+			Scopes:   []string{"things:read"},
+		}
+		_, err := repo.Save(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	// Login sessions are not listed.
+	id, _ := uuidProvider.New().ID()
+	session := authn.Key{
+		Type:      authn.RefreshKey,
+		Issuer:    email,
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+		ID:        id,
+	}
+	_, err := repo.Save(context.Background(), session)
+	assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		issuer string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		{
+			desc:   "retrieve all keys",
+			issuer: email,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		{
+			desc:   "retrieve subset of keys",
+			issuer: email,
+			offset: n / 2,
+			limit:  n,
+			size:   n / 2,
+			total:  n,
+		},
+		{
+			desc:   "retrieve keys of non-existing issuer",
+			issuer: "wrong@example.com",
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.issuer, tc.offset, tc.limit)
+		size := uint64(len(page.Keys))
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+		for _, k := range page.Keys {
+			assert.Equal(t, []string{"things:read"}, k.Scopes, fmt.Sprintf("%s: expected scopes %v got %v\n", tc.desc, []string{"things:read"}, k.Scopes))
+		}
+	}
+}
+
+func TestKeyUpdateLastUsed(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-update@example.com"
+	id, _ := uuidProvider.New().ID()
+	key := authn.Key{
+		Issuer:   email,
+		IssuedAt: time.Now(),
+		ID:       id,
+	}
+	_, err := repo.Save(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	lastUsed := time.Now().UTC().Round(time.Millisecond)
+	err = repo.UpdateLastUsed(context.Background(), email, id, lastUsed)
+	assert.Nil(t, err, fmt.Sprintf("Updating Key expected to succeed: %s", err))
+
+	k, err := repo.Retrieve(context.Background(), email, id)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving Key expected to succeed: %s", err))
+	assert.True(t, lastUsed.Equal(k.LastUsedAt), fmt.Sprintf("expected last used time %s got %s\n", lastUsed, k.LastUsedAt))
+}
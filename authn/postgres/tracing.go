@@ -21,6 +21,7 @@ type database struct {
 type Database interface {
 	NamedExecContext(context.Context, string, interface{}) (sql.Result, error)
 	QueryRowxContext(context.Context, string, ...interface{}) *sqlx.Row
+	NamedQueryContext(context.Context, string, interface{}) (*sqlx.Rows, error)
 }
 
 // NewDatabase creates a ThingDatabase instance
@@ -40,6 +41,11 @@ func (d database) QueryRowxContext(ctx context.Context, query string, args ...in
 	return d.db.QueryRowxContext(ctx, query, args...)
 }
 
+func (d database) NamedQueryContext(ctx context.Context, query string, args interface{}) (*sqlx.Rows, error) {
+	addSpanTags(ctx, query)
+	return d.db.NamedQueryContext(ctx, query, args)
+}
+
 func addSpanTags(ctx context.Context, query string) {
 	span := opentracing.SpanFromContext(ctx)
 	if span != nil {
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package authn
+
+import "strings"
+
+// Scope actions.
+const (
+	ReadAction  = "read"
+	WriteAction = "write"
+)
+
+// Resources API key can be scoped to.
+var resources = map[string]bool{
+	"things":   true,
+	"channels": true,
+}
+
+// ValidateScopes checks whether the scopes are well formed. Scope has the
+// format <resource>:<action>[:<id>], e.g. things:read, channels:write, or
+// channels:read:<channel_id> which is limited to the channel with the given
+// ID. Resource is either things or channels, and action is either read or
+// write.
+func ValidateScopes(scopes []string) error {
+	for _, s := range scopes {
+		if _, _, _, ok := parseScope(s); !ok {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+// Allows checks whether Key is granted the scope required by the operation.
+// API key without scopes is granted any scope. Scope limited to the resource
+// ID is granted by the scope of the same resource and action, either for all
+// the resources or for the resource with that ID. Empty scope stands for the
+// operation which requires the full access, so it's granted only to the keys
+// without scopes.
+func (k Key) Allows(scope string) bool {
+	if len(k.Scopes) == 0 {
+		return true
+	}
+
+	res, act, id, ok := parseScope(scope)
+	if !ok {
+		return false
+	}
+
+	for _, s := range k.Scopes {
+		kres, kact, kid, ok := parseScope(s)
+		if !ok || kres != res || kact != act {
+			continue
+		}
+		if kid == "" || kid == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseScope(scope string) (string, string, string, bool) {
+	parts := strings.Split(scope, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", "", false
+	}
+
+	res, act := parts[0], parts[1]
+	if !resources[res] || (act != ReadAction && act != WriteAction) {
+		return "", "", "", false
+	}
+
+	id := ""
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", "", false
+		}
+		id = parts[2]
+	}
+
+	return res, act, id, true
+}
@@ -13,8 +13,13 @@ import (
 
 const (
 	loginDuration    = 10 * time.Hour
+	refreshDuration  = 7 * 24 * time.Hour
 	recoveryDuration = 5 * time.Minute
 	issuerName       = "mainflux.authn"
+
+	// lastUsedInterval is the precision of API key last used time, so the
+	// key isn't updated on every use.
+	lastUsedInterval = time.Minute
 )
 
 var (
@@ -31,19 +36,38 @@ var (
 	// ErrConflict indicates that entity already exists.
 	ErrConflict = errors.New("entity already exists")
 
-	errIssueUser = errors.New("failed to issue new user key")
-	errIssueTmp  = errors.New("failed to issue new temporary key")
-	errRevoke    = errors.New("failed to remove key")
-	errRetrieve  = errors.New("failed to retrieve key data")
-	errIdentify  = errors.New("failed to validate token")
+	errIssueUser  = errors.New("failed to issue new user key")
+	errIssueTmp   = errors.New("failed to issue new temporary key")
+	errIssueLogin = errors.New("failed to issue new login key")
+	errRefresh    = errors.New("failed to refresh login key")
+	errRevoke     = errors.New("failed to remove key")
+	errRetrieve   = errors.New("failed to retrieve key data")
+	errList       = errors.New("failed to list keys")
+	errIdentify   = errors.New("failed to validate token")
+	errLogout     = errors.New("failed to end login session")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
-	// Issue issues a new Key.
+	// Issue issues a new Key. Issuing the User key starts the new login
+	// session, and the refresh token of the session is returned along with
+	// the key.
 	Issue(context.Context, string, Key) (Key, error)
 
+	// Refresh issues the new User key for the provided refresh token. Refresh
+	// token is rotated, so the login session continues with the new refresh
+	// token returned along with the key, and the used one is revoked.
+	Refresh(context.Context, string) (Key, error)
+
+	// Logout ends the login session of the provided User key, which revokes
+	// the key and its refresh token.
+	Logout(context.Context, string) error
+
+	// RevokeSessions ends all the login sessions of the user identified by
+	// the provided User key.
+	RevokeSessions(context.Context, string) error
+
 	// Revoke removes the Key with the provided id that is
 	// issued by the user identified by the provided key.
 	Revoke(context.Context, string, string) error
@@ -52,10 +76,20 @@ type Service interface {
 	// ID, that is issued by the user identified by the provided key.
 	Retrieve(context.Context, string, string) (Key, error)
 
-	// Identify validates token token. If token is valid, content
-	// is returned. If token is invalid, or invocation failed for some
-	// other reason, non-nil error value is returned in response.
-	Identify(context.Context, string) (string, error)
+	// RetrieveAll retrieves the subset of API keys issued by the user
+	// identified by the provided key. Login sessions are not listed.
+	RetrieveAll(context.Context, string, uint64, uint64) (KeyPage, error)
+
+	// Identify validates token token, which must be granted the provided
+	// scope. Empty scope requires the token to be granted the full access.
+	// If token is valid, content is returned. If token is invalid, or
+	// invocation failed for some other reason, non-nil error value is
+	// returned in response.
+	Identify(context.Context, string, string) (string, error)
+
+	// PublicKeys returns the public keys the issued tokens can be verified
+	// with, or an empty list if tokens are signed with the shared secret.
+	PublicKeys(context.Context) []PublicKey
 }
 
 var _ Service = (*service)(nil)
@@ -85,23 +119,73 @@ func (svc service) Issue(ctx context.Context, issuer string, key Key) (Key, erro
 	case RecoveryKey:
 		return svc.tmpKey(issuer, recoveryDuration, key)
 	default:
-		return svc.tmpKey(issuer, loginDuration, key)
+		return svc.loginKey(ctx, issuer, key)
+	}
+}
+
+func (svc service) Refresh(ctx context.Context, token string) (Key, error) {
+	c, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return Key{}, errors.Wrap(errRefresh, err)
+	}
+	if c.Type != RefreshKey || c.Issuer != issuerName {
+		return Key{}, ErrUnauthorizedAccess
+	}
+
+	// Refresh token can be used only once, so the session it was issued for
+	// is ended and the new one is started. Only the refresh which actually
+	// removes the session succeeds, so the token can't be reused concurrently.
+	if err := svc.session(ctx, c); err != nil {
+		return Key{}, errors.Wrap(errRefresh, err)
+	}
+	if err := svc.keys.Remove(ctx, c.Secret, c.ID); err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return Key{}, errors.Wrap(errRefresh, ErrUnauthorizedAccess)
+		}
+		return Key{}, errors.Wrap(errRefresh, err)
+	}
+
+	return svc.loginKey(ctx, c.Secret, Key{Type: UserKey, IssuedAt: time.Now().UTC()})
+}
+
+func (svc service) Logout(ctx context.Context, token string) error {
+	c, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return errors.Wrap(errLogout, err)
+	}
+	if c.Type != UserKey || c.Issuer != issuerName || c.ID == "" {
+		return ErrUnauthorizedAccess
+	}
+	if err := svc.keys.Remove(ctx, c.Secret, c.ID); err != nil && !errors.Contains(err, ErrNotFound) {
+		return errors.Wrap(errLogout, err)
 	}
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, token string) error {
+	email, err := svc.login(ctx, token)
+	if err != nil {
+		return errors.Wrap(errLogout, err)
+	}
+	if err := svc.keys.RemoveAll(ctx, email, RefreshKey); err != nil {
+		return errors.Wrap(errLogout, err)
+	}
+	return nil
 }
 
 func (svc service) Revoke(ctx context.Context, issuer, id string) error {
-	email, err := svc.login(issuer)
+	email, err := svc.login(ctx, issuer)
 	if err != nil {
 		return errors.Wrap(errRevoke, err)
 	}
-	if err := svc.keys.Remove(ctx, email, id); err != nil {
+	if err := svc.keys.Remove(ctx, email, id); err != nil && !errors.Contains(err, ErrNotFound) {
 		return errors.Wrap(errRevoke, err)
 	}
 	return nil
 }
 
 func (svc service) Retrieve(ctx context.Context, issuer, id string) (Key, error) {
-	email, err := svc.login(issuer)
+	email, err := svc.login(ctx, issuer)
 	if err != nil {
 		return Key{}, errors.Wrap(errRetrieve, err)
 	}
@@ -109,7 +193,16 @@ func (svc service) Retrieve(ctx context.Context, issuer, id string) (Key, error)
 	return svc.keys.Retrieve(ctx, email, id)
 }
 
-func (svc service) Identify(ctx context.Context, token string) (string, error) {
+func (svc service) RetrieveAll(ctx context.Context, issuer string, offset, limit uint64) (KeyPage, error) {
+	email, err := svc.login(ctx, issuer)
+	if err != nil {
+		return KeyPage{}, errors.Wrap(errList, err)
+	}
+
+	return svc.keys.RetrieveAll(ctx, email, offset, limit)
+}
+
+func (svc service) Identify(ctx context.Context, token, scope string) (string, error) {
 	c, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return "", errors.Wrap(errIdentify, err)
@@ -126,8 +219,22 @@ func (svc service) Identify(ctx context.Context, token string) (string, error) {
 			svc.keys.Remove(ctx, c.Issuer, c.ID)
 			return "", ErrKeyExpired
 		}
+		if !k.Allows(scope) {
+			return "", ErrInsufficientScope
+		}
+		if now := time.Now().UTC(); now.Sub(k.LastUsedAt) >= lastUsedInterval {
+			svc.keys.UpdateLastUsed(ctx, c.Issuer, c.ID, now)
+		}
 		return c.Issuer, nil
-	case RecoveryKey, UserKey:
+	case UserKey:
+		if c.Issuer != issuerName {
+			return "", ErrUnauthorizedAccess
+		}
+		if err := svc.session(ctx, c); err != nil {
+			return "", err
+		}
+		return c.Secret, nil
+	case RecoveryKey:
 		if c.Issuer != issuerName {
 			return "", ErrUnauthorizedAccess
 		}
@@ -137,6 +244,10 @@ func (svc service) Identify(ctx context.Context, token string) (string, error) {
 	}
 }
 
+func (svc service) PublicKeys(ctx context.Context) []PublicKey {
+	return svc.tokenizer.PublicKeys()
+}
+
 func (svc service) tmpKey(issuer string, duration time.Duration, key Key) (Key, error) {
 	key.Secret = issuer
 	key.Issuer = issuerName
@@ -150,8 +261,66 @@ func (svc service) tmpKey(issuer string, duration time.Duration, key Key) (Key,
 	return key, nil
 }
 
+// loginKey starts the new login session of the user with the provided email.
+// Session is persisted as the Refresh key, and the User key is issued with the
+// same ID, so it's valid only as long as the session isn't ended.
+func (svc service) loginKey(ctx context.Context, email string, key Key) (Key, error) {
+	id, err := svc.uuidProvider.ID()
+	if err != nil {
+		return Key{}, errors.Wrap(errIssueLogin, err)
+	}
+
+	session := Key{
+		ID:        id,
+		Type:      RefreshKey,
+		Issuer:    email,
+		IssuedAt:  key.IssuedAt,
+		ExpiresAt: key.IssuedAt.Add(refreshDuration),
+	}
+	if _, err := svc.keys.Save(ctx, session); err != nil {
+		return Key{}, errors.Wrap(errIssueLogin, err)
+	}
+
+	refresh, err := svc.tmpKey(email, refreshDuration, session)
+	if err != nil {
+		return Key{}, errors.Wrap(errIssueLogin, err)
+	}
+
+	key.ID = id
+	key, err = svc.tmpKey(email, loginDuration, key)
+	if err != nil {
+		return Key{}, errors.Wrap(errIssueLogin, err)
+	}
+	key.Refresh = refresh.Secret
+
+	return key, nil
+}
+
+// session checks whether the login session the key belongs to is not ended.
+func (svc service) session(ctx context.Context, key Key) error {
+	// Keys issued without session can't be revoked, so they're not valid.
+	if key.ID == "" {
+		return ErrUnauthorizedAccess
+	}
+
+	s, err := svc.keys.Retrieve(ctx, key.Secret, key.ID)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if s.Type != RefreshKey {
+		return ErrUnauthorizedAccess
+	}
+	// Auto revoke expired session.
+	if s.Expired() {
+		svc.keys.Remove(ctx, key.Secret, key.ID)
+		return ErrKeyExpired
+	}
+
+	return nil
+}
+
 func (svc service) userKey(ctx context.Context, issuer string, key Key) (Key, error) {
-	email, err := svc.login(issuer)
+	email, err := svc.login(ctx, issuer)
 	if err != nil {
 		return Key{}, errors.Wrap(errIssueUser, err)
 	}
@@ -176,7 +345,7 @@ func (svc service) userKey(ctx context.Context, issuer string, key Key) (Key, er
 	return key, nil
 }
 
-func (svc service) login(token string) (string, error) {
+func (svc service) login(ctx context.Context, token string) (string, error) {
 	c, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return "", err
@@ -189,5 +358,8 @@ func (svc service) login(token string) (string, error) {
 	if c.Secret == "" {
 		return "", ErrUnauthorizedAccess
 	}
+	if err := svc.session(ctx, c); err != nil {
+		return "", err
+	}
 	return c.Secret, nil
 }
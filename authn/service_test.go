@@ -6,6 +6,7 @@ package authn_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -210,6 +212,76 @@ func TestRetrieve(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+func TestRetrieveAll(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+		assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	}
+
+	otherKey, err := svc.Issue(context.Background(), "other@example.com", authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		issuer string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+		err    error
+	}{
+		{
+			desc:   "retrieve all keys",
+			issuer: loginKey.Secret,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve subset of keys",
+			issuer: loginKey.Secret,
+			offset: 5,
+			limit:  n,
+			size:   n - 5,
+			total:  n,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve keys of other issuer",
+			issuer: otherKey.Secret,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve keys unauthorized",
+			issuer: "wrong",
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+			err:    authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.RetrieveAll(context.Background(), tc.issuer, tc.offset, tc.limit)
+		size := uint64(len(page.Keys))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	svc := newService()
 	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
@@ -228,11 +300,15 @@ func TestIdentify(t *testing.T) {
 	invalidKey, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: 22, IssuedAt: time.Now()})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
 
+	scopedKey, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Scopes: []string{"things:read", "channels:write:1"}})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped user key expected to succeed: %s", err))
+
 	cases := []struct {
-		desc string
-		key  string
-		id   string
-		err  error
+		desc  string
+		key   string
+		scope string
+		id    string
+		err   error
 	}{
 		{
 			desc: "identify login key",
@@ -246,6 +322,12 @@ func TestIdentify(t *testing.T) {
 			id:   email,
 			err:  nil,
 		},
+		{
+			desc: "identify refresh key",
+			key:  loginKey.Refresh,
+			id:   "",
+			err:  authn.ErrUnauthorizedAccess,
+		},
 		{
 			desc: "identify user key",
 			key:  userKey.Secret,
@@ -270,11 +352,266 @@ func TestIdentify(t *testing.T) {
 			id:   "",
 			err:  authn.ErrUnauthorizedAccess,
 		},
+		{
+			desc:  "identify user key with scope",
+			key:   userKey.Secret,
+			scope: "things:write",
+			id:    email,
+			err:   nil,
+		},
+		{
+			desc:  "identify scoped key with granted scope",
+			key:   scopedKey.Secret,
+			scope: "things:read:1",
+			id:    email,
+			err:   nil,
+		},
+		{
+			desc:  "identify scoped key with granted resource scope",
+			key:   scopedKey.Secret,
+			scope: "channels:write:1",
+			id:    email,
+			err:   nil,
+		},
+		{
+			desc:  "identify scoped key with scope of other resource",
+			key:   scopedKey.Secret,
+			scope: "channels:write:2",
+			id:    "",
+			err:   authn.ErrInsufficientScope,
+		},
+		{
+			desc:  "identify scoped key with scope of other action",
+			key:   scopedKey.Secret,
+			scope: "things:write",
+			id:    "",
+			err:   authn.ErrInsufficientScope,
+		},
+		{
+			desc:  "identify scoped key without scope",
+			key:   scopedKey.Secret,
+			scope: "",
+			id:    "",
+			err:   authn.ErrInsufficientScope,
+		},
 	}
 
 	for _, tc := range cases {
-		id, err := svc.Identify(context.Background(), tc.key)
+		id, err := svc.Identify(context.Background(), tc.key, tc.scope)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.id, id, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.id, id))
 	}
 }
+
+func TestIdentifyLastUsed(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	userKey, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	key, err := svc.Retrieve(context.Background(), loginKey.Secret, userKey.ID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving user key expected to succeed: %s", err))
+	assert.True(t, key.LastUsedAt.IsZero(), "unused key expected not to have last used time")
+
+	_, err = svc.Identify(context.Background(), userKey.Secret, "")
+	assert.Nil(t, err, fmt.Sprintf("Identifying user key expected to succeed: %s", err))
+
+	key, err = svc.Retrieve(context.Background(), loginKey.Secret, userKey.ID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving user key expected to succeed: %s", err))
+	assert.False(t, key.LastUsedAt.IsZero(), "used key expected to have last used time")
+}
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	assert.NotEmpty(t, loginKey.Refresh, "Issuing login key expected to issue refresh token")
+
+	refreshed, err := svc.Refresh(context.Background(), loginKey.Refresh)
+	assert.Nil(t, err, fmt.Sprintf("Refreshing login key expected to succeed: %s", err))
+	assert.NotEqual(t, loginKey.Refresh, refreshed.Refresh, "Refreshing login key expected to rotate refresh token")
+
+	id, err := svc.Identify(context.Background(), refreshed.Secret, "")
+	assert.Nil(t, err, fmt.Sprintf("Identifying refreshed login key expected to succeed: %s", err))
+	assert.Equal(t, email, id, fmt.Sprintf("Identifying refreshed login key expected %s got %s", email, id))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "refresh with used refresh token",
+			token: loginKey.Refresh,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with login key",
+			token: refreshed.Secret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with invalid token",
+			token: "invalid",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with refresh token",
+			token: refreshed.Refresh,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), loginKey.Secret, "")
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("Identifying login key of refreshed session expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+}
+
+func TestRefreshReuse(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	// Refresh the same token concurrently, e.g. replaying the stolen one.
+	n := 10
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Refresh(context.Background(), loginKey.Refresh)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	refreshed := 0
+	for err := range errs {
+		if err == nil {
+			refreshed++
+			continue
+		}
+		assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("reusing refresh token expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+	}
+	assert.Equal(t, 1, refreshed, fmt.Sprintf("expected refresh token to be used once got %d times\n", refreshed))
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	otherKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	apiKey, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "logout with API key",
+			token: apiKey.Secret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with refresh token",
+			token: loginKey.Refresh,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with invalid token",
+			token: "invalid",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with login key",
+			token: loginKey.Secret,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Logout(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), loginKey.Secret, "")
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("Identifying login key of ended session expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+
+	_, err = svc.Refresh(context.Background(), loginKey.Refresh)
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("Refreshing ended session expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+
+	_, err = svc.Identify(context.Background(), otherKey.Secret, "")
+	assert.Nil(t, err, fmt.Sprintf("Identifying login key of other session expected to succeed: %s", err))
+
+	_, err = svc.Identify(context.Background(), apiKey.Secret, "")
+	assert.Nil(t, err, fmt.Sprintf("Identifying API key expected to succeed: %s", err))
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc := newService()
+	loginKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	sessionKey, err := svc.Issue(context.Background(), email, authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	otherKey, err := svc.Issue(context.Background(), "other@example.com", authn.Key{Type: authn.UserKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	apiKey, err := svc.Issue(context.Background(), loginKey.Secret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	err = svc.RevokeSessions(context.Background(), apiKey.Secret)
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("Revoking sessions with API key expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+
+	err = svc.RevokeSessions(context.Background(), loginKey.Secret)
+	assert.Nil(t, err, fmt.Sprintf("Revoking sessions expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "identify login key of revoked session",
+			token: loginKey.Secret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify login key of other revoked session",
+			token: sessionKey.Secret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify login key of other user",
+			token: otherKey.Secret,
+			err:   nil,
+		},
+		{
+			desc:  "identify API key",
+			token: apiKey.Secret,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Identify(context.Background(), tc.token, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Refresh(context.Background(), sessionKey.Refresh)
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("Refreshing revoked session expected %s got %s\n", authn.ErrUnauthorizedAccess, err))
+}
@@ -3,6 +3,21 @@
 
 package authn
 
+import "crypto"
+
+// PublicKey represents the public key the tokens can be verified with.
+type PublicKey struct {
+	// ID is the key identifier set in the header of the signed tokens.
+	ID string
+
+	// Algorithm is the name of the algorithm the tokens are signed with,
+	// e.g. RS256 or ES256.
+	Algorithm string
+
+	// Key is the public key, either *rsa.PublicKey or *ecdsa.PublicKey.
+	Key crypto.PublicKey
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +25,9 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(string) (Key, error)
+
+	// PublicKeys returns the public keys the issued tokens can be verified
+	// with. Tokenizers which sign the tokens with the shared secret don't
+	// have the public keys.
+	PublicKeys() []PublicKey
 }
@@ -7,15 +7,19 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/authn"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveOp     = "save"
-	retrieveOp = "retrieve_by_id"
-	revokeOp   = "remove"
+	saveOp           = "save"
+	retrieveOp       = "retrieve_by_id"
+	retrieveAllOp    = "retrieve_all"
+	updateLastUsedOp = "update_last_used"
+	revokeOp         = "remove"
+	revokeAllOp      = "remove_all"
 )
 
 var _ authn.KeyRepository = (*keyRepositoryMiddleware)(nil)
@@ -52,6 +56,22 @@ func (krm keyRepositoryMiddleware) Retrieve(ctx context.Context, owner, id strin
 	return krm.repo.Retrieve(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (authn.KeyPage, error) {
+	span := createSpan(ctx, krm.tracer, retrieveAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveAll(ctx, owner, offset, limit)
+}
+
+func (krm keyRepositoryMiddleware) UpdateLastUsed(ctx context.Context, owner, id string, lastUsed time.Time) error {
+	span := createSpan(ctx, krm.tracer, updateLastUsedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.UpdateLastUsed(ctx, owner, id, lastUsed)
+}
+
 func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
 	span := createSpan(ctx, krm.tracer, revokeOp)
 	defer span.Finish()
@@ -60,6 +80,14 @@ func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string)
 	return krm.repo.Remove(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RemoveAll(ctx context.Context, owner string, keyType uint32) error {
+	span := createSpan(ctx, krm.tracer, revokeAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RemoveAll(ctx, owner, keyType)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
@@ -32,6 +32,8 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			ClientKey:   req.ClientKey,
 			CACert:      req.CACert,
 			Content:     req.Content,
+			TemplateID:  req.TemplateID,
+			Format:      req.Format,
 		}
 
 		saved, err := svc.Add(req.token, config)
@@ -48,6 +50,49 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
+func addBulkEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(addBulkReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		channels := []bootstrap.Channel{}
+		for _, c := range req.channels {
+			channels = append(channels, bootstrap.Channel{ID: c})
+		}
+
+		var configs []bootstrap.Config
+		for _, c := range req.configs {
+			configs = append(configs, bootstrap.Config{
+				MFThing:     c.ThingID,
+				ExternalID:  c.ExternalID,
+				ExternalKey: c.ExternalKey,
+				MFChannels:  channels,
+				Name:        c.Name,
+				Content:     c.Content,
+				TemplateID:  req.templateID,
+				Format:      req.format,
+			})
+		}
+
+		saved, err := svc.AddBulk(req.token, configs)
+		if err != nil {
+			return nil, err
+		}
+
+		res := bulkRes{
+			Configs: []viewRes{},
+		}
+
+		for _, cfg := range saved {
+			res.Configs = append(res.Configs, toViewRes(cfg))
+		}
+
+		return res, nil
+	}
+}
+
 func updateCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	return func(_ context.Context, request interface{}) (interface{}, error) {
 		req := request.(updateCertReq)
@@ -78,27 +123,7 @@ func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		var channels []channelRes
-		for _, ch := range config.MFChannels {
-			channels = append(channels, channelRes{
-				ID:       ch.ID,
-				Name:     ch.Name,
-				Metadata: ch.Metadata,
-			})
-		}
-
-		res := viewRes{
-			MFThing:     config.MFThing,
-			MFKey:       config.MFKey,
-			Channels:    channels,
-			ExternalID:  config.ExternalID,
-			ExternalKey: config.ExternalKey,
-			Name:        config.Name,
-			Content:     config.Content,
-			State:       config.State,
-		}
-
-		return res, nil
+		return toViewRes(config), nil
 	}
 }
 
@@ -111,9 +136,11 @@ func updateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		}
 
 		config := bootstrap.Config{
-			MFThing: req.id,
-			Name:    req.Name,
-			Content: req.Content,
+			MFThing:    req.id,
+			Name:       req.Name,
+			Content:    req.Content,
+			TemplateID: req.TemplateID,
+			Format:     req.Format,
 		}
 
 		if err := svc.Update(req.key, config); err != nil {
@@ -170,26 +197,7 @@ func listEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		}
 
 		for _, cfg := range page.Configs {
-			var channels []channelRes
-			for _, ch := range cfg.MFChannels {
-				channels = append(channels, channelRes{
-					ID:       ch.ID,
-					Name:     ch.Name,
-					Metadata: ch.Metadata,
-				})
-			}
-
-			view := viewRes{
-				MFThing:     cfg.MFThing,
-				MFKey:       cfg.MFKey,
-				Channels:    channels,
-				ExternalID:  cfg.ExternalID,
-				ExternalKey: cfg.ExternalKey,
-				Name:        cfg.Name,
-				Content:     cfg.Content,
-				State:       cfg.State,
-			}
-			res.Configs = append(res.Configs, view)
+			res.Configs = append(res.Configs, toViewRes(cfg))
 		}
 
 		return res, nil
@@ -212,7 +220,91 @@ func removeEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
-func bootstrapEndpoint(svc bootstrap.Service, reader bootstrap.ConfigReader, secure bool) endpoint.Endpoint {
+func listVersionsEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(listVersionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListVersions(req.key, req.id, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listVersionsRes{
+			Total:    page.Total,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Versions: []versionRes{},
+		}
+
+		for _, ver := range page.Versions {
+			res.Versions = append(res.Versions, toVersionRes(ver))
+		}
+
+		return res, nil
+	}
+}
+
+func viewVersionEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewVersionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ver, err := svc.ViewVersion(req.key, req.id, req.version)
+		if err != nil {
+			return nil, err
+		}
+
+		return toVersionRes(ver), nil
+	}
+}
+
+func diffEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(diffReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		diff, err := svc.DiffVersions(req.key, req.id, req.from, req.to)
+		if err != nil {
+			return nil, err
+		}
+
+		res := diffRes{
+			From:            diff.From,
+			To:              diff.To,
+			Changed:         []string{},
+			Content:         diff.Content,
+			AddedChannels:   diff.AddedChannels,
+			RemovedChannels: diff.RemovedChannels,
+		}
+		res.Changed = append(res.Changed, diff.Changed...)
+
+		return res, nil
+	}
+}
+
+func rollbackEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(rollbackReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Rollback(req.key, req.id, req.Version); err != nil {
+			return nil, err
+		}
+
+		return rollbackRes{}, nil
+	}
+}
+
+func bootstrapEndpoint(svc bootstrap.Service, readers map[string]bootstrap.ConfigReader, secure bool) endpoint.Endpoint {
 	return func(_ context.Context, request interface{}) (interface{}, error) {
 		req := request.(bootstrapReq)
 		if err := req.validate(); err != nil {
@@ -224,8 +316,58 @@ func bootstrapEndpoint(svc bootstrap.Service, reader bootstrap.ConfigReader, sec
 			return nil, err
 		}
 
-		return reader.ReadConfig(cfg, secure)
+		return readConfig(readers, cfg, req.format, secure)
+	}
+}
+
+func certBootstrapEndpoint(svc bootstrap.Service, readers map[string]bootstrap.ConfigReader) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(certBootstrapReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		cfg, err := svc.CertBootstrap(req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return readConfig(readers, cfg, req.format, false)
+	}
+}
+
+// readConfig reads Config using the reader of the requested format. Format
+// requested by the Thing takes precedence over the Config one.
+func readConfig(readers map[string]bootstrap.ConfigReader, cfg bootstrap.Config, format string, secure bool) (bootstrapRes, error) {
+	if format == "" {
+		format = cfg.Format
+	}
+	if format == "" {
+		format = bootstrap.JSONFormat
+	}
+
+	reader, ok := readers[format]
+	if !ok {
+		return bootstrapRes{}, errNotAcceptable
+	}
+
+	data, err := reader.ReadConfig(cfg, secure)
+	if err != nil {
+		return bootstrapRes{}, err
 	}
+
+	ct := contentTypes[format]
+	// Encrypted response is binary, regardless of the format.
+	if secure {
+		ct = contentTypes[bootstrap.RawFormat]
+	}
+
+	res := bootstrapRes{
+		contentType: ct,
+		data:        data,
+	}
+
+	return res, nil
 }
 
 func stateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
@@ -243,3 +385,159 @@ func stateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		return stateRes{}, nil
 	}
 }
+
+func addTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(addTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tpl := bootstrap.Template{
+			Name:    req.Name,
+			Content: req.Content,
+		}
+
+		saved, err := svc.AddTemplate(req.token, tpl)
+		if err != nil {
+			return nil, err
+		}
+
+		res := templateRes{
+			id:      saved.ID,
+			created: true,
+		}
+
+		return res, nil
+	}
+}
+
+func viewTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tpl, err := svc.ViewTemplate(req.key, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := viewTemplateRes{
+			ID:      tpl.ID,
+			Name:    tpl.Name,
+			Content: tpl.Content,
+		}
+
+		return res, nil
+	}
+}
+
+func updateTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		tpl := bootstrap.Template{
+			ID:      req.id,
+			Name:    req.Name,
+			Content: req.Content,
+		}
+
+		if err := svc.UpdateTemplate(req.key, tpl); err != nil {
+			return nil, err
+		}
+
+		res := templateRes{
+			id:      req.id,
+			created: false,
+		}
+
+		return res, nil
+	}
+}
+
+func listTemplatesEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(listTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListTemplates(req.key, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listTemplatesRes{
+			Total:     page.Total,
+			Offset:    page.Offset,
+			Limit:     page.Limit,
+			Templates: []viewTemplateRes{},
+		}
+
+		for _, tpl := range page.Templates {
+			res.Templates = append(res.Templates, viewTemplateRes{
+				ID:      tpl.ID,
+				Name:    tpl.Name,
+				Content: tpl.Content,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func removeTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return removeRes{}, err
+		}
+
+		if err := svc.RemoveTemplate(req.key, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func toViewRes(cfg bootstrap.Config) viewRes {
+	var channels []channelRes
+	for _, ch := range cfg.MFChannels {
+		channels = append(channels, channelRes{
+			ID:       ch.ID,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+		})
+	}
+
+	return viewRes{
+		MFThing:        cfg.MFThing,
+		MFKey:          cfg.MFKey,
+		Channels:       channels,
+		ExternalID:     cfg.ExternalID,
+		ExternalKey:    cfg.ExternalKey,
+		Name:           cfg.Name,
+		Content:        cfg.Content,
+		TemplateID:     cfg.TemplateID,
+		Format:         cfg.Format,
+		State:          cfg.State,
+		Version:        cfg.Version,
+		FetchedVersion: cfg.FetchedVersion,
+	}
+}
+
+func toVersionRes(ver bootstrap.ConfigVersion) versionRes {
+	return versionRes{
+		Version:    ver.Version,
+		Content:    ver.Content,
+		TemplateID: ver.TemplateID,
+		Channels:   ver.Channels,
+		CreatedAt:  ver.CreatedAt,
+	}
+}
@@ -6,23 +6,33 @@ package api_test
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/bootstrap"
 	bsapi "github.com/mainflux/mainflux/bootstrap/api"
 	"github.com/mainflux/mainflux/bootstrap/mocks"
+	"github.com/mainflux/mainflux/logger"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	thingsapi "github.com/mainflux/mainflux/things/api/things/http"
 	"github.com/opentracing/opentracing-go/mocktracer"
@@ -37,6 +47,7 @@ const (
 	unknown        = "unknown"
 	channelsNum    = 3
 	contentType    = "application/json"
+	csvContentType = "text/csv"
 	wrongID        = "wrong_id"
 	addExternalID  = "external-id"
 	addExternalKey = "external-key"
@@ -46,6 +57,7 @@ const (
 
 var (
 	encKey      = []byte("1234567891011121")
+	testLog, _  = logger.New(os.Stdout, logger.Info.String())
 	addChannels = []string{"1"}
 	metadata    = map[string]interface{}{"meta": "data"}
 	addReq      = struct {
@@ -79,6 +91,14 @@ var (
 		CACert:     "newca",
 	}
 
+	templateReq = struct {
+		Name    string `json:"name"`
+		Content string `json:"content"`
+	}{
+		Name:    "template",
+		Content: "{{.ExternalID}}",
+	}
+
 	bsErrorRes           = toJSON(errorRes{bootstrap.ErrBootstrap.Error()})
 	unauthRes            = toJSON(errorRes{bootstrap.ErrUnauthorizedAccess.Error()})
 	malformedRes         = toJSON(errorRes{bootstrap.ErrMalformedEntity.Error()})
@@ -91,6 +111,7 @@ type testRequest struct {
 	method      string
 	url         string
 	contentType string
+	accept      string
 	token       string
 	body        io.Reader
 }
@@ -123,6 +144,10 @@ func (tr testRequest) make() (*http.Response, error) {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
 
+	if tr.accept != "" {
+		req.Header.Set("Accept", tr.accept)
+	}
+
 	return tr.client.Do(req)
 }
 
@@ -159,11 +184,12 @@ func dec(in []byte) ([]byte, error) {
 func newService(authn mainflux.AuthNServiceClient, url string) bootstrap.Service {
 	things := mocks.NewConfigsRepository()
 	config := mfsdk.Config{
-		BaseURL: url,
+		BaseURL:  url,
+		CertsURL: url + "/certs",
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(authn, things, sdk, encKey)
+	return bootstrap.New(authn, things, mocks.NewTemplatesRepository(), sdk, encKey, uuidProvider.NewMock(), testLog)
 }
 
 func generateChannels() map[string]things.Channel {
@@ -184,15 +210,98 @@ func newThingsService(authn mainflux.AuthNServiceClient) things.Service {
 }
 
 func newThingsServer(svc things.Service) *httptest.Server {
-	mux := thingsapi.MakeHandler(mocktracer.New(), svc)
+	mux := http.NewServeMux()
+	mux.Handle("/", thingsapi.MakeHandler(mocktracer.New(), svc))
+	mux.HandleFunc("/certs/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
 	return httptest.NewServer(mux)
 }
 
 func newBootstrapServer(svc bootstrap.Service) *httptest.Server {
-	mux := bsapi.MakeHandler(svc, bootstrap.NewConfigReader(encKey))
+	readers := map[string]bootstrap.ConfigReader{
+		bootstrap.JSONFormat: bootstrap.NewConfigReader(encKey),
+		bootstrap.TOMLFormat: bootstrap.NewTOMLReader(encKey),
+		bootstrap.YAMLFormat: bootstrap.NewYAMLReader(encKey),
+		bootstrap.RawFormat:  bootstrap.NewRawReader(encKey),
+	}
+	mux := bsapi.MakeHandler(svc, readers, bootstrap.CommonNameField)
 	return httptest.NewServer(mux)
 }
 
+func newCertBootstrapServer(svc bootstrap.Service, ca *x509.Certificate) *httptest.Server {
+	readers := map[string]bootstrap.ConfigReader{
+		bootstrap.JSONFormat: bootstrap.NewConfigReader(encKey),
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	ts := httptest.NewUnstartedServer(bsapi.MakeHandler(svc, readers, bootstrap.CommonNameField))
+	ts.TLS = &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	ts.StartTLS()
+	return ts
+}
+
+// newCertClient returns a client trusting the test server which presents
+// the given client certificates.
+func newCertClient(ts *httptest.Server, certs []tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      roots,
+				Certificates: certs,
+			},
+		},
+	}
+}
+
+// newCert issues a certificate with the given Common Name. The certificate is
+// self-signed CA certificate if the parent is nil.
+func newCert(cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
 func toJSON(data interface{}) string {
 	jsonData, _ := json.Marshal(data)
 	return string(jsonData)
@@ -322,6 +431,113 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestAddBulk(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	data := "external_id,external_key,name\nbulk-id-1,bulk-key-1,name 1\nbulk-id-2,bulk-key-2,name 2\n"
+
+	cases := []struct {
+		desc        string
+		req         string
+		auth        string
+		contentType string
+		query       string
+		status      int
+		count       int
+	}{
+		{
+			desc:        "add configs in bulk unauthorized",
+			req:         data,
+			auth:        invalidToken,
+			contentType: csvContentType,
+			query:       "channels=1",
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "add configs in bulk",
+			req:         data,
+			auth:        validToken,
+			contentType: csvContentType,
+			query:       "channels=1,2",
+			status:      http.StatusCreated,
+			count:       2,
+		},
+		{
+			desc:        "add existing configs in bulk",
+			req:         data,
+			auth:        validToken,
+			contentType: csvContentType,
+			query:       "channels=1",
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "add configs in bulk with wrong content type",
+			req:         data,
+			auth:        validToken,
+			contentType: contentType,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "add configs in bulk with invalid channels",
+			req:         "external_id,external_key\nbulk-id-3,bulk-key-3\n",
+			auth:        validToken,
+			contentType: csvContentType,
+			query:       fmt.Sprintf("channels=%s", wrongID),
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add configs in bulk with missing external key",
+			req:         "external_id,external_key\nbulk-id-3,\n",
+			auth:        validToken,
+			contentType: csvContentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add configs in bulk with unknown column",
+			req:         "external_id,external_key,unknown\nbulk-id-3,bulk-key-3,value\n",
+			auth:        validToken,
+			contentType: csvContentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add configs in bulk with malformed CSV",
+			req:         "external_id,external_key\nbulk-id-3,bulk-key-3,value\n",
+			auth:        validToken,
+			contentType: csvContentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add configs in bulk with an empty request",
+			req:         "",
+			auth:        validToken,
+			contentType: csvContentType,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      bs.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/configs/bulk?%s", bs.URL, tc.query),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body configPage
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.count, len(body.Configs), fmt.Sprintf("%s: expected %d configs got %d", tc.desc, tc.count, len(body.Configs)))
+	}
+}
+
 func TestView(t *testing.T) {
 	users := mocks.NewUsersService(map[string]string{validToken: email})
 
@@ -1059,6 +1275,7 @@ func TestBootstrap(t *testing.T) {
 		ClientCert string    `json:"client_cert"`
 		ClientKey  string    `json:"client_key"`
 		CACert     string    `json:"ca_cert"`
+		Version    uint64    `json:"version"`
 	}{
 		MFThing:    saved.MFThing,
 		MFKey:      saved.MFKey,
@@ -1067,6 +1284,7 @@ func TestBootstrap(t *testing.T) {
 		ClientCert: saved.ClientCert,
 		ClientKey:  saved.ClientKey,
 		CACert:     saved.CACert,
+		Version:    saved.Version,
 	}
 
 	data := toJSON(s)
@@ -1151,6 +1369,8 @@ func TestBootstrap(t *testing.T) {
 		body, err := ioutil.ReadAll(res.Body)
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		if tc.secure && tc.status == http.StatusOK {
+			ct := res.Header.Get("Content-Type")
+			assert.Equal(t, "application/octet-stream", ct, fmt.Sprintf("%s: expected content type application/octet-stream got %s", tc.desc, ct))
 			body, err = dec(body)
 		}
 
@@ -1159,6 +1379,222 @@ func TestBootstrap(t *testing.T) {
 	}
 }
 
+func TestBootstrapFormats(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	c := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	yc := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+	yc.ExternalID = "yaml-external-id"
+	yc.Format = bootstrap.YAMLFormat
+	ySaved, err := svc.Add(validToken, yc)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	read := func(r bootstrap.ConfigReader, id string) string {
+		cfg, err := svc.View(validToken, id)
+		require.Nil(t, err, fmt.Sprintf("Viewing config expected to succeed: %s.\n", err))
+		res, err := r.ReadConfig(cfg, false)
+		require.Nil(t, err, fmt.Sprintf("Reading config expected to succeed: %s.\n", err))
+		if b, ok := res.([]byte); ok {
+			return strings.Trim(string(b), "\n")
+		}
+		return toJSON(res)
+	}
+
+	cases := []struct {
+		desc        string
+		externalID  string
+		externalKey string
+		accept      string
+		status      int
+		contentType string
+		res         string
+	}{
+		{
+			desc:        "bootstrap with JSON accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "application/json",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			res:         read(bootstrap.NewConfigReader(encKey), saved.MFThing),
+		},
+		{
+			desc:        "bootstrap with TOML accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "application/toml",
+			status:      http.StatusOK,
+			contentType: "application/toml",
+			res:         read(bootstrap.NewTOMLReader(encKey), saved.MFThing),
+		},
+		{
+			desc:        "bootstrap with YAML accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "text/html, application/x-yaml;q=0.9",
+			status:      http.StatusOK,
+			contentType: "application/yaml",
+			res:         read(bootstrap.NewYAMLReader(encKey), saved.MFThing),
+		},
+		{
+			desc:        "bootstrap with raw content accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "application/octet-stream",
+			status:      http.StatusOK,
+			contentType: "application/octet-stream",
+			res:         c.Content,
+		},
+		{
+			desc:        "bootstrap with unsupported format accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "text/html",
+			status:      http.StatusNotAcceptable,
+			contentType: "application/json",
+			res:         toJSON(errorRes{"requested response format is not supported"}),
+		},
+		{
+			desc:        "bootstrap with any format accepted",
+			externalID:  c.ExternalID,
+			externalKey: c.ExternalKey,
+			accept:      "*/*",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			res:         read(bootstrap.NewConfigReader(encKey), saved.MFThing),
+		},
+		{
+			desc:        "bootstrap with Config format",
+			externalID:  yc.ExternalID,
+			externalKey: yc.ExternalKey,
+			status:      http.StatusOK,
+			contentType: "application/yaml",
+			res:         read(bootstrap.NewYAMLReader(encKey), ySaved.MFThing),
+		},
+		{
+			desc:        "bootstrap with Config format overridden",
+			externalID:  yc.ExternalID,
+			externalKey: yc.ExternalKey,
+			accept:      "application/json",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			res:         read(bootstrap.NewConfigReader(encKey), ySaved.MFThing),
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: bs.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/bootstrap/%s", bs.URL, tc.externalID),
+			token:  tc.externalKey,
+			accept: tc.accept,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, res.Header.Get("Content-Type")))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected response '%s' got '%s'", tc.desc, tc.res, data))
+	}
+}
+
+func TestCertBootstrap(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+
+	ca, caKey, err := newCert("ca", nil, nil)
+	require.Nil(t, err, fmt.Sprintf("Creating CA expected to succeed: %s.\n", err))
+	untrustedCA, untrustedKey, err := newCert("untrusted", nil, nil)
+	require.Nil(t, err, fmt.Sprintf("Creating CA expected to succeed: %s.\n", err))
+
+	bs := newCertBootstrapServer(svc, ca)
+	defer bs.Close()
+
+	c := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	cfg, err := svc.View(validToken, saved.MFThing)
+	require.Nil(t, err, fmt.Sprintf("Viewing config expected to succeed: %s.\n", err))
+	cfg.FetchedVersion = cfg.Version
+	res, err := bootstrap.NewConfigReader(encKey).ReadConfig(cfg, false)
+	require.Nil(t, err, fmt.Sprintf("Reading config expected to succeed: %s.\n", err))
+	data := toJSON(res)
+
+	cases := []struct {
+		desc   string
+		cn     string
+		ca     *x509.Certificate
+		caKey  *ecdsa.PrivateKey
+		status int
+		res    string
+	}{
+		{
+			desc:   "bootstrap with a valid certificate",
+			cn:     c.ExternalID,
+			ca:     ca,
+			caKey:  caKey,
+			status: http.StatusOK,
+			res:    data,
+		},
+		{
+			desc:   "bootstrap with a certificate of unknown Thing",
+			cn:     unknown,
+			ca:     ca,
+			caKey:  caKey,
+			status: http.StatusNotFound,
+			res:    bsErrorRes,
+		},
+		{
+			desc:   "bootstrap with a certificate issued by untrusted CA",
+			cn:     c.ExternalID,
+			ca:     untrustedCA,
+			caKey:  untrustedKey,
+			status: http.StatusForbidden,
+			res:    unauthRes,
+		},
+		{
+			desc:   "bootstrap without a certificate",
+			status: http.StatusForbidden,
+			res:    unauthRes,
+		},
+	}
+
+	for _, tc := range cases {
+		var certs []tls.Certificate
+		if tc.ca != nil {
+			cert, key, err := newCert(tc.cn, tc.ca, tc.caKey)
+			require.Nil(t, err, fmt.Sprintf("%s: creating certificate expected to succeed: %s", tc.desc, err))
+			certs = append(certs, tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert})
+		}
+		client := newCertClient(bs, certs)
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/bootstrap", bs.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected response '%s' got '%s'", tc.desc, tc.res, data))
+	}
+}
+
 func TestChangeState(t *testing.T) {
 	users := mocks.NewUsersService(map[string]string{validToken: email})
 
@@ -1173,6 +1609,7 @@ func TestChangeState(t *testing.T) {
 
 	inactive := fmt.Sprintf("{\"state\": %d}", bootstrap.Inactive)
 	active := fmt.Sprintf("{\"state\": %d}", bootstrap.Active)
+	suspended := fmt.Sprintf("{\"state\": %d}", bootstrap.Suspended)
 
 	cases := []struct {
 		desc        string
@@ -1222,6 +1659,14 @@ func TestChangeState(t *testing.T) {
 			contentType: contentType,
 			status:      http.StatusOK,
 		},
+		{
+			desc:        "change state from inactive to suspended",
+			id:          saved.MFThing,
+			auth:        validToken,
+			state:       suspended,
+			contentType: contentType,
+			status:      http.StatusConflict,
+		},
 		{
 			desc:        "change state of non-existing config",
 			id:          wrongID,
@@ -1263,22 +1708,458 @@ func TestChangeState(t *testing.T) {
 	}
 }
 
-type channel struct {
-	ID       string      `json:"id"`
-	Name     string      `json:"name,omitempty"`
-	Metadata interface{} `json:"metadata,omitempty"`
-}
+func TestAddTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
 
-type config struct {
-	MFThing     string          `json:"mainflux_id,omitempty"`
-	MFKey       string          `json:"mainflux_key,omitempty"`
-	Channels    []channel       `json:"mainflux_channels,omitempty"`
-	ExternalID  string          `json:"external_id"`
-	ExternalKey string          `json:"external_key,omitempty"`
-	Content     string          `json:"content,omitempty"`
-	Name        string          `json:"name"`
-	State       bootstrap.State `json:"state"`
-}
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	data := toJSON(templateReq)
+
+	cases := []struct {
+		desc        string
+		req         string
+		auth        string
+		contentType string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "add a template unauthorized",
+			req:         data,
+			auth:        invalidToken,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			location:    "",
+		},
+		{
+			desc:        "add a valid template",
+			req:         data,
+			auth:        validToken,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/things/templates/%s%012d", uuidProvider.Prefix, 1),
+		},
+		{
+			desc:        "add a template with wrong content type",
+			req:         data,
+			auth:        validToken,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			location:    "",
+		},
+		{
+			desc:        "add a template with invalid syntax",
+			req:         toJSON(map[string]string{"content": "{{.ExternalID"}),
+			auth:        validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add a template with empty JSON",
+			req:         "{}",
+			auth:        validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      bs.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/templates", bs.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location '%s' got '%s'", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	saved, err := svc.AddTemplate(validToken, bootstrap.Template{Name: templateReq.Name, Content: templateReq.Content})
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	data := template{
+		ID:      saved.ID,
+		Name:    saved.Name,
+		Content: saved.Content,
+	}
+
+	cases := []struct {
+		desc   string
+		auth   string
+		id     string
+		status int
+		res    template
+	}{
+		{
+			desc:   "view a template unauthorized",
+			auth:   invalidToken,
+			id:     saved.ID,
+			status: http.StatusForbidden,
+			res:    template{},
+		},
+		{
+			desc:   "view a template",
+			auth:   validToken,
+			id:     saved.ID,
+			status: http.StatusOK,
+			res:    data,
+		},
+		{
+			desc:   "view a non-existing template",
+			auth:   validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			res:    template{},
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: bs.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/templates/%s", bs.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var view template
+		if err := json.NewDecoder(res.Body).Decode(&view); err != io.EOF {
+			assert.Nil(t, err, fmt.Sprintf("Decoding expected to succeed %s: %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.res, view, fmt.Sprintf("%s: expected response '%v' got '%v'", tc.desc, tc.res, view))
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	saved, err := svc.AddTemplate(validToken, bootstrap.Template{Name: templateReq.Name, Content: templateReq.Content})
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "remove a template unauthorized",
+			id:     saved.ID,
+			auth:   invalidToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove an existing template",
+			id:     saved.ID,
+			auth:   validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed template",
+			id:     saved.ID,
+			auth:   validToken,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: bs.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/templates/%s", bs.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	c := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	saved.Content = "new content"
+	err = svc.Update(validToken, saved)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "list versions unauthorized",
+			url:    fmt.Sprintf("%s/things/configs/%s/versions", bs.URL, saved.MFThing),
+			auth:   invalidToken,
+			status: http.StatusForbidden,
+			total:  0,
+		},
+		{
+			desc:   "list versions of an existing config",
+			url:    fmt.Sprintf("%s/things/configs/%s/versions", bs.URL, saved.MFThing),
+			auth:   validToken,
+			status: http.StatusOK,
+			total:  2,
+		},
+		{
+			desc:   "list versions with invalid offset",
+			url:    fmt.Sprintf("%s/things/configs/%s/versions?offset=invalid", bs.URL, saved.MFThing),
+			auth:   validToken,
+			status: http.StatusBadRequest,
+			total:  0,
+		},
+		{
+			desc:   "list versions of a non-existing config",
+			url:    fmt.Sprintf("%s/things/configs/%s/versions", bs.URL, unknown),
+			auth:   validToken,
+			status: http.StatusNotFound,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: bs.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var page versionsPage
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	c := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	saved.Content = "new content"
+	err = svc.Update(validToken, saved)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc    string
+		url     string
+		auth    string
+		status  int
+		changed []string
+	}{
+		{
+			desc:    "diff versions unauthorized",
+			url:     fmt.Sprintf("%s/things/configs/%s/diff?from=1&to=2", bs.URL, saved.MFThing),
+			auth:    invalidToken,
+			status:  http.StatusForbidden,
+			changed: nil,
+		},
+		{
+			desc:    "diff existing versions",
+			url:     fmt.Sprintf("%s/things/configs/%s/diff?from=1&to=2", bs.URL, saved.MFThing),
+			auth:    validToken,
+			status:  http.StatusOK,
+			changed: []string{"content"},
+		},
+		{
+			desc:    "diff versions without a version",
+			url:     fmt.Sprintf("%s/things/configs/%s/diff?from=1", bs.URL, saved.MFThing),
+			auth:    validToken,
+			status:  http.StatusBadRequest,
+			changed: nil,
+		},
+		{
+			desc:    "diff versions with invalid version",
+			url:     fmt.Sprintf("%s/things/configs/%s/diff?from=1&to=invalid", bs.URL, saved.MFThing),
+			auth:    validToken,
+			status:  http.StatusBadRequest,
+			changed: nil,
+		},
+		{
+			desc:    "diff non-existing versions",
+			url:     fmt.Sprintf("%s/things/configs/%s/diff?from=1&to=5", bs.URL, saved.MFThing),
+			auth:    validToken,
+			status:  http.StatusNotFound,
+			changed: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: bs.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var d diff
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, tc.changed, d.Changed, fmt.Sprintf("%s: expected changed fields %v got %v", tc.desc, tc.changed, d.Changed))
+	}
+}
+
+func TestRollback(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	ts := newThingsServer(newThingsService(users))
+	svc := newService(users, ts.URL)
+	bs := newBootstrapServer(svc)
+
+	c := newConfig([]bootstrap.Channel{bootstrap.Channel{ID: "1"}})
+
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	modified := saved
+	modified.Content = "new content"
+	err = svc.Update(validToken, modified)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc        string
+		id          string
+		auth        string
+		contentType string
+		req         string
+		status      int
+	}{
+		{
+			desc:        "rollback unauthorized",
+			id:          saved.MFThing,
+			auth:        invalidToken,
+			contentType: contentType,
+			req:         `{"version": 1}`,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "rollback with invalid content type",
+			id:          saved.MFThing,
+			auth:        validToken,
+			contentType: "",
+			req:         `{"version": 1}`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "rollback without a version",
+			id:          saved.MFThing,
+			auth:        validToken,
+			contentType: contentType,
+			req:         "{}",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rollback with invalid request format",
+			id:          saved.MFThing,
+			auth:        validToken,
+			contentType: contentType,
+			req:         "}",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rollback to a non-existing version",
+			id:          saved.MFThing,
+			auth:        validToken,
+			contentType: contentType,
+			req:         `{"version": 5}`,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "rollback a non-existing config",
+			id:          unknown,
+			auth:        validToken,
+			contentType: contentType,
+			req:         `{"version": 1}`,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "rollback to an existing version",
+			id:          saved.MFThing,
+			auth:        validToken,
+			contentType: contentType,
+			req:         `{"version": 1}`,
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      bs.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/configs/%s/rollback", bs.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+type channel struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name,omitempty"`
+	Metadata interface{} `json:"metadata,omitempty"`
+}
+
+type config struct {
+	MFThing     string          `json:"mainflux_id,omitempty"`
+	MFKey       string          `json:"mainflux_key,omitempty"`
+	Channels    []channel       `json:"mainflux_channels,omitempty"`
+	ExternalID  string          `json:"external_id"`
+	ExternalKey string          `json:"external_key,omitempty"`
+	Content     string          `json:"content,omitempty"`
+	Name        string          `json:"name"`
+	State       bootstrap.State `json:"state"`
+}
 
 type configPage struct {
 	Total   uint64   `json:"total"`
@@ -1290,3 +2171,29 @@ type configPage struct {
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+type template struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content"`
+}
+
+type version struct {
+	Version  uint64   `json:"version"`
+	Content  string   `json:"content,omitempty"`
+	Channels []string `json:"mainflux_channels,omitempty"`
+}
+
+type versionsPage struct {
+	Total    uint64    `json:"total"`
+	Offset   uint64    `json:"offset"`
+	Limit    uint64    `json:"limit"`
+	Versions []version `json:"versions"`
+}
+
+type diff struct {
+	From    uint64   `json:"from"`
+	To      uint64   `json:"to"`
+	Changed []string `json:"changed"`
+	Content []string `json:"content,omitempty"`
+}
@@ -38,6 +38,19 @@ func (lm *loggingMiddleware) Add(token string, cfg bootstrap.Config) (saved boot
 	return lm.svc.Add(token, cfg)
 }
 
+func (lm *loggingMiddleware) AddBulk(token string, cfgs []bootstrap.Config) (saved []bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_bulk for token %s and %d configs took %s to complete", token, len(cfgs), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddBulk(token, cfgs)
+}
+
 func (lm *loggingMiddleware) View(token, id string) (saved bootstrap.Config, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view for token %s and thing %s took %s to complete", token, saved.MFThing, time.Since(begin))
@@ -116,6 +129,58 @@ func (lm *loggingMiddleware) Remove(token, id string) (err error) {
 	return lm.svc.Remove(token, id)
 }
 
+func (lm *loggingMiddleware) ListVersions(token, id string, offset, limit uint64) (page bootstrap.ConfigVersionsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_versions for token %s and thing %s and offset %d and limit %d took %s to complete", token, id, offset, limit, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListVersions(token, id, offset, limit)
+}
+
+func (lm *loggingMiddleware) ViewVersion(token, id string, version uint64) (ver bootstrap.ConfigVersion, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_version for token %s and thing %s and version %d took %s to complete", token, id, version, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewVersion(token, id, version)
+}
+
+func (lm *loggingMiddleware) DiffVersions(token, id string, from, to uint64) (diff bootstrap.VersionsDiff, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method diff_versions for token %s and thing %s from version %d to version %d took %s to complete", token, id, from, to, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DiffVersions(token, id, from, to)
+}
+
+func (lm *loggingMiddleware) Rollback(token, id string, version uint64) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method rollback for token %s and thing %s to version %d took %s to complete", token, id, version, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Rollback(token, id, version)
+}
+
 func (lm *loggingMiddleware) Bootstrap(externalKey, externalID string, secure bool) (cfg bootstrap.Config, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method bootstrap for thing with external id %s took %s to complete", externalID, time.Since(begin))
@@ -129,6 +194,19 @@ func (lm *loggingMiddleware) Bootstrap(externalKey, externalID string, secure bo
 	return lm.svc.Bootstrap(externalKey, externalID, secure)
 }
 
+func (lm *loggingMiddleware) CertBootstrap(externalID string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method cert_bootstrap for thing with external id %s took %s to complete", externalID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CertBootstrap(externalID)
+}
+
 func (lm *loggingMiddleware) ChangeState(token, id string, state bootstrap.State) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method change_state for token %s and thing %s took %s to complete", token, id, time.Since(begin))
@@ -142,6 +220,71 @@ func (lm *loggingMiddleware) ChangeState(token, id string, state bootstrap.State
 	return lm.svc.ChangeState(token, id, state)
 }
 
+func (lm *loggingMiddleware) AddTemplate(token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_template for token %s and template %s took %s to complete", token, saved.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddTemplate(token, tpl)
+}
+
+func (lm *loggingMiddleware) ViewTemplate(token, id string) (tpl bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_template for token %s and template %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewTemplate(token, id)
+}
+
+func (lm *loggingMiddleware) UpdateTemplate(token string, tpl bootstrap.Template) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_template for token %s and template %s took %s to complete", token, tpl.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateTemplate(token, tpl)
+}
+
+func (lm *loggingMiddleware) ListTemplates(token string, offset, limit uint64) (res bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_templates for token %s and offset %d and limit %d took %s to complete", token, offset, limit, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListTemplates(token, offset, limit)
+}
+
+func (lm *loggingMiddleware) RemoveTemplate(token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_template for token %s and template %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveTemplate(token, id)
+}
+
 func (lm *loggingMiddleware) UpdateChannelHandler(channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method update_channel_handler for channel %s took %s to complete", channel.ID, time.Since(begin))
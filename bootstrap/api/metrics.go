@@ -39,6 +39,15 @@ func (mm *metricsMiddleware) Add(token string, cfg bootstrap.Config) (saved boot
 	return mm.svc.Add(token, cfg)
 }
 
+func (mm *metricsMiddleware) AddBulk(token string, cfgs []bootstrap.Config) (saved []bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_bulk").Add(1)
+		mm.latency.With("method", "add_bulk").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AddBulk(token, cfgs)
+}
+
 func (mm *metricsMiddleware) View(token, id string) (saved bootstrap.Config, err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "view").Add(1)
@@ -93,6 +102,42 @@ func (mm *metricsMiddleware) Remove(token, id string) (err error) {
 	return mm.svc.Remove(token, id)
 }
 
+func (mm *metricsMiddleware) ListVersions(token, id string, offset, limit uint64) (page bootstrap.ConfigVersionsPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_versions").Add(1)
+		mm.latency.With("method", "list_versions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListVersions(token, id, offset, limit)
+}
+
+func (mm *metricsMiddleware) ViewVersion(token, id string, version uint64) (ver bootstrap.ConfigVersion, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_version").Add(1)
+		mm.latency.With("method", "view_version").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewVersion(token, id, version)
+}
+
+func (mm *metricsMiddleware) DiffVersions(token, id string, from, to uint64) (diff bootstrap.VersionsDiff, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "diff_versions").Add(1)
+		mm.latency.With("method", "diff_versions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DiffVersions(token, id, from, to)
+}
+
+func (mm *metricsMiddleware) Rollback(token, id string, version uint64) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rollback").Add(1)
+		mm.latency.With("method", "rollback").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Rollback(token, id, version)
+}
+
 func (mm *metricsMiddleware) Bootstrap(externalKey, externalID string, secure bool) (cfg bootstrap.Config, err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "bootstrap").Add(1)
@@ -102,6 +147,15 @@ func (mm *metricsMiddleware) Bootstrap(externalKey, externalID string, secure bo
 	return mm.svc.Bootstrap(externalKey, externalID, secure)
 }
 
+func (mm *metricsMiddleware) CertBootstrap(externalID string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "cert_bootstrap").Add(1)
+		mm.latency.With("method", "cert_bootstrap").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CertBootstrap(externalID)
+}
+
 func (mm *metricsMiddleware) ChangeState(token, id string, state bootstrap.State) (err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "change_state").Add(1)
@@ -111,6 +165,51 @@ func (mm *metricsMiddleware) ChangeState(token, id string, state bootstrap.State
 	return mm.svc.ChangeState(token, id, state)
 }
 
+func (mm *metricsMiddleware) AddTemplate(token string, tpl bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_template").Add(1)
+		mm.latency.With("method", "add_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AddTemplate(token, tpl)
+}
+
+func (mm *metricsMiddleware) ViewTemplate(token, id string) (tpl bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_template").Add(1)
+		mm.latency.With("method", "view_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTemplate(token, id)
+}
+
+func (mm *metricsMiddleware) UpdateTemplate(token string, tpl bootstrap.Template) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_template").Add(1)
+		mm.latency.With("method", "update_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateTemplate(token, tpl)
+}
+
+func (mm *metricsMiddleware) ListTemplates(token string, offset, limit uint64) (res bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_templates").Add(1)
+		mm.latency.With("method", "list_templates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTemplates(token, offset, limit)
+}
+
+func (mm *metricsMiddleware) RemoveTemplate(token, id string) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_template").Add(1)
+		mm.latency.With("method", "remove_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTemplate(token, id)
+}
+
 func (mm *metricsMiddleware) UpdateChannelHandler(channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "update_channel").Add(1)
@@ -17,6 +17,8 @@ type addReq struct {
 	Channels    []string `json:"channels"`
 	Name        string   `json:"name"`
 	Content     string   `json:"content"`
+	TemplateID  string   `json:"template_id"`
+	Format      string   `json:"format"`
 	ClientCert  string   `json:"client_cert"`
 	ClientKey   string   `json:"client_key"`
 	CACert      string   `json:"ca_cert"`
@@ -31,6 +33,48 @@ func (req addReq) validate() error {
 		return bootstrap.ErrMalformedEntity
 	}
 
+	if req.Format != "" && !bootstrap.ValidFormat(req.Format) {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type bulkConfig struct {
+	ThingID     string
+	ExternalID  string
+	ExternalKey string
+	Name        string
+	Content     string
+}
+
+type addBulkReq struct {
+	token      string
+	templateID string
+	format     string
+	channels   []string
+	configs    []bulkConfig
+}
+
+func (req addBulkReq) validate() error {
+	if req.token == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if len(req.configs) == 0 || len(req.configs) > maxBulkSize {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	if req.format != "" && !bootstrap.ValidFormat(req.format) {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	for _, cfg := range req.configs {
+		if cfg.ExternalID == "" || cfg.ExternalKey == "" {
+			return bootstrap.ErrMalformedEntity
+		}
+	}
+
 	return nil
 }
 
@@ -52,10 +96,12 @@ func (req entityReq) validate() error {
 }
 
 type updateReq struct {
-	key     string
-	id      string
-	Name    string `json:"name"`
-	Content string `json:"content"`
+	key        string
+	id         string
+	Name       string `json:"name"`
+	Content    string `json:"content"`
+	TemplateID string `json:"template_id"`
+	Format     string `json:"format"`
 }
 
 func (req updateReq) validate() error {
@@ -67,6 +113,10 @@ func (req updateReq) validate() error {
 		return bootstrap.ErrMalformedEntity
 	}
 
+	if req.Format != "" && !bootstrap.ValidFormat(req.Format) {
+		return bootstrap.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -128,8 +178,9 @@ func (req listReq) validate() error {
 }
 
 type bootstrapReq struct {
-	key string
-	id  string
+	key    string
+	id     string
+	format string
 }
 
 func (req bootstrapReq) validate() error {
@@ -144,6 +195,93 @@ func (req bootstrapReq) validate() error {
 	return nil
 }
 
+type certBootstrapReq struct {
+	id     string
+	format string
+}
+
+func (req certBootstrapReq) validate() error {
+	if req.id == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+type listVersionsReq struct {
+	key    string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listVersionsReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.limit == 0 || req.limit > maxLimit {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewVersionReq struct {
+	key     string
+	id      string
+	version uint64
+}
+
+func (req viewVersionReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.version == 0 {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type diffReq struct {
+	key  string
+	id   string
+	from uint64
+	to   uint64
+}
+
+func (req diffReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.from == 0 || req.to == 0 {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type rollbackReq struct {
+	key     string
+	id      string
+	Version uint64 `json:"version"`
+}
+
+func (req rollbackReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.Version == 0 {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type changeStateReq struct {
 	key   string
 	id    string
@@ -159,8 +297,62 @@ func (req changeStateReq) validate() error {
 		return bootstrap.ErrMalformedEntity
 	}
 
-	if req.State != bootstrap.Inactive &&
-		req.State != bootstrap.Active {
+	if !req.State.Valid() {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type addTemplateReq struct {
+	token   string
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+func (req addTemplateReq) validate() error {
+	if req.token == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.Content == "" {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type updateTemplateReq struct {
+	key     string
+	id      string
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+func (req updateTemplateReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.Content == "" {
+		return bootstrap.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listTemplatesReq struct {
+	key    string
+	offset uint64
+	limit  uint64
+}
+
+func (req listTemplatesReq) validate() error {
+	if req.key == "" {
+		return bootstrap.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimit {
 		return bootstrap.ErrMalformedEntity
 	}
 
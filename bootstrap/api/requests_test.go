@@ -14,6 +14,7 @@ func TestAddReqValidation(t *testing.T) {
 		token       string
 		externalID  string
 		externalKey string
+		format      string
 		err         error
 	}{
 		{
@@ -37,6 +38,22 @@ func TestAddReqValidation(t *testing.T) {
 			externalKey: "",
 			err:         bootstrap.ErrMalformedEntity,
 		},
+		{
+			desc:        "unsupported format",
+			token:       "token",
+			externalID:  "external-id",
+			externalKey: "external-key",
+			format:      "xml",
+			err:         bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:        "supported format",
+			token:       "token",
+			externalID:  "external-id",
+			externalKey: "external-key",
+			format:      bootstrap.TOMLFormat,
+			err:         nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -44,6 +61,7 @@ func TestAddReqValidation(t *testing.T) {
 			token:       tc.token,
 			ExternalID:  tc.externalID,
 			ExternalKey: tc.externalKey,
+			Format:      tc.format,
 		}
 
 		err := req.validate()
@@ -84,10 +102,11 @@ func TestEntityReqValidation(t *testing.T) {
 
 func TestUpdateReqValidation(t *testing.T) {
 	cases := []struct {
-		desc string
-		key  string
-		id   string
-		err  error
+		desc   string
+		key    string
+		id     string
+		format string
+		err    error
 	}{
 		{
 			desc: "empty key",
@@ -101,12 +120,20 @@ func TestUpdateReqValidation(t *testing.T) {
 			id:   "",
 			err:  bootstrap.ErrMalformedEntity,
 		},
+		{
+			desc:   "unsupported format",
+			key:    "key",
+			id:     "id",
+			format: "xml",
+			err:    bootstrap.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		req := updateReq{
-			key: tc.key,
-			id:  tc.id,
+			key:    tc.key,
+			id:     tc.id,
+			Format: tc.format,
 		}
 
 		err := req.validate()
@@ -253,6 +280,32 @@ func TestBootstrapReqValidation(t *testing.T) {
 	}
 }
 
+func TestCertBootstrapReqValidation(t *testing.T) {
+	cases := []struct {
+		desc     string
+		externID string
+		err      error
+	}{
+		{
+			desc:     "empty external id",
+			externID: "",
+			err:      bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "valid external id",
+			externID: "id",
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := certBootstrapReq{id: tc.externID}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestChangeStateReqValidation(t *testing.T) {
 	cases := []struct {
 		desc  string
@@ -295,3 +348,197 @@ func TestChangeStateReqValidation(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestAddBulkReqValidation(t *testing.T) {
+	cfg := bulkConfig{
+		ExternalID:  "external-id",
+		ExternalKey: "external-key",
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		configs []bulkConfig
+		err     error
+	}{
+		{
+			desc:    "empty key",
+			token:   "",
+			configs: []bulkConfig{cfg},
+			err:     bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "empty list of configs",
+			token:   "token",
+			configs: []bulkConfig{},
+			err:     bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:    "too many configs",
+			token:   "token",
+			configs: make([]bulkConfig, maxBulkSize+1),
+			err:     bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:    "empty external ID",
+			token:   "token",
+			configs: []bulkConfig{cfg, bulkConfig{ExternalKey: "external-key"}},
+			err:     bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:    "empty external key",
+			token:   "token",
+			configs: []bulkConfig{cfg, bulkConfig{ExternalID: "external-id"}},
+			err:     bootstrap.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := addBulkReq{
+			token:   tc.token,
+			configs: tc.configs,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAddTemplateReqValidation(t *testing.T) {
+	cases := []struct {
+		desc    string
+		token   string
+		content string
+		err     error
+	}{
+		{
+			desc:    "empty key",
+			token:   "",
+			content: "{{.ExternalID}}",
+			err:     bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "empty content",
+			token:   "token",
+			content: "",
+			err:     bootstrap.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := addTemplateReq{
+			token:   tc.token,
+			Content: tc.content,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDiffReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  string
+		id   string
+		from uint64
+		to   uint64
+		err  error
+	}{
+		{
+			desc: "empty key",
+			key:  "",
+			id:   "id",
+			from: 1,
+			to:   2,
+			err:  bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "empty id",
+			key:  "key",
+			id:   "",
+			from: 1,
+			to:   2,
+			err:  bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc: "missing from version",
+			key:  "key",
+			id:   "id",
+			from: 0,
+			to:   2,
+			err:  bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc: "missing to version",
+			key:  "key",
+			id:   "id",
+			from: 1,
+			to:   0,
+			err:  bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc: "valid request",
+			key:  "key",
+			id:   "id",
+			from: 1,
+			to:   2,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := diffReq{
+			key:  tc.key,
+			id:   tc.id,
+			from: tc.from,
+			to:   tc.to,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRollbackReqValidation(t *testing.T) {
+	cases := []struct {
+		desc    string
+		key     string
+		id      string
+		version uint64
+		err     error
+	}{
+		{
+			desc:    "empty key",
+			key:     "",
+			id:      "id",
+			version: 1,
+			err:     bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "empty id",
+			key:     "key",
+			id:      "",
+			version: 1,
+			err:     bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:    "missing version",
+			key:     "key",
+			id:      "id",
+			version: 0,
+			err:     bootstrap.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := rollbackReq{
+			key:     tc.key,
+			id:      tc.id,
+			Version: tc.version,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
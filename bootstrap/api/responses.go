@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/bootstrap"
@@ -17,6 +18,10 @@ var (
 	_ mainflux.Response = (*stateRes)(nil)
 	_ mainflux.Response = (*viewRes)(nil)
 	_ mainflux.Response = (*listRes)(nil)
+	_ mainflux.Response = (*bulkRes)(nil)
+	_ mainflux.Response = (*templateRes)(nil)
+	_ mainflux.Response = (*viewTemplateRes)(nil)
+	_ mainflux.Response = (*listTemplatesRes)(nil)
 )
 
 type removeRes struct{}
@@ -67,14 +72,18 @@ type channelRes struct {
 }
 
 type viewRes struct {
-	MFThing     string          `json:"mainflux_id,omitempty"`
-	MFKey       string          `json:"mainflux_key,omitempty"`
-	Channels    []channelRes    `json:"mainflux_channels,omitempty"`
-	ExternalID  string          `json:"external_id"`
-	ExternalKey string          `json:"external_key,omitempty"`
-	Content     string          `json:"content,omitempty"`
-	Name        string          `json:"name,omitempty"`
-	State       bootstrap.State `json:"state"`
+	MFThing        string          `json:"mainflux_id,omitempty"`
+	MFKey          string          `json:"mainflux_key,omitempty"`
+	Channels       []channelRes    `json:"mainflux_channels,omitempty"`
+	ExternalID     string          `json:"external_id"`
+	ExternalKey    string          `json:"external_key,omitempty"`
+	Content        string          `json:"content,omitempty"`
+	TemplateID     string          `json:"template_id,omitempty"`
+	Format         string          `json:"format,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	State          bootstrap.State `json:"state"`
+	Version        uint64          `json:"version"`
+	FetchedVersion uint64          `json:"fetched_version"`
 }
 
 func (res viewRes) Code() int {
@@ -108,6 +117,103 @@ func (res listRes) Empty() bool {
 	return false
 }
 
+type bulkRes struct {
+	Configs []viewRes `json:"configs"`
+}
+
+func (res bulkRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res bulkRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bulkRes) Empty() bool {
+	return false
+}
+
+type versionRes struct {
+	Version    uint64    `json:"version"`
+	Content    string    `json:"content,omitempty"`
+	TemplateID string    `json:"template_id,omitempty"`
+	Channels   []string  `json:"mainflux_channels,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (res versionRes) Code() int {
+	return http.StatusOK
+}
+
+func (res versionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res versionRes) Empty() bool {
+	return false
+}
+
+type listVersionsRes struct {
+	Total    uint64       `json:"total"`
+	Offset   uint64       `json:"offset"`
+	Limit    uint64       `json:"limit"`
+	Versions []versionRes `json:"versions"`
+}
+
+func (res listVersionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listVersionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listVersionsRes) Empty() bool {
+	return false
+}
+
+type diffRes struct {
+	From            uint64   `json:"from"`
+	To              uint64   `json:"to"`
+	Changed         []string `json:"changed"`
+	Content         []string `json:"content,omitempty"`
+	AddedChannels   []string `json:"added_channels,omitempty"`
+	RemovedChannels []string `json:"removed_channels,omitempty"`
+}
+
+func (res diffRes) Code() int {
+	return http.StatusOK
+}
+
+func (res diffRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res diffRes) Empty() bool {
+	return false
+}
+
+type rollbackRes struct{}
+
+func (res rollbackRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rollbackRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rollbackRes) Empty() bool {
+	return true
+}
+
+// bootstrapRes wraps the ConfigReader output along with the content type
+// of the format it is encoded in.
+type bootstrapRes struct {
+	contentType string
+	data        interface{}
+}
+
 type stateRes struct{}
 
 func (res stateRes) Code() int {
@@ -122,6 +228,70 @@ func (res stateRes) Empty() bool {
 	return true
 }
 
+type templateRes struct {
+	id      string
+	created bool
+}
+
+func (res templateRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res templateRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/things/templates/%s", res.id),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res templateRes) Empty() bool {
+	return true
+}
+
+type viewTemplateRes struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content"`
+}
+
+func (res viewTemplateRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewTemplateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewTemplateRes) Empty() bool {
+	return false
+}
+
+type listTemplatesRes struct {
+	Total     uint64            `json:"total"`
+	Offset    uint64            `json:"offset"`
+	Limit     uint64            `json:"limit"`
+	Templates []viewTemplateRes `json:"templates"`
+}
+
+func (res listTemplatesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listTemplatesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listTemplatesRes) Empty() bool {
+	return false
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
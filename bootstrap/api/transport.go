@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -21,9 +22,11 @@ import (
 )
 
 const (
-	contentType  = "application/json"
-	maxLimit     = 100
-	defaultLimit = 10
+	contentType    = "application/json"
+	csvContentType = "text/csv"
+	maxLimit       = 100
+	defaultLimit   = 10
+	maxBulkSize    = 10000
 )
 
 var (
@@ -31,12 +34,33 @@ var (
 	errInvalidQueryParams     = errors.New("invalid query params")
 	errInvalidLimitParam      = errors.New("invalid limit query param")
 	errInvalidOffsetParam     = errors.New("invalid offset query param")
+	errInvalidCSV             = errors.New("invalid CSV document")
+	errNotAcceptable          = errors.New("requested response format is not supported")
 	fullMatch                 = []string{"state", "external_id", "mainflux_id", "mainflux_key"}
 	partialMatch              = []string{"name"}
+	// contentTypes maps bootstrap response formats to the response Content-Type.
+	contentTypes = map[string]string{
+		bootstrap.JSONFormat: contentType,
+		bootstrap.TOMLFormat: "application/toml",
+		bootstrap.YAMLFormat: "application/yaml",
+		bootstrap.RawFormat:  "application/octet-stream",
+	}
+	// acceptedTypes maps media types of the Accept header to bootstrap response formats.
+	acceptedTypes = map[string]string{
+		"application/json":         bootstrap.JSONFormat,
+		"application/toml":         bootstrap.TOMLFormat,
+		"application/yaml":         bootstrap.YAMLFormat,
+		"application/x-yaml":       bootstrap.YAMLFormat,
+		"text/yaml":                bootstrap.YAMLFormat,
+		"application/octet-stream": bootstrap.RawFormat,
+		"text/plain":               bootstrap.RawFormat,
+	}
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader) http.Handler {
+// Readers are mapped by the response format they produce, while certField is
+// the client certificate field mapped to the external ID of the Thing.
+func MakeHandler(svc bootstrap.Service, readers map[string]bootstrap.ConfigReader, certField string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
@@ -48,6 +72,12 @@ func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader) http.Hand
 		encodeResponse,
 		opts...))
 
+	r.Post("/things/configs/bulk", kithttp.NewServer(
+		addBulkEndpoint(svc),
+		decodeAddBulkRequest,
+		encodeResponse,
+		opts...))
+
 	r.Get("/things/configs/:id", kithttp.NewServer(
 		viewEndpoint(svc),
 		decodeEntityRequest,
@@ -72,6 +102,30 @@ func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader) http.Hand
 		encodeResponse,
 		opts...))
 
+	r.Get("/things/configs/:id/versions", kithttp.NewServer(
+		listVersionsEndpoint(svc),
+		decodeListVersionsRequest,
+		encodeResponse,
+		opts...))
+
+	r.Get("/things/configs/:id/versions/:version", kithttp.NewServer(
+		viewVersionEndpoint(svc),
+		decodeViewVersionRequest,
+		encodeResponse,
+		opts...))
+
+	r.Get("/things/configs/:id/diff", kithttp.NewServer(
+		diffEndpoint(svc),
+		decodeDiffRequest,
+		encodeResponse,
+		opts...))
+
+	r.Post("/things/configs/:id/rollback", kithttp.NewServer(
+		rollbackEndpoint(svc),
+		decodeRollbackRequest,
+		encodeResponse,
+		opts...))
+
 	r.Get("/things/configs", kithttp.NewServer(
 		listEndpoint(svc),
 		decodeListRequest,
@@ -79,15 +133,21 @@ func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader) http.Hand
 		opts...))
 
 	r.Get("/things/bootstrap/:external_id", kithttp.NewServer(
-		bootstrapEndpoint(svc, reader, false),
+		bootstrapEndpoint(svc, readers, false),
 		decodeBootstrapRequest,
-		encodeResponse,
+		encodeBootstrapRes,
 		opts...))
 
 	r.Get("/things/bootstrap/secure/:external_id", kithttp.NewServer(
-		bootstrapEndpoint(svc, reader, true),
+		bootstrapEndpoint(svc, readers, true),
 		decodeBootstrapRequest,
-		encodeSecureRes,
+		encodeBootstrapRes,
+		opts...))
+
+	r.Get("/things/bootstrap", kithttp.NewServer(
+		certBootstrapEndpoint(svc, readers),
+		decodeCertBootstrapRequest(certField),
+		encodeBootstrapRes,
 		opts...))
 
 	r.Put("/things/state/:id", kithttp.NewServer(
@@ -102,6 +162,36 @@ func MakeHandler(svc bootstrap.Service, reader bootstrap.ConfigReader) http.Hand
 		encodeResponse,
 		opts...))
 
+	r.Post("/things/templates", kithttp.NewServer(
+		addTemplateEndpoint(svc),
+		decodeAddTemplateRequest,
+		encodeResponse,
+		opts...))
+
+	r.Get("/things/templates", kithttp.NewServer(
+		listTemplatesEndpoint(svc),
+		decodeListTemplatesRequest,
+		encodeResponse,
+		opts...))
+
+	r.Get("/things/templates/:id", kithttp.NewServer(
+		viewTemplateEndpoint(svc),
+		decodeEntityRequest,
+		encodeResponse,
+		opts...))
+
+	r.Put("/things/templates/:id", kithttp.NewServer(
+		updateTemplateEndpoint(svc),
+		decodeUpdateTemplateRequest,
+		encodeResponse,
+		opts...))
+
+	r.Delete("/things/templates/:id", kithttp.NewServer(
+		removeTemplateEndpoint(svc),
+		decodeEntityRequest,
+		encodeResponse,
+		opts...))
+
 	r.GetFunc("/version", mainflux.Version("bootstrap"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -121,6 +211,68 @@ func decodeAddRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// Bulk request body is a CSV document with the header row. Columns external_id
+// and external_key are mandatory, while thing_id, name and content are optional.
+// Template and Channels common to all the Configs are passed as query params.
+func decodeAddBulkRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), csvContentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	q, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, errInvalidQueryParams
+	}
+
+	req := addBulkReq{
+		token:      r.Header.Get("Authorization"),
+		templateID: q.Get("template_id"),
+	}
+
+	if chs := q.Get("channels"); chs != "" {
+		req.channels = strings.Split(chs, ",")
+	}
+
+	records, err := csv.NewReader(r.Body).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, errors.Wrap(errInvalidCSV, err))
+	}
+
+	if len(records) == 0 {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, errInvalidCSV)
+	}
+
+	cols := make(map[string]int)
+	for i, col := range records[0] {
+		col = strings.TrimSpace(col)
+		switch col {
+		case "thing_id", "external_id", "external_key", "name", "content":
+			cols[col] = i
+		default:
+			return nil, errors.Wrap(bootstrap.ErrMalformedEntity, errInvalidCSV)
+		}
+	}
+
+	field := func(record []string, col string) string {
+		if i, ok := cols[col]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	for _, record := range records[1:] {
+		req.configs = append(req.configs, bulkConfig{
+			ThingID:     field(record, "thing_id"),
+			ExternalID:  field(record, "external_id"),
+			ExternalKey: field(record, "external_key"),
+			Name:        field(record, "name"),
+			Content:     field(record, "content"),
+		})
+	}
+
+	return req, nil
+}
+
 func decodeUpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errUnsupportedContentType
@@ -189,15 +341,123 @@ func decodeListRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeListVersionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, errInvalidQueryParams
+	}
+
+	offset, limit, err := parsePagePrams(q)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listVersionsReq{
+		key:    r.Header.Get("Authorization"),
+		id:     bone.GetValue(r, "id"),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeViewVersionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	version, err := strconv.ParseUint(bone.GetValue(r, "version"), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, err)
+	}
+
+	req := viewVersionReq{
+		key:     r.Header.Get("Authorization"),
+		id:      bone.GetValue(r, "id"),
+		version: version,
+	}
+
+	return req, nil
+}
+
+func decodeDiffRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, errInvalidQueryParams
+	}
+
+	from, err := parseUint(q.Get("from"))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseUint(q.Get("to"))
+	if err != nil {
+		return nil, err
+	}
+
+	req := diffReq{
+		key:  r.Header.Get("Authorization"),
+		id:   bone.GetValue(r, "id"),
+		from: from,
+		to:   to,
+	}
+
+	return req, nil
+}
+
+func decodeRollbackRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := rollbackReq{
+		key: r.Header.Get("Authorization"),
+		id:  bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeBootstrapRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	format, err := parseAccept(r.Header.Get("Accept"))
+	if err != nil {
+		return nil, err
+	}
+
 	req := bootstrapReq{
-		id:  bone.GetValue(r, "external_id"),
-		key: r.Header.Get("Authorization"),
+		id:     bone.GetValue(r, "external_id"),
+		key:    r.Header.Get("Authorization"),
+		format: format,
 	}
 
 	return req, nil
 }
 
+// Thing is authenticated only if the client certificate chain is verified
+// during the TLS handshake against the trusted CAs.
+func decodeCertBootstrapRequest(certField string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		format, err := parseAccept(r.Header.Get("Accept"))
+		if err != nil {
+			return nil, err
+		}
+
+		req := certBootstrapReq{format: format}
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			return req, nil
+		}
+
+		id, err := bootstrap.CertExternalID(r.TLS.VerifiedChains[0][0], certField)
+		if err != nil {
+			return nil, errors.Wrap(bootstrap.ErrUnauthorizedAccess, err)
+		}
+		req.id = id
+
+		return req, nil
+	}
+}
+
 func decodeStateRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errUnsupportedContentType
@@ -212,6 +472,55 @@ func decodeStateRequest(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeAddTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := addTemplateReq{token: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := updateTemplateReq{
+		key: r.Header.Get("Authorization"),
+		id:  bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(bootstrap.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, errInvalidQueryParams
+	}
+
+	offset, limit, err := parsePagePrams(q)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listTemplatesReq{
+		key:    r.Header.Get("Authorization"),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
 func decodeEntityRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := entityReq{
 		key: r.Header.Get("Authorization"),
@@ -238,15 +547,16 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
-func encodeSecureRes(_ context.Context, w http.ResponseWriter, response interface{}) error {
-	w.Header().Set("Content-Type", contentType)
+func encodeBootstrapRes(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(bootstrapRes)
+	w.Header().Set("Content-Type", res.contentType)
 	w.WriteHeader(http.StatusOK)
-	if b, ok := response.([]byte); ok {
-		if _, err := w.Write(b); err != nil {
-			return err
-		}
+	if b, ok := res.data.([]byte); ok {
+		_, err := w.Write(b)
+		return err
 	}
-	return nil
+
+	return json.NewEncoder(w).Encode(res.data)
 }
 
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
@@ -256,8 +566,15 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		switch {
 		case errors.Contains(errorVal, errUnsupportedContentType):
 			w.WriteHeader(http.StatusUnsupportedMediaType)
+		case errors.Contains(errorVal, errNotAcceptable):
+			w.WriteHeader(http.StatusNotAcceptable)
 		case errors.Contains(errorVal, errInvalidQueryParams):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, bootstrap.ErrStateTransition),
+			errors.Contains(errorVal, bootstrap.ErrDecommissioned):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, bootstrap.ErrBootstrapDisabled):
+			w.WriteHeader(http.StatusForbidden)
 		case errors.Contains(errorVal, bootstrap.ErrMalformedEntity):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, bootstrap.ErrNotFound):
@@ -339,6 +656,27 @@ func parseFilter(values url.Values) bootstrap.Filter {
 	return ret
 }
 
+// parseAccept returns the bootstrap response format of the first supported
+// media type listed in the Accept header. Empty format is returned if the
+// header is missing or accepts any media type, so the Config format is used.
+func parseAccept(accept string) (string, error) {
+	if accept == "" {
+		return "", nil
+	}
+
+	for _, mt := range strings.Split(accept, ",") {
+		mt = strings.ToLower(strings.TrimSpace(strings.Split(mt, ";")[0]))
+		if mt == "*/*" {
+			return "", nil
+		}
+		if format, ok := acceptedTypes[mt]; ok {
+			return format, nil
+		}
+	}
+
+	return "", errNotAcceptable
+}
+
 func contains(l []string, s string) bool {
 	for _, v := range l {
 		if v == s {
@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Client certificate fields that can be mapped to the Config external ID.
+const (
+	// CommonNameField maps certificate subject Common Name to the external ID.
+	CommonNameField = "cn"
+	// SerialField maps lower-case hex encoded certificate serial number to the external ID.
+	SerialField = "serial"
+)
+
+// ErrCertIdentity indicates client certificate that can't be mapped to the external ID.
+var ErrCertIdentity = errors.New("failed to map client certificate to external ID")
+
+// ValidCertField returns true if the given certificate field can be mapped to the external ID.
+func ValidCertField(field string) bool {
+	return field == CommonNameField || field == SerialField
+}
+
+// CertExternalID returns the external ID of the Thing presenting the given
+// client certificate. The certificate is expected to be already verified
+// against the trusted CAs.
+func CertExternalID(cert *x509.Certificate, field string) (string, error) {
+	if cert == nil {
+		return "", ErrCertIdentity
+	}
+
+	var id string
+	switch field {
+	case CommonNameField:
+		id = cert.Subject.CommonName
+	case SerialField:
+		if cert.SerialNumber != nil {
+			id = fmt.Sprintf("%x", cert.SerialNumber)
+		}
+	}
+
+	if id == "" {
+		return "", ErrCertIdentity
+	}
+
+	return id, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/mainflux/mainflux/bootstrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertExternalID(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "external-id"},
+		SerialNumber: big.NewInt(0xabc123),
+	}
+
+	cases := []struct {
+		desc  string
+		cert  *x509.Certificate
+		field string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "map common name to external ID",
+			cert:  cert,
+			field: bootstrap.CommonNameField,
+			id:    "external-id",
+			err:   nil,
+		},
+		{
+			desc:  "map serial number to external ID",
+			cert:  cert,
+			field: bootstrap.SerialField,
+			id:    "abc123",
+			err:   nil,
+		},
+		{
+			desc:  "map empty common name to external ID",
+			cert:  &x509.Certificate{SerialNumber: big.NewInt(1)},
+			field: bootstrap.CommonNameField,
+			id:    "",
+			err:   bootstrap.ErrCertIdentity,
+		},
+		{
+			desc:  "map unknown field to external ID",
+			cert:  cert,
+			field: "email",
+			id:    "",
+			err:   bootstrap.ErrCertIdentity,
+		},
+		{
+			desc:  "map missing certificate to external ID",
+			cert:  nil,
+			field: bootstrap.CommonNameField,
+			id:    "",
+			err:   bootstrap.ErrCertIdentity,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := bootstrap.CertExternalID(tc.cert, tc.field)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
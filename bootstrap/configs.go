@@ -8,19 +8,28 @@ package bootstrap
 // MFThing represents corresponding Mainflux Thing ID.
 // MFKey is key of corresponding Mainflux Thing.
 // MFChannels is a list of Mainflux Channels corresponding Mainflux Thing connects to.
+// TemplateID is an optional ID of the Template used to render Content.
+// Format is an optional format of the bootstrap response, used unless the
+// Thing requests a specific format.
+// Version is the current version of the Config, while FetchedVersion is the
+// version the Thing received during its latest bootstrap.
 type Config struct {
-	MFThing     string
-	Owner       string
-	Name        string
-	ClientCert  string
-	ClientKey   string
-	CACert      string
-	MFKey       string
-	MFChannels  []Channel
-	ExternalID  string
-	ExternalKey string
-	Content     string
-	State       State
+	MFThing        string
+	Owner          string
+	Name           string
+	ClientCert     string
+	ClientKey      string
+	CACert         string
+	MFKey          string
+	MFChannels     []Channel
+	ExternalID     string
+	ExternalKey    string
+	Content        string
+	TemplateID     string
+	Format         string
+	State          State
+	Version        uint64
+	FetchedVersion uint64
 }
 
 // Channel represents Mainflux channel corresponding Mainflux Thing is connected to.
@@ -51,6 +60,11 @@ type ConfigRepository interface {
 	// error response.
 	Save(cfg Config, chsConnIDs []string) (string, error)
 
+	// SaveAll persists the Configs and the given Channels in a single
+	// transaction. Every Config is connected to the Channels listed in its
+	// MFChannels. A non-nil error is returned to indicate operation failure.
+	SaveAll(cfgs []Config, channels []Channel) error
+
 	// RetrieveByID retrieves the Config having the provided identifier, that is owned
 	// by the specified user.
 	RetrieveByID(owner, id string) (Config, error)
@@ -63,7 +77,9 @@ type ConfigRepository interface {
 	RetrieveByExternalID(externalID string) (Config, error)
 
 	// Update updates an existing Config. A non-nil error is returned
-	// to indicate operation failure.
+	// to indicate operation failure. Methods which change the Config save
+	// its new version, unless only the fields which aren't versioned, such
+	// as the name, are changed.
 	Update(cfg Config) error
 
 	// UpdateCerts updates an existing Config certificate and owner.
@@ -74,6 +90,21 @@ type ConfigRepository interface {
 	// adding new Channels if needed.
 	UpdateConnections(owner, id string, channels []Channel, connections []string) error
 
+	// RetrieveVersions retrieves a subset of versions of the Config having the
+	// provided identifier, that is owned by the specified user.
+	RetrieveVersions(owner, id string, offset, limit uint64) (ConfigVersionsPage, error)
+
+	// RetrieveVersion retrieves the given version of the Config having the
+	// provided identifier, that is owned by the specified user.
+	RetrieveVersion(owner, id string, version uint64) (ConfigVersion, error)
+
+	// Rollback restores Content, Template, certificates and connections of
+	// the Config from the given version, adding new Channels if needed.
+	// Since the versions don't contain the client key, certificates are
+	// restored only if the client key didn't change since the version.
+	// The restored state is saved as a new version.
+	Rollback(owner, id string, ver ConfigVersion, channels []Channel) error
+
 	// Remove removes the Config having the provided identifier, that is owned
 	// by the specified user.
 	Remove(owner, id string) error
@@ -93,10 +124,17 @@ type ConfigRepository interface {
 	// UpdateChannel updates channel with the given ID.
 	UpdateChannel(c Channel) error
 
-	// RemoveChannel removes channel with the given ID.
+	// RemoveChannel removes channel with the given ID and saves the new
+	// version of the Configs connected to it.
 	RemoveChannel(id string) error
 
 	// DisconnectHandler changes state of the Config when the corresponding Thing is
-	// disconnected from the Channel.
+	// disconnected from the Channel. Connections of the Config are kept, so
+	// that the Thing is reconnected once the Config is activated again, thus
+	// no new version is saved.
 	DisconnectThing(channelID, thingID string) error
+
+	// UpdateFetchedVersion stores the Config version fetched by the Thing
+	// with the given ID during bootstrap.
+	UpdateFetchedVersion(id string, version uint64) error
 }
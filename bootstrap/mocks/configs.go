@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/bootstrap"
 )
@@ -24,6 +25,7 @@ type configRepositoryMock struct {
 	counter  uint64
 	configs  map[string]bootstrap.Config
 	channels map[string]bootstrap.Channel
+	versions map[string][]bootstrap.ConfigVersion
 }
 
 // NewConfigsRepository creates in-memory config repository.
@@ -31,6 +33,7 @@ func NewConfigsRepository() bootstrap.ConfigRepository {
 	return &configRepositoryMock{
 		configs:  make(map[string]bootstrap.Config),
 		channels: make(map[string]bootstrap.Channel),
+		versions: make(map[string][]bootstrap.ConfigVersion),
 	}
 }
 
@@ -59,10 +62,40 @@ func (crm *configRepositoryMock) Save(config bootstrap.Config, connections []str
 	}
 
 	crm.configs[config.MFThing] = config
+	crm.saveVersion(config)
 
 	return config.MFThing, nil
 }
 
+func (crm *configRepositoryMock) SaveAll(configs []bootstrap.Config, channels []bootstrap.Channel) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for _, config := range configs {
+		for _, v := range crm.configs {
+			if v.MFThing == config.MFThing || v.ExternalID == config.ExternalID {
+				return bootstrap.ErrConflict
+			}
+		}
+	}
+
+	for _, ch := range channels {
+		crm.channels[ch.ID] = ch
+	}
+
+	for _, config := range configs {
+		var connected []bootstrap.Channel
+		for _, ch := range config.MFChannels {
+			connected = append(connected, crm.channels[ch.ID])
+		}
+		config.MFChannels = connected
+		crm.configs[config.MFThing] = config
+		crm.saveVersion(config)
+	}
+
+	return nil
+}
+
 func (crm *configRepositoryMock) RetrieveByID(token, id string) (bootstrap.Config, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
@@ -151,7 +184,9 @@ func (crm *configRepositoryMock) Update(config bootstrap.Config) error {
 
 	cfg.Name = config.Name
 	cfg.Content = config.Content
-	crm.configs[config.MFThing] = cfg
+	cfg.TemplateID = config.TemplateID
+	cfg.Format = config.Format
+	crm.newVersion(cfg)
 
 	return nil
 }
@@ -172,7 +207,7 @@ func (crm *configRepositoryMock) UpdateCert(owner, thingID, clientCert, clientKe
 	forUpdate.ClientCert = clientCert
 	forUpdate.ClientKey = clientKey
 	forUpdate.CACert = caCert
-	crm.configs[forUpdate.MFThing] = forUpdate
+	crm.newVersion(forUpdate)
 
 	return nil
 }
@@ -198,7 +233,88 @@ func (crm *configRepositoryMock) UpdateConnections(token, id string, channels []
 		}
 		config.MFChannels = append(config.MFChannels, ch)
 	}
-	crm.configs[id] = config
+	crm.newVersion(config)
+
+	return nil
+}
+
+func (crm *configRepositoryMock) RetrieveVersions(owner, id string, offset, limit uint64) (bootstrap.ConfigVersionsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	config, ok := crm.configs[id]
+	if !ok || config.Owner != owner {
+		return bootstrap.ConfigVersionsPage{}, bootstrap.ErrNotFound
+	}
+
+	versions := crm.versions[id]
+	total := uint64(len(versions))
+	page := bootstrap.ConfigVersionsPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Versions: []bootstrap.ConfigVersion{},
+	}
+	if offset >= total {
+		return page, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	page.Versions = append(page.Versions, versions[offset:end]...)
+
+	return page, nil
+}
+
+func (crm *configRepositoryMock) RetrieveVersion(owner, id string, version uint64) (bootstrap.ConfigVersion, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	config, ok := crm.configs[id]
+	if !ok || config.Owner != owner {
+		return bootstrap.ConfigVersion{}, bootstrap.ErrNotFound
+	}
+
+	for _, v := range crm.versions[id] {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	return bootstrap.ConfigVersion{}, bootstrap.ErrNotFound
+}
+
+func (crm *configRepositoryMock) Rollback(owner, id string, ver bootstrap.ConfigVersion, channels []bootstrap.Channel) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	config, ok := crm.configs[id]
+	if !ok || config.Owner != owner {
+		return bootstrap.ErrNotFound
+	}
+
+	for _, ch := range channels {
+		crm.channels[ch.ID] = ch
+	}
+
+	config.MFChannels = []bootstrap.Channel{}
+	for _, conn := range ver.Channels {
+		ch, ok := crm.channels[conn]
+		if !ok {
+			return bootstrap.ErrNotFound
+		}
+		config.MFChannels = append(config.MFChannels, ch)
+	}
+
+	config.Content = ver.Content
+	config.TemplateID = ver.TemplateID
+	if bootstrap.KeyDigest(config.ClientKey) == ver.ClientKeyDigest {
+		config.ClientCert = ver.ClientCert
+		config.CACert = ver.CACert
+	}
+	crm.newVersion(config)
 
 	return nil
 }
@@ -210,6 +326,7 @@ func (crm *configRepositoryMock) Remove(token, id string) error {
 	for k, v := range crm.configs {
 		if v.Owner == token && k == id {
 			delete(crm.configs, k)
+			delete(crm.versions, k)
 			break
 		}
 	}
@@ -257,6 +374,7 @@ func (crm *configRepositoryMock) RemoveThing(id string) error {
 	defer crm.mu.Unlock()
 
 	delete(crm.configs, id)
+	delete(crm.versions, id)
 	return nil
 }
 
@@ -280,6 +398,16 @@ func (crm *configRepositoryMock) RemoveChannel(id string) error {
 	defer crm.mu.Unlock()
 
 	delete(crm.channels, id)
+	for _, config := range crm.configs {
+		for i, ch := range config.MFChannels {
+			if ch.ID == id {
+				config.MFChannels = append(config.MFChannels[:i:i], config.MFChannels[i+1:]...)
+				crm.newVersion(config)
+				break
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -306,3 +434,57 @@ func (crm *configRepositoryMock) DisconnectThing(channelID, thingID string) erro
 
 	return nil
 }
+
+func (crm *configRepositoryMock) UpdateFetchedVersion(id string, version uint64) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	config, ok := crm.configs[id]
+	if !ok {
+		return bootstrap.ErrNotFound
+	}
+
+	config.FetchedVersion = version
+	crm.configs[id] = config
+
+	return nil
+}
+
+// Method newVersion increments the Config version and saves it, unless the
+// versioned fields didn't change since the latest version.
+func (crm *configRepositoryMock) newVersion(config bootstrap.Config) {
+	versions := crm.versions[config.MFThing]
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		if len(bootstrap.Diff(latest, toVersion(config)).Changed) == 0 {
+			crm.configs[config.MFThing] = config
+			return
+		}
+	}
+
+	config.Version++
+	crm.configs[config.MFThing] = config
+	crm.saveVersion(config)
+}
+
+func (crm *configRepositoryMock) saveVersion(config bootstrap.Config) {
+	crm.versions[config.MFThing] = append(crm.versions[config.MFThing], toVersion(config))
+}
+
+func toVersion(config bootstrap.Config) bootstrap.ConfigVersion {
+	var chs []string
+	for _, ch := range config.MFChannels {
+		chs = append(chs, ch.ID)
+	}
+
+	return bootstrap.ConfigVersion{
+		Version:         config.Version,
+		Content:         config.Content,
+		TemplateID:      config.TemplateID,
+		ClientCert:      config.ClientCert,
+		ClientKeyDigest: bootstrap.KeyDigest(config.ClientKey),
+		CACert:          config.CACert,
+		Channels:        chs,
+		CreatedAt:       time.Now(),
+	}
+}
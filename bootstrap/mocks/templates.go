@@ -0,0 +1,111 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/bootstrap"
+)
+
+var _ bootstrap.TemplateRepository = (*templateRepositoryMock)(nil)
+
+type templateRepositoryMock struct {
+	mu        sync.Mutex
+	templates map[string]bootstrap.Template
+}
+
+// NewTemplatesRepository creates in-memory template repository.
+func NewTemplatesRepository() bootstrap.TemplateRepository {
+	return &templateRepositoryMock{
+		templates: make(map[string]bootstrap.Template),
+	}
+}
+
+func (trm *templateRepositoryMock) Save(tpl bootstrap.Template) (string, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.templates[tpl.ID]; ok {
+		return "", bootstrap.ErrConflict
+	}
+
+	trm.templates[tpl.ID] = tpl
+
+	return tpl.ID, nil
+}
+
+func (trm *templateRepositoryMock) RetrieveByID(owner, id string) (bootstrap.Template, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	tpl, ok := trm.templates[id]
+	if !ok || tpl.Owner != owner {
+		return bootstrap.Template{}, bootstrap.ErrNotFound
+	}
+
+	return tpl, nil
+}
+
+func (trm *templateRepositoryMock) RetrieveAll(owner string, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	tpls := []bootstrap.Template{}
+	for _, tpl := range trm.templates {
+		if tpl.Owner == owner {
+			tpls = append(tpls, tpl)
+		}
+	}
+
+	sort.SliceStable(tpls, func(i, j int) bool {
+		return tpls[i].ID < tpls[j].ID
+	})
+
+	total := uint64(len(tpls))
+	if offset >= total {
+		tpls = []bootstrap.Template{}
+	} else {
+		end := offset + limit
+		if end > total {
+			end = total
+		}
+		tpls = tpls[offset:end]
+	}
+
+	return bootstrap.TemplatesPage{
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Templates: tpls,
+	}, nil
+}
+
+func (trm *templateRepositoryMock) Update(tpl bootstrap.Template) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.templates[tpl.ID]
+	if !ok || t.Owner != tpl.Owner {
+		return bootstrap.ErrNotFound
+	}
+
+	t.Name = tpl.Name
+	t.Content = tpl.Content
+	trm.templates[tpl.ID] = t
+
+	return nil
+}
+
+func (trm *templateRepositoryMock) Remove(owner, id string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if t, ok := trm.templates[id]; ok && t.Owner == owner {
+		delete(trm.templates, id)
+	}
+
+	return nil
+}
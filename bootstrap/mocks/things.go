@@ -70,7 +70,7 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string, _ string) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -163,8 +163,24 @@ func (svc *mainfluxThings) UpdateThing(context.Context, string, things.Thing) er
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) error {
-	panic("not implemented")
+func (svc *mainfluxThings) UpdateKey(_ context.Context, owner, id, key string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	userID, err := svc.auth.Identify(context.Background(), &mainflux.Token{Value: owner})
+	if err != nil {
+		return things.ErrUnauthorizedAccess
+	}
+
+	t, ok := svc.things[id]
+	if !ok || t.Owner != userID.Value {
+		return things.ErrNotFound
+	}
+
+	t.Key = key
+	svc.things[id] = t
+
+	return nil
 }
 
 func (svc *mainfluxThings) ListThings(context.Context, string, uint64, uint64, string, things.Metadata) (things.Page, error) {
@@ -209,11 +225,11 @@ func (svc *mainfluxThings) RemoveChannel(context.Context, string, string) error
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string) (string, error) {
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string) (string, error) {
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByID(context.Context, string, string) error {
+func (svc *mainfluxThings) CanAccessByID(context.Context, string, string, string) error {
 	panic("not implemented")
 }
 
@@ -221,6 +237,26 @@ func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) UpdatePSK(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RetrievePSK(context.Context, string) (string, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ChannelIDByName(context.Context, string, string) (string, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RetrieveLimits(context.Context, string) (things.Limits, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RetrieveSchema(context.Context, string) (string, error) {
+	panic("not implemented")
+}
+
 func findIndex(list []string, val string) int {
 	for i, v := range list {
 		if v == val {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -26,6 +27,16 @@ const (
 	connFieldsNum     = 2
 	cleanupQuery      = `DELETE FROM channels ch WHERE NOT EXISTS (
 						 SELECT channel_id FROM connections c WHERE ch.mainflux_channel = c.channel_id);`
+	saveConfigQuery = `INSERT INTO configs (mainflux_thing, owner, name, client_cert, client_key, ca_cert, mainflux_key, external_id, external_key, content, template_id, format, state, version)
+		  VALUES (:mainflux_thing, :owner, :name, :client_cert, :client_key, :ca_cert, :mainflux_key, :external_id, :external_key, :content, :template_id, :format, :state, :version)`
+	incVersionQuery  = `UPDATE configs SET version = version + 1 WHERE mainflux_thing = $1 AND owner = $2`
+	saveVersionQuery = `INSERT INTO config_versions (config_id, config_owner, version, content, template_id, client_cert, client_key_digest, ca_cert, channels, created_at)
+		  VALUES (:config_id, :config_owner, :version, :content, :template_id, :client_cert, :client_key_digest, :ca_cert, :channels, :created_at)`
+	currentVersionQuery = `SELECT version, content, template_id, client_cert, client_key, ca_cert,
+		  ARRAY(SELECT channel_id FROM connections WHERE config_id = $1 AND config_owner = $2 ORDER BY channel_id)
+		  FROM configs WHERE mainflux_thing = $1 AND owner = $2 FOR UPDATE`
+	latestVersionQuery = `SELECT version, content, template_id, client_cert, client_key_digest, ca_cert, channels, created_at
+		  FROM config_versions WHERE config_id = $1 AND config_owner = $2 ORDER BY version DESC LIMIT 1`
 )
 
 var (
@@ -40,6 +51,9 @@ var (
 	errUpdateChannels   = errors.New("failed to update channels in bootstrap configuration database")
 	errRemoveChannels   = errors.New("failed to remove channels from bootstrap configuration in database")
 	errDisconnectThing  = errors.New("failed to disconnect thing in bootstrap configuration in database")
+	errSaveVersion      = errors.New("failed to save bootstrap configuration version to database")
+	errRetrieveVersions = errors.New("failed to retrieve bootstrap configuration versions from database")
+	errRollback         = errors.New("failed to rollback bootstrap configuration in database")
 )
 
 var _ bootstrap.ConfigRepository = (*configRepository)(nil)
@@ -56,9 +70,6 @@ func NewConfigRepository(db *sqlx.DB, log logger.Logger) bootstrap.ConfigReposit
 }
 
 func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (string, error) {
-	q := `INSERT INTO configs (mainflux_thing, owner, name, client_cert, client_key, ca_cert, mainflux_key, external_id, external_key, content, state)
-		  VALUES (:mainflux_thing, :owner, :name, :client_cert, :client_key, :ca_cert, :mainflux_key, :external_id, :external_key, :content, :state)`
-
 	tx, err := cr.db.Beginx()
 	if err != nil {
 		return "", errors.Wrap(errSaveDB, err)
@@ -66,7 +77,7 @@ func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (stri
 
 	dbcfg := toDBConfig(cfg)
 
-	if _, err := tx.NamedExec(q, dbcfg); err != nil {
+	if _, err := tx.NamedExec(saveConfigQuery, dbcfg); err != nil {
 		e := err
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == duplicateErr {
 			e = bootstrap.ErrConflict
@@ -89,6 +100,12 @@ func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (stri
 		return "", errors.Wrap(errSaveConnections, err)
 	}
 
+	if err := saveVersion(cfg.Owner, cfg.MFThing, tx); err != nil {
+		cr.rollback("Failed to save Config version", tx, err)
+
+		return "", errors.Wrap(errSaveVersion, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		cr.rollback("Failed to commit Config save", tx, err)
 	}
@@ -96,8 +113,71 @@ func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (stri
 	return cfg.MFThing, nil
 }
 
+func (cr configRepository) SaveAll(cfgs []bootstrap.Config, channels []bootstrap.Channel) error {
+	if len(cfgs) == 0 {
+		return nil
+	}
+
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errSaveDB, err)
+	}
+
+	if err := insertChannels(cfgs[0].Owner, channels, tx); err != nil {
+		cr.rollback("Failed to insert Channels", tx, err)
+
+		return errors.Wrap(errSaveChannels, err)
+	}
+
+	stmt, err := tx.PrepareNamed(saveConfigQuery)
+	if err != nil {
+		cr.rollback("Failed to prepare Config insert", tx, err)
+
+		return errors.Wrap(errSaveDB, err)
+	}
+	defer stmt.Close()
+
+	for _, cfg := range cfgs {
+		if _, err := stmt.Exec(toDBConfig(cfg)); err != nil {
+			e := err
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == duplicateErr {
+				e = bootstrap.ErrConflict
+			}
+
+			cr.rollback("Failed to insert a Config", tx, err)
+
+			return errors.Wrap(errSaveDB, e)
+		}
+
+		var conns []string
+		for _, ch := range cfg.MFChannels {
+			conns = append(conns, ch.ID)
+		}
+
+		if err := insertConnections(cfg, conns, tx); err != nil {
+			cr.rollback("Failed to insert connections", tx, err)
+
+			return errors.Wrap(errSaveConnections, err)
+		}
+
+		if err := saveVersion(cfg.Owner, cfg.MFThing, tx); err != nil {
+			cr.rollback("Failed to save Config version", tx, err)
+
+			return errors.Wrap(errSaveVersion, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		cr.rollback("Failed to commit Configs save", tx, err)
+
+		return errors.Wrap(errSaveDB, err)
+	}
+
+	return nil
+}
+
 func (cr configRepository) RetrieveByID(owner, id string) (bootstrap.Config, error) {
-	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, state
+	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, template_id, format, state, version, fetched_version
 		  FROM configs
 		  WHERE mainflux_thing = $1 AND owner = $2`
 
@@ -153,7 +233,7 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 	search, params := cr.retrieveAll(owner, filter)
 	n := len(params)
 
-	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, state
+	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, template_id, format, state, version, fetched_version
 	      FROM configs %s ORDER BY mainflux_thing LIMIT $%d OFFSET $%d`
 	q = fmt.Sprintf(q, search, n+1, n+2)
 
@@ -164,18 +244,20 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 	}
 	defer rows.Close()
 
-	var name, content sql.NullString
+	var name, content, templateID, format sql.NullString
 	configs := []bootstrap.Config{}
 
 	for rows.Next() {
 		c := bootstrap.Config{Owner: owner}
-		if err := rows.Scan(&c.MFThing, &c.MFKey, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State); err != nil {
+		if err := rows.Scan(&c.MFThing, &c.MFKey, &c.ExternalID, &c.ExternalKey, &name, &content, &templateID, &format, &c.State, &c.Version, &c.FetchedVersion); err != nil {
 			cr.log.Error(fmt.Sprintf("Failed to read retrieved config due to %s", err))
 			return bootstrap.ConfigsPage{}
 		}
 
 		c.Name = name.String
 		c.Content = content.String
+		c.TemplateID = templateID.String
+		c.Format = format.String
 		configs = append(configs, c)
 	}
 
@@ -196,7 +278,7 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 }
 
 func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Config, error) {
-	q := `SELECT mainflux_thing, mainflux_key, external_key, owner, name, client_cert, client_key, ca_cert, content, state
+	q := `SELECT mainflux_thing, mainflux_key, external_key, owner, name, client_cert, client_key, ca_cert, content, template_id, format, state, version, fetched_version
 		  FROM configs
 		  WHERE external_id = $1`
 	dbcfg := dbConfig{
@@ -247,23 +329,34 @@ func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Co
 }
 
 func (cr configRepository) Update(cfg bootstrap.Config) error {
-	q := `UPDATE configs SET name = $1, content = $2 WHERE mainflux_thing = $3 AND owner = $4`
+	q := `UPDATE configs SET name = $1, content = $2, template_id = $3, format = $4 WHERE mainflux_thing = $5 AND owner = $6`
 
 	content := nullString(cfg.Content)
 	name := nullString(cfg.Name)
+	templateID := nullString(cfg.TemplateID)
+	format := nullString(cfg.Format)
 
-	res, err := cr.db.Exec(q, name, content, cfg.MFThing, cfg.Owner)
+	tx, err := cr.db.Beginx()
 	if err != nil {
 		return errors.Wrap(errUpdate, err)
 	}
 
-	cnt, err := res.RowsAffected()
-	if err != nil {
+	if err := updateConfig(tx, q, name, content, templateID, format, cfg.MFThing, cfg.Owner); err != nil {
+		cr.rollback("Failed to update Config", tx, err)
+
 		return errors.Wrap(errUpdate, err)
 	}
 
-	if cnt == 0 {
-		return bootstrap.ErrNotFound
+	if err := newVersion(cfg.Owner, cfg.MFThing, tx); err != nil {
+		cr.rollback("Failed to save Config version", tx, err)
+
+		return errors.Wrap(errSaveVersion, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		cr.rollback("Failed to commit Config update", tx, err)
+
+		return errors.Wrap(errUpdate, err)
 	}
 
 	return nil
@@ -272,18 +365,27 @@ func (cr configRepository) Update(cfg bootstrap.Config) error {
 func (cr configRepository) UpdateCert(owner, thingID, clientCert, clientKey, caCert string) error {
 	q := `UPDATE configs SET client_cert = $1, client_key = $2, ca_cert = $3 WHERE mainflux_thing = $4 AND owner = $5`
 
-	res, err := cr.db.Exec(q, clientCert, clientKey, caCert, thingID, owner)
+	tx, err := cr.db.Beginx()
 	if err != nil {
 		return err
 	}
 
-	cnt, err := res.RowsAffected()
-	if err != nil {
+	if err := updateConfig(tx, q, clientCert, clientKey, caCert, thingID, owner); err != nil {
+		cr.rollback("Failed to update Config certificates", tx, err)
+
 		return err
 	}
 
-	if cnt == 0 {
-		return bootstrap.ErrNotFound
+	if err := newVersion(owner, thingID, tx); err != nil {
+		cr.rollback("Failed to save Config version", tx, err)
+
+		return errors.Wrap(errSaveVersion, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		cr.rollback("Failed to commit Config certificates update", tx, err)
+
+		return err
 	}
 
 	return nil
@@ -312,6 +414,12 @@ func (cr configRepository) UpdateConnections(owner, id string, channels []bootst
 		return err
 	}
 
+	if err := newVersion(owner, id, tx); err != nil {
+		cr.rollback("Failed to save Config version", tx, err)
+
+		return errors.Wrap(errSaveVersion, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		cr.rollback("Failed to commit Config update", tx, err)
 	}
@@ -319,6 +427,138 @@ func (cr configRepository) UpdateConnections(owner, id string, channels []bootst
 	return nil
 }
 
+func (cr configRepository) RetrieveVersions(owner, id string, offset, limit uint64) (bootstrap.ConfigVersionsPage, error) {
+	var total uint64
+	q := `SELECT COUNT(*) FROM config_versions WHERE config_id = $1 AND config_owner = $2`
+	if err := cr.db.Get(&total, q, id, owner); err != nil {
+		return bootstrap.ConfigVersionsPage{}, errors.Wrap(errRetrieveVersions, err)
+	}
+
+	// Every Config has at least the initial version.
+	if total == 0 {
+		return bootstrap.ConfigVersionsPage{}, bootstrap.ErrNotFound
+	}
+
+	q = `SELECT version, content, template_id, client_cert, client_key_digest, ca_cert, channels, created_at
+		 FROM config_versions
+		 WHERE config_id = $1 AND config_owner = $2 ORDER BY version LIMIT $3 OFFSET $4`
+
+	rows, err := cr.db.Queryx(q, id, owner, limit, offset)
+	if err != nil {
+		return bootstrap.ConfigVersionsPage{}, errors.Wrap(errRetrieveVersions, err)
+	}
+	defer rows.Close()
+
+	versions := []bootstrap.ConfigVersion{}
+	for rows.Next() {
+		dbver := dbConfigVersion{}
+		if err := rows.StructScan(&dbver); err != nil {
+			cr.log.Error(fmt.Sprintf("Failed to read retrieved config version due to %s", err))
+			return bootstrap.ConfigVersionsPage{}, errors.Wrap(errRetrieveVersions, err)
+		}
+		versions = append(versions, toConfigVersion(dbver))
+	}
+
+	return bootstrap.ConfigVersionsPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Versions: versions,
+	}, nil
+}
+
+func (cr configRepository) RetrieveVersion(owner, id string, version uint64) (bootstrap.ConfigVersion, error) {
+	q := `SELECT version, content, template_id, client_cert, client_key_digest, ca_cert, channels, created_at
+		  FROM config_versions
+		  WHERE config_id = $1 AND config_owner = $2 AND version = $3`
+
+	dbver := dbConfigVersion{}
+	if err := cr.db.QueryRowx(q, id, owner, version).StructScan(&dbver); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.ConfigVersion{}, errors.Wrap(bootstrap.ErrNotFound, err)
+		}
+
+		return bootstrap.ConfigVersion{}, errors.Wrap(errRetrieveVersions, err)
+	}
+
+	return toConfigVersion(dbver), nil
+}
+
+func (cr configRepository) Rollback(owner, id string, ver bootstrap.ConfigVersion, channels []bootstrap.Channel) error {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	if err := insertChannels(owner, channels, tx); err != nil {
+		cr.rollback("Failed to insert Channels during the rollback", tx, err)
+
+		return errors.Wrap(errSaveChannels, err)
+	}
+
+	cur, err := currentVersion(owner, id, tx)
+	if err != nil {
+		cr.rollback("Failed to retrieve Config during the rollback", tx, err)
+
+		if err == sql.ErrNoRows {
+			return errors.Wrap(errRollback, bootstrap.ErrNotFound)
+		}
+		return errors.Wrap(errRollback, err)
+	}
+
+	// Versions don't contain the client key, so the certificates are
+	// restored only if they are issued for the current key.
+	clientCert, caCert := cur.ClientCert, cur.CACert
+	if cur.ClientKeyDigest.String == ver.ClientKeyDigest {
+		clientCert, caCert = nullString(ver.ClientCert), nullString(ver.CACert)
+	}
+
+	q := `UPDATE configs SET content = $1, template_id = $2, client_cert = $3, ca_cert = $4
+		  WHERE mainflux_thing = $5 AND owner = $6`
+	content := nullString(ver.Content)
+	templateID := nullString(ver.TemplateID)
+
+	if err := updateConfig(tx, q, content, templateID, clientCert, caCert, id, owner); err != nil {
+		cr.rollback("Failed to restore Config", tx, err)
+
+		return errors.Wrap(errRollback, err)
+	}
+
+	q = `DELETE FROM connections WHERE config_id = $1 AND config_owner = $2`
+	if _, err := tx.Exec(q, id, owner); err != nil {
+		cr.rollback("Failed to remove connections during the rollback", tx, err)
+
+		return errors.Wrap(errRollback, err)
+	}
+
+	cfg := bootstrap.Config{MFThing: id, Owner: owner}
+	if err := insertConnections(cfg, ver.Channels, tx); err != nil {
+		cr.rollback("Failed to restore connections", tx, err)
+
+		return errors.Wrap(errSaveConnections, err)
+	}
+
+	if _, err := tx.Exec(cleanupQuery); err != nil {
+		cr.rollback("Failed to clean dangling channels during the rollback", tx, err)
+
+		return errors.Wrap(errRollback, err)
+	}
+
+	if err := newVersion(owner, id, tx); err != nil {
+		cr.rollback("Failed to save Config version", tx, err)
+
+		return errors.Wrap(errSaveVersion, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		cr.rollback("Failed to commit Config rollback", tx, err)
+
+		return errors.Wrap(errRollback, err)
+	}
+
+	return nil
+}
+
 func (cr configRepository) Remove(owner, id string) error {
 	q := `DELETE FROM configs WHERE mainflux_thing = $1 AND owner = $2`
 	if _, err := cr.db.Exec(q, id, owner); err != nil {
@@ -410,22 +650,72 @@ func (cr configRepository) UpdateChannel(c bootstrap.Channel) error {
 }
 
 func (cr configRepository) RemoveChannel(id string) error {
-	q := `DELETE FROM channels WHERE mainflux_channel = $1`
-	if _, err := cr.db.Exec(q, id); err != nil {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errRemoveChannels, err)
+	}
+
+	q := `SELECT config_id, config_owner FROM connections WHERE channel_id = $1`
+	var conns []dbConnection
+	if err := tx.Select(&conns, q, id); err != nil {
+		cr.rollback("Failed to retrieve Channel connections", tx, err)
+
+		return errors.Wrap(errRemoveChannels, err)
+	}
+
+	q = `DELETE FROM channels WHERE mainflux_channel = $1`
+	if _, err := tx.Exec(q, id); err != nil {
+		cr.rollback("Failed to remove Channel", tx, err)
+
+		return errors.Wrap(errRemoveChannels, err)
+	}
+
+	for _, conn := range conns {
+		if err := newVersion(conn.ConfigOwner, conn.Config, tx); err != nil {
+			cr.rollback("Failed to save Config version", tx, err)
+
+			return errors.Wrap(errSaveVersion, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		cr.rollback("Failed to commit Channel removal", tx, err)
+
 		return errors.Wrap(errRemoveChannels, err)
 	}
+
 	return nil
 }
 
 func (cr configRepository) DisconnectThing(channelID, thingID string) error {
-	q := `UPDATE configs SET state = $1 WHERE EXISTS (
+	q := `UPDATE configs SET state = $1 WHERE mainflux_thing = $2 AND state != $4 AND EXISTS (
 		SELECT 1 FROM connections WHERE config_id = $2 AND channel_id = $3)`
-	if _, err := cr.db.Exec(q, bootstrap.Inactive, thingID, channelID); err != nil {
+	if _, err := cr.db.Exec(q, bootstrap.Inactive, thingID, channelID, bootstrap.Decommissioned); err != nil {
 		return errors.Wrap(errDisconnectThing, err)
 	}
 	return nil
 }
 
+func (cr configRepository) UpdateFetchedVersion(id string, version uint64) error {
+	q := `UPDATE configs SET fetched_version = $1 WHERE mainflux_thing = $2`
+
+	res, err := cr.db.Exec(q, version, id)
+	if err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+
+	if cnt == 0 {
+		return bootstrap.ErrNotFound
+	}
+
+	return nil
+}
+
 func (cr configRepository) retrieveAll(owner string, filter bootstrap.Filter) (string, []interface{}) {
 	template := `WHERE owner = $1 %s`
 	params := []interface{}{owner}
@@ -552,6 +842,88 @@ func updateConnections(owner, id string, connections []string, tx *sqlx.Tx) erro
 	return err
 }
 
+// updateConfig executes the given Config update query returning
+// ErrNotFound if there is no Config to update.
+func updateConfig(tx *sqlx.Tx, query string, args ...interface{}) error {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if cnt == 0 {
+		return bootstrap.ErrNotFound
+	}
+
+	return nil
+}
+
+// currentVersion returns the current state of the Config as its version,
+// containing the digest of the client key instead of the key itself.
+func currentVersion(owner, id string, tx *sqlx.Tx) (dbConfigVersion, error) {
+	ver := dbConfigVersion{
+		ConfigID:    id,
+		ConfigOwner: owner,
+		CreatedAt:   time.Now(),
+	}
+
+	var clientKey sql.NullString
+	row := tx.QueryRowx(currentVersionQuery, id, owner)
+	if err := row.Scan(&ver.Version, &ver.Content, &ver.TemplateID, &ver.ClientCert, &clientKey, &ver.CACert, &ver.Channels); err != nil {
+		return dbConfigVersion{}, err
+	}
+	ver.ClientKeyDigest = nullString(bootstrap.KeyDigest(clientKey.String))
+
+	return ver, nil
+}
+
+// saveVersion saves the current state of the Config as its current version.
+func saveVersion(owner, id string, tx *sqlx.Tx) error {
+	ver, err := currentVersion(owner, id, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExec(saveVersionQuery, ver)
+
+	return err
+}
+
+// newVersion increments the Config version and saves the current state of
+// the Config as the new version. If the versioned fields didn't change since
+// the latest version, e.g. only the Config name is updated, the new version
+// is not saved.
+func newVersion(owner, id string, tx *sqlx.Tx) error {
+	ver, err := currentVersion(owner, id, tx)
+	if err != nil {
+		return err
+	}
+
+	latest := dbConfigVersion{}
+	err = tx.QueryRowx(latestVersionQuery, id, owner).StructScan(&latest)
+	switch {
+	case err == sql.ErrNoRows:
+		// Config has no versions yet, so there is nothing to compare to.
+	case err != nil:
+		return err
+	case len(bootstrap.Diff(toConfigVersion(latest), toConfigVersion(ver)).Changed) == 0:
+		return nil
+	}
+
+	if _, err := tx.Exec(incVersionQuery, id, owner); err != nil {
+		return err
+	}
+	ver.Version++
+
+	_, err = tx.NamedExec(saveVersionQuery, ver)
+
+	return err
+}
+
 func nullString(s string) sql.NullString {
 	if s == "" {
 		return sql.NullString{}
@@ -564,17 +936,21 @@ func nullString(s string) sql.NullString {
 }
 
 type dbConfig struct {
-	MFThing     string          `db:"mainflux_thing"`
-	Owner       string          `db:"owner"`
-	Name        sql.NullString  `db:"name"`
-	ClientCert  sql.NullString  `db:"client_cert"`
-	ClientKey   sql.NullString  `db:"client_key"`
-	CaCert      sql.NullString  `db:"ca_cert"`
-	MFKey       string          `db:"mainflux_key"`
-	ExternalID  string          `db:"external_id"`
-	ExternalKey string          `db:"external_key"`
-	Content     sql.NullString  `db:"content"`
-	State       bootstrap.State `db:"state"`
+	MFThing        string          `db:"mainflux_thing"`
+	Owner          string          `db:"owner"`
+	Name           sql.NullString  `db:"name"`
+	ClientCert     sql.NullString  `db:"client_cert"`
+	ClientKey      sql.NullString  `db:"client_key"`
+	CaCert         sql.NullString  `db:"ca_cert"`
+	MFKey          string          `db:"mainflux_key"`
+	ExternalID     string          `db:"external_id"`
+	ExternalKey    string          `db:"external_key"`
+	Content        sql.NullString  `db:"content"`
+	TemplateID     sql.NullString  `db:"template_id"`
+	Format         sql.NullString  `db:"format"`
+	State          bootstrap.State `db:"state"`
+	Version        uint64          `db:"version"`
+	FetchedVersion uint64          `db:"fetched_version"`
 }
 
 func toDBConfig(cfg bootstrap.Config) dbConfig {
@@ -589,18 +965,23 @@ func toDBConfig(cfg bootstrap.Config) dbConfig {
 		ExternalID:  cfg.ExternalID,
 		ExternalKey: cfg.ExternalKey,
 		Content:     nullString(cfg.Content),
+		TemplateID:  nullString(cfg.TemplateID),
+		Format:      nullString(cfg.Format),
 		State:       cfg.State,
+		Version:     cfg.Version,
 	}
 }
 
 func toConfig(dbcfg dbConfig) bootstrap.Config {
 	cfg := bootstrap.Config{
-		MFThing:     dbcfg.MFThing,
-		Owner:       dbcfg.Owner,
-		MFKey:       dbcfg.MFKey,
-		ExternalID:  dbcfg.ExternalID,
-		ExternalKey: dbcfg.ExternalKey,
-		State:       dbcfg.State,
+		MFThing:        dbcfg.MFThing,
+		Owner:          dbcfg.Owner,
+		MFKey:          dbcfg.MFKey,
+		ExternalID:     dbcfg.ExternalID,
+		ExternalKey:    dbcfg.ExternalKey,
+		State:          dbcfg.State,
+		Version:        dbcfg.Version,
+		FetchedVersion: dbcfg.FetchedVersion,
 	}
 
 	if dbcfg.Name.Valid {
@@ -611,6 +992,14 @@ func toConfig(dbcfg dbConfig) bootstrap.Config {
 		cfg.Content = dbcfg.Content.String
 	}
 
+	if dbcfg.TemplateID.Valid {
+		cfg.TemplateID = dbcfg.TemplateID.String
+	}
+
+	if dbcfg.Format.Valid {
+		cfg.Format = dbcfg.Format.String
+	}
+
 	if dbcfg.ClientCert.Valid {
 		cfg.ClientCert = dbcfg.ClientCert.String
 	}
@@ -670,3 +1059,29 @@ type dbConnection struct {
 	ConfigOwner  string `db:"config_owner"`
 	ChannelOwner string `db:"channel_owner"`
 }
+
+type dbConfigVersion struct {
+	ConfigID        string         `db:"config_id"`
+	ConfigOwner     string         `db:"config_owner"`
+	Version         uint64         `db:"version"`
+	Content         sql.NullString `db:"content"`
+	TemplateID      sql.NullString `db:"template_id"`
+	ClientCert      sql.NullString `db:"client_cert"`
+	ClientKeyDigest sql.NullString `db:"client_key_digest"`
+	CACert          sql.NullString `db:"ca_cert"`
+	Channels        pq.StringArray `db:"channels"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
+
+func toConfigVersion(dbver dbConfigVersion) bootstrap.ConfigVersion {
+	return bootstrap.ConfigVersion{
+		Version:         dbver.Version,
+		Content:         dbver.Content.String,
+		TemplateID:      dbver.TemplateID.String,
+		ClientCert:      dbver.ClientCert.String,
+		ClientKeyDigest: dbver.ClientKeyDigest.String,
+		CACert:          dbver.CACert.String,
+		Channels:        []string(dbver.Channels),
+		CreatedAt:       dbver.CreatedAt,
+	}
+}
@@ -95,6 +95,54 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestSaveAll(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	var cfgs []bootstrap.Config
+	for i := 0; i < numConfigs; i++ {
+		c := config
+		// Use UUID to prevent conflicts.
+		uid, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+		c.MFKey = uid.String()
+		c.MFThing = uid.String()
+		c.ExternalID = uid.String()
+		cfgs = append(cfgs, c)
+	}
+
+	duplicate := cfgs[0]
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+	duplicate.MFThing = uid.String()
+	duplicate.MFKey = uid.String()
+
+	cases := []struct {
+		desc     string
+		configs  []bootstrap.Config
+		channels []bootstrap.Channel
+		err      error
+	}{
+		{
+			desc:     "save configs",
+			configs:  cfgs,
+			channels: config.MFChannels,
+			err:      nil,
+		},
+		{
+			desc:     "save configs with same external ID",
+			configs:  []bootstrap.Config{duplicate},
+			channels: nil,
+			err:      bootstrap.ErrConflict,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.SaveAll(tc.configs, tc.channels)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestRetrieveByID(t *testing.T) {
 	repo := postgres.NewConfigRepository(db, testLog)
 	err := deleteChannels(repo)
@@ -444,6 +492,146 @@ func TestUpdateConnections(t *testing.T) {
 	}
 }
 
+func TestRetrieveVersions(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := config
+	// Use UUID to prevent conflicts.
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+	c.MFKey = uid.String()
+	c.MFThing = uid.String()
+	c.ExternalID = uid.String()
+	c.ExternalKey = uid.String()
+	c.Version = 1
+	c.ClientKey = "key"
+	id, err := repo.Save(c, channels)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	// Name is not versioned.
+	c.Name = "new name"
+	err = repo.Update(c)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	c.Content = "new content"
+	err = repo.Update(c)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc   string
+		owner  string
+		id     string
+		offset uint64
+		limit  uint64
+		size   int
+		err    error
+	}{
+		{
+			desc:   "retrieve all versions",
+			owner:  c.Owner,
+			id:     id,
+			offset: 0,
+			limit:  10,
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve a page of versions",
+			owner:  c.Owner,
+			id:     id,
+			offset: 1,
+			limit:  10,
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve versions of a non-existing config",
+			owner:  c.Owner,
+			id:     wrongID,
+			offset: 0,
+			limit:  10,
+			size:   0,
+			err:    bootstrap.ErrNotFound,
+		},
+		{
+			desc:   "retrieve versions with wrong owner",
+			owner:  "wrong",
+			id:     id,
+			offset: 0,
+			limit:  10,
+			size:   0,
+			err:    bootstrap.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		page, err := repo.RetrieveVersions(tc.owner, tc.id, tc.offset, tc.limit)
+		assert.Equal(t, tc.size, len(page.Versions), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Versions)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ver, err := repo.RetrieveVersion(c.Owner, id, 1)
+	require.Nil(t, err, fmt.Sprintf("Retrieving version expected to succeed: %s.\n", err))
+	assert.Equal(t, config.Content, ver.Content, fmt.Sprintf("expected content %s got %s\n", config.Content, ver.Content))
+	assert.Equal(t, len(channels), len(ver.Channels), fmt.Sprintf("expected %d channels got %d\n", len(channels), len(ver.Channels)))
+	assert.Equal(t, bootstrap.KeyDigest(c.ClientKey), ver.ClientKeyDigest, fmt.Sprintf("expected client key digest %s got %s\n", bootstrap.KeyDigest(c.ClientKey), ver.ClientKeyDigest))
+}
+
+func TestRollback(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := config
+	// Use UUID to prevent conflicts.
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+	c.MFKey = uid.String()
+	c.MFThing = uid.String()
+	c.ExternalID = uid.String()
+	c.ExternalKey = uid.String()
+	c.Version = 1
+	id, err := repo.Save(c, channels)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	ver, err := repo.RetrieveVersion(c.Owner, id, 1)
+	require.Nil(t, err, fmt.Sprintf("Retrieving version expected to succeed: %s.\n", err))
+
+	c.Content = "new content"
+	err = repo.Update(c)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "rollback a non-existing config",
+			owner: c.Owner,
+			id:    wrongID,
+			err:   bootstrap.ErrNotFound,
+		},
+		{
+			desc:  "rollback a config",
+			owner: c.Owner,
+			id:    id,
+			err:   nil,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.Rollback(tc.owner, tc.id, ver, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	cfg, err := repo.RetrieveByID(c.Owner, id)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, config.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", config.Content, cfg.Content))
+	assert.Equal(t, uint64(3), cfg.Version, fmt.Sprintf("expected version 3 got %d\n", cfg.Version))
+}
+
 func TestRemove(t *testing.T) {
 	repo := postgres.NewConfigRepository(db, testLog)
 	err := deleteChannels(repo)
@@ -660,6 +848,11 @@ func TestRemoveChannel(t *testing.T) {
 	cfg, err := repo.RetrieveByID(c.Owner, c.MFThing)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 	assert.NotContains(t, cfg.MFChannels, c.MFChannels[0], fmt.Sprintf("expected to remove channel %s from %s", c.MFChannels[0], cfg.MFChannels))
+
+	ver, err := repo.RetrieveVersion(c.Owner, c.MFThing, cfg.Version)
+	require.Nil(t, err, fmt.Sprintf("Retrieving version expected to succeed: %s.\n", err))
+	assert.Equal(t, c.Version+1, ver.Version, fmt.Sprintf("expected version %d got %d\n", c.Version+1, ver.Version))
+	assert.NotContains(t, ver.Channels, c.MFChannels[0].ID, fmt.Sprintf("expected to remove channel %s from version channels %v", c.MFChannels[0].ID, ver.Channels))
 }
 
 func TestDisconnectThing(t *testing.T) {
@@ -683,7 +876,7 @@ func TestDisconnectThing(t *testing.T) {
 
 	cfg, err := repo.RetrieveByID(c.Owner, c.MFThing)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
-	assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected ti be inactive when a connection is removed from %s", cfg))
+	assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected ti be inactive when a connection is removed from %v", cfg))
 }
 
 func deleteChannels(repo bootstrap.ConfigRepository) error {
@@ -100,6 +100,65 @@ func migrateDB(db *sqlx.DB) error {
 					"CREATE TABLE IF NOT EXISTS unknown_configs",
 				},
 			},
+			{
+				Id: "configs_3",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS templates (
+						id      UUID UNIQUE NOT NULL,
+						owner   VARCHAR(254) NOT NULL,
+						name    TEXT,
+						content TEXT NOT NULL,
+						PRIMARY KEY (id, owner)
+					)`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS template_id UUID REFERENCES templates (id) ON DELETE RESTRICT`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS template_id",
+					"DROP TABLE templates",
+				},
+			},
+			{
+				Id: "configs_4",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS fetched_version BIGINT NOT NULL DEFAULT 0`,
+					`CREATE TABLE IF NOT EXISTS config_versions (
+						config_id         TEXT,
+						config_owner      VARCHAR(254),
+						version           BIGINT NOT NULL,
+						content           TEXT,
+						template_id       UUID,
+						client_cert       TEXT,
+						client_key_digest TEXT,
+						ca_cert           TEXT,
+						channels          TEXT[],
+						created_at        TIMESTAMP NOT NULL,
+						FOREIGN KEY (config_id, config_owner) REFERENCES configs (mainflux_thing, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (config_id, config_owner, version)
+					)`,
+					// Client keys are never copied to the versions, and the
+					// backfilled versions have no key digest, so their
+					// certificates aren't restored on rollback.
+					`INSERT INTO config_versions (config_id, config_owner, version, content, template_id, client_cert, ca_cert, channels, created_at)
+					 SELECT c.mainflux_thing, c.owner, c.version, c.content, c.template_id, c.client_cert, c.ca_cert,
+					 ARRAY(SELECT channel_id FROM connections WHERE config_id = c.mainflux_thing AND config_owner = c.owner ORDER BY channel_id), NOW()
+					 FROM configs c`,
+				},
+				Down: []string{
+					"DROP TABLE config_versions",
+					"ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS fetched_version",
+					"ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS version",
+				},
+			},
+			{
+				Id: "configs_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS format TEXT`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS format",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/bootstrap"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSaveTemplate     = errors.New("failed to save template to database")
+	errRetrieveTemplate = errors.New("failed to retrieve template from database")
+	errUpdateTemplate   = errors.New("failed to update template in database")
+	errRemoveTemplate   = errors.New("failed to remove template from database")
+)
+
+var _ bootstrap.TemplateRepository = (*templateRepository)(nil)
+
+type templateRepository struct {
+	db  *sqlx.DB
+	log logger.Logger
+}
+
+// NewTemplateRepository instantiates a PostgreSQL implementation of template
+// repository.
+func NewTemplateRepository(db *sqlx.DB, log logger.Logger) bootstrap.TemplateRepository {
+	return &templateRepository{db: db, log: log}
+}
+
+func (tr templateRepository) Save(tpl bootstrap.Template) (string, error) {
+	q := `INSERT INTO templates (id, owner, name, content) VALUES (:id, :owner, :name, :content)`
+
+	if _, err := tr.db.NamedExec(q, toDBTemplate(tpl)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == duplicateErr {
+			return "", errors.Wrap(errSaveTemplate, bootstrap.ErrConflict)
+		}
+
+		return "", errors.Wrap(errSaveTemplate, err)
+	}
+
+	return tpl.ID, nil
+}
+
+func (tr templateRepository) RetrieveByID(owner, id string) (bootstrap.Template, error) {
+	q := `SELECT id, owner, name, content FROM templates WHERE id = $1 AND owner = $2`
+
+	dbtpl := dbTemplate{}
+	if err := tr.db.QueryRowx(q, id, owner).StructScan(&dbtpl); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.Template{}, errors.Wrap(bootstrap.ErrNotFound, err)
+		}
+		if pqErr, ok := err.(*pq.Error); ok && strings.Contains(pqErr.Message, uuidErr) {
+			return bootstrap.Template{}, errors.Wrap(bootstrap.ErrNotFound, err)
+		}
+
+		return bootstrap.Template{}, errors.Wrap(errRetrieveTemplate, err)
+	}
+
+	return toTemplate(dbtpl), nil
+}
+
+func (tr templateRepository) RetrieveAll(owner string, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	q := `SELECT id, owner, name, content FROM templates WHERE owner = $1 ORDER BY id LIMIT $2 OFFSET $3`
+
+	rows, err := tr.db.Queryx(q, owner, limit, offset)
+	if err != nil {
+		return bootstrap.TemplatesPage{}, errors.Wrap(errRetrieveTemplate, err)
+	}
+	defer rows.Close()
+
+	tpls := []bootstrap.Template{}
+	for rows.Next() {
+		dbtpl := dbTemplate{}
+		if err := rows.StructScan(&dbtpl); err != nil {
+			return bootstrap.TemplatesPage{}, errors.Wrap(errRetrieveTemplate, err)
+		}
+		tpls = append(tpls, toTemplate(dbtpl))
+	}
+
+	var total uint64
+	if err := tr.db.Get(&total, `SELECT COUNT(*) FROM templates WHERE owner = $1`, owner); err != nil {
+		return bootstrap.TemplatesPage{}, errors.Wrap(errRetrieveTemplate, err)
+	}
+
+	return bootstrap.TemplatesPage{
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Templates: tpls,
+	}, nil
+}
+
+func (tr templateRepository) Update(tpl bootstrap.Template) error {
+	q := `UPDATE templates SET name = :name, content = :content WHERE id = :id AND owner = :owner`
+
+	res, err := tr.db.NamedExec(q, toDBTemplate(tpl))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && strings.Contains(pqErr.Message, uuidErr) {
+			return errors.Wrap(errUpdateTemplate, bootstrap.ErrNotFound)
+		}
+
+		return errors.Wrap(errUpdateTemplate, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateTemplate, err)
+	}
+
+	if cnt == 0 {
+		return bootstrap.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr templateRepository) Remove(owner, id string) error {
+	q := `DELETE FROM templates WHERE id = $1 AND owner = $2`
+
+	if _, err := tr.db.Exec(q, id, owner); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == fkViolation {
+			return errors.Wrap(errRemoveTemplate, bootstrap.ErrConflict)
+		}
+
+		return errors.Wrap(errRemoveTemplate, err)
+	}
+
+	return nil
+}
+
+type dbTemplate struct {
+	ID      string         `db:"id"`
+	Owner   string         `db:"owner"`
+	Name    sql.NullString `db:"name"`
+	Content string         `db:"content"`
+}
+
+func toDBTemplate(tpl bootstrap.Template) dbTemplate {
+	return dbTemplate{
+		ID:      tpl.ID,
+		Owner:   tpl.Owner,
+		Name:    nullString(tpl.Name),
+		Content: tpl.Content,
+	}
+}
+
+func toTemplate(dbtpl dbTemplate) bootstrap.Template {
+	return bootstrap.Template{
+		ID:      dbtpl.ID,
+		Owner:   dbtpl.Owner,
+		Name:    dbtpl.Name.String,
+		Content: dbtpl.Content,
+	}
+}
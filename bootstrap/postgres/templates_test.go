@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/mainflux/mainflux/bootstrap"
+	"github.com/mainflux/mainflux/bootstrap/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var template = bootstrap.Template{
+	Owner:   "user@email.com",
+	Name:    "template",
+	Content: "{{.ExternalID}}",
+}
+
+func TestSaveTemplate(t *testing.T) {
+	repo := postgres.NewTemplateRepository(db, testLog)
+
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	tpl := template
+	tpl.ID = uid.String()
+
+	cases := []struct {
+		desc     string
+		template bootstrap.Template
+		err      error
+	}{
+		{
+			desc:     "save a template",
+			template: tpl,
+			err:      nil,
+		},
+		{
+			desc:     "save a template with the same ID",
+			template: tpl,
+			err:      bootstrap.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(tc.template)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveTemplateByID(t *testing.T) {
+	repo := postgres.NewTemplateRepository(db, testLog)
+
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	tpl := template
+	tpl.ID = uid.String()
+	_, err = repo.Save(tpl)
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve template",
+			owner: tpl.Owner,
+			id:    tpl.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve template with wrong owner",
+			owner: "2",
+			id:    tpl.ID,
+			err:   bootstrap.ErrNotFound,
+		},
+		{
+			desc:  "retrieve template with malformed ID",
+			owner: tpl.Owner,
+			id:    wrongID,
+			err:   bootstrap.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.RetrieveByID(tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	repo := postgres.NewTemplateRepository(db, testLog)
+	configRepo := postgres.NewConfigRepository(db, testLog)
+
+	uid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	used := template
+	used.ID = uid.String()
+	_, err = repo.Save(used)
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	c := config
+	c.MFThing = uid.String()
+	c.MFKey = uid.String()
+	c.ExternalID = uid.String()
+	c.MFChannels = nil
+	c.TemplateID = used.ID
+	_, err = configRepo.Save(c, nil)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	uid, err = uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
+
+	unused := template
+	unused.ID = uid.String()
+	_, err = repo.Save(unused)
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove a template used by a config",
+			id:   used.ID,
+			err:  bootstrap.ErrConflict,
+		},
+		{
+			desc: "remove a template",
+			id:   unused.ID,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(template.Owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
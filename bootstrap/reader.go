@@ -4,31 +4,51 @@
 package bootstrap
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/json"
 	"io"
 	"net/http"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Supported formats of the bootstrap response.
+const (
+	JSONFormat = "json"
+	TOMLFormat = "toml"
+	YAMLFormat = "yaml"
+	RawFormat  = "raw"
+)
+
+var (
+	_ ConfigReader = (*reader)(nil)
+	_ ConfigReader = (*tomlReader)(nil)
+	_ ConfigReader = (*yamlReader)(nil)
+	_ ConfigReader = (*rawReader)(nil)
 )
 
 // bootstrapRes represent Mainflux Response to the Bootatrap request.
 // This is used as a response from ConfigReader and can easily be
 // replace with any other response format.
 type bootstrapRes struct {
-	MFThing    string       `json:"mainflux_id"`
-	MFKey      string       `json:"mainflux_key"`
-	MFChannels []channelRes `json:"mainflux_channels"`
-	Content    string       `json:"content,omitempty"`
-	ClientCert string       `json:"client_cert,omitempty"`
-	ClientKey  string       `json:"client_key,omitempty"`
-	CACert     string       `json:"ca_cert,omitempty"`
+	MFThing    string       `json:"mainflux_id" toml:"mainflux_id" yaml:"mainflux_id"`
+	MFKey      string       `json:"mainflux_key" toml:"mainflux_key" yaml:"mainflux_key"`
+	MFChannels []channelRes `json:"mainflux_channels" toml:"mainflux_channels" yaml:"mainflux_channels"`
+	Content    string       `json:"content,omitempty" toml:"content,omitempty" yaml:"content,omitempty"`
+	ClientCert string       `json:"client_cert,omitempty" toml:"client_cert,omitempty" yaml:"client_cert,omitempty"`
+	ClientKey  string       `json:"client_key,omitempty" toml:"client_key,omitempty" yaml:"client_key,omitempty"`
+	CACert     string       `json:"ca_cert,omitempty" toml:"ca_cert,omitempty" yaml:"ca_cert,omitempty"`
+	Version    uint64       `json:"version,omitempty" toml:"version,omitempty" yaml:"version,omitempty"`
 }
 
 type channelRes struct {
-	ID       string      `json:"id"`
-	Name     string      `json:"name,omitempty"`
-	Metadata interface{} `json:"metadata,omitempty"`
+	ID       string      `json:"id" toml:"id" yaml:"id"`
+	Name     string      `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
+	Metadata interface{} `json:"metadata,omitempty" toml:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 func (res bootstrapRes) Code() int {
@@ -54,20 +74,7 @@ func NewConfigReader(encKey []byte) ConfigReader {
 }
 
 func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
-	var channels []channelRes
-	for _, ch := range cfg.MFChannels {
-		channels = append(channels, channelRes{ID: ch.ID, Name: ch.Name, Metadata: ch.Metadata})
-	}
-
-	res := bootstrapRes{
-		MFKey:      cfg.MFKey,
-		MFThing:    cfg.MFThing,
-		MFChannels: channels,
-		Content:    cfg.Content,
-		ClientCert: cfg.ClientCert,
-		ClientKey:  cfg.ClientKey,
-		CACert:     cfg.CACert,
-	}
+	res := toBootstrapRes(cfg)
 	if secure {
 		b, err := json.Marshal(res)
 		if err != nil {
@@ -79,6 +86,15 @@ func (r reader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
 	return res, nil
 }
 
+// Method output returns the encoded response, encrypted if secure is set.
+func (r reader) output(b []byte, secure bool) (interface{}, error) {
+	if secure {
+		return r.encrypt(b)
+	}
+
+	return b, nil
+}
+
 func (r reader) encrypt(in []byte) ([]byte, error) {
 	block, err := aes.NewCipher(r.encKey)
 	if err != nil {
@@ -93,3 +109,84 @@ func (r reader) encrypt(in []byte) ([]byte, error) {
 	stream.XORKeyStream(ciphertext[aes.BlockSize:], in)
 	return ciphertext, nil
 }
+
+type tomlReader struct {
+	reader
+}
+
+// NewTOMLReader returns new reader which is used to generate TOML encoded
+// response from the config.
+func NewTOMLReader(encKey []byte) ConfigReader {
+	return tomlReader{reader{encKey: encKey}}
+}
+
+func (r tomlReader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(toBootstrapRes(cfg)); err != nil {
+		return nil, err
+	}
+
+	return r.output(buf.Bytes(), secure)
+}
+
+type yamlReader struct {
+	reader
+}
+
+// NewYAMLReader returns new reader which is used to generate YAML encoded
+// response from the config.
+func NewYAMLReader(encKey []byte) ConfigReader {
+	return yamlReader{reader{encKey: encKey}}
+}
+
+func (r yamlReader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
+	b, err := yaml.Marshal(toBootstrapRes(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.output(b, secure)
+}
+
+type rawReader struct {
+	reader
+}
+
+// NewRawReader returns new reader which responds with the config Content
+// only, so that it can be used as is by the clients that expect a plain
+// configuration file.
+func NewRawReader(encKey []byte) ConfigReader {
+	return rawReader{reader{encKey: encKey}}
+}
+
+func (r rawReader) ReadConfig(cfg Config, secure bool) (interface{}, error) {
+	return r.output([]byte(cfg.Content), secure)
+}
+
+// ValidFormat returns true if the given bootstrap response format is supported.
+func ValidFormat(format string) bool {
+	switch format {
+	case JSONFormat, TOMLFormat, YAMLFormat, RawFormat:
+		return true
+	default:
+		return false
+	}
+}
+
+func toBootstrapRes(cfg Config) bootstrapRes {
+	var channels []channelRes
+	for _, ch := range cfg.MFChannels {
+		channels = append(channels, channelRes{ID: ch.ID, Name: ch.Name, Metadata: ch.Metadata})
+	}
+
+	return bootstrapRes{
+		MFKey:      cfg.MFKey,
+		MFThing:    cfg.MFThing,
+		MFChannels: channels,
+		Content:    cfg.Content,
+		ClientCert: cfg.ClientCert,
+		ClientKey:  cfg.ClientKey,
+		CACert:     cfg.CACert,
+		Version:    cfg.Version,
+	}
+}
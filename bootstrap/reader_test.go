@@ -124,3 +124,72 @@ func TestReadConfig(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.Code(), fmt.Sprintf("Default config response code should be 200."))
 	}
 }
+
+func TestReadConfigFormats(t *testing.T) {
+	cfg := bootstrap.Config{
+		MFThing: "mf_id",
+		MFKey:   "mf_key",
+		MFChannels: []bootstrap.Channel{
+			bootstrap.Channel{
+				ID:       "mf_id",
+				Name:     "mf_name",
+				Metadata: map[string]interface{}{"key": "value"},
+			},
+		},
+		Content: "content",
+		Version: 2,
+	}
+
+	cases := []struct {
+		desc   string
+		reader bootstrap.ConfigReader
+		res    string
+	}{
+		{
+			desc:   "read a config as TOML",
+			reader: bootstrap.NewTOMLReader(encKey),
+			res: `mainflux_id = "mf_id"
+mainflux_key = "mf_key"
+content = "content"
+version = 2
+
+[[mainflux_channels]]
+  id = "mf_id"
+  name = "mf_name"
+  [mainflux_channels.metadata]
+    key = "value"
+`,
+		},
+		{
+			desc:   "read a config as YAML",
+			reader: bootstrap.NewYAMLReader(encKey),
+			res: `mainflux_id: mf_id
+mainflux_key: mf_key
+mainflux_channels:
+- id: mf_id
+  name: mf_name
+  metadata:
+    key: value
+content: content
+version: 2
+`,
+		},
+		{
+			desc:   "read a raw config",
+			reader: bootstrap.NewRawReader(encKey),
+			res:    "content",
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tc.reader.ReadConfig(cfg, false)
+		require.Nil(t, err, fmt.Sprintf("%s: reading config expected to succeed: %s.\n", tc.desc, err))
+		assert.Equal(t, tc.res, string(res.([]byte)), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+
+		res, err = tc.reader.ReadConfig(cfg, true)
+		require.Nil(t, err, fmt.Sprintf("%s: reading encrypted config expected to succeed: %s.\n", tc.desc, err))
+		d, err := dec(res.([]byte))
+		require.Nil(t, err, fmt.Sprintf("%s: decrypting expected to succeed: %s.\n", tc.desc, err))
+		assert.Equal(t, tc.res, string(d), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, d))
+	}
+}
@@ -11,10 +11,11 @@ import (
 )
 
 const (
-	configPrefix = "config."
-	configCreate = configPrefix + "create"
-	configUpdate = configPrefix + "update"
-	configRemove = configPrefix + "remove"
+	configPrefix   = "config."
+	configCreate   = configPrefix + "create"
+	configUpdate   = configPrefix + "update"
+	configRemove   = configPrefix + "remove"
+	configRollback = configPrefix + "rollback"
 
 	thingPrefix            = "thing."
 	thingBootstrap         = thingPrefix + "bootstrap"
@@ -30,6 +31,7 @@ var (
 	_ event = (*createConfigEvent)(nil)
 	_ event = (*updateConfigEvent)(nil)
 	_ event = (*removeConfigEvent)(nil)
+	_ event = (*rollbackConfigEvent)(nil)
 	_ event = (*bootstrapEvent)(nil)
 	_ event = (*changeStateEvent)(nil)
 	_ event = (*updateConnectionsEvent)(nil)
@@ -88,33 +90,57 @@ func (rce removeConfigEvent) encode() map[string]interface{} {
 	}
 }
 
+type rollbackConfigEvent struct {
+	mfThing   string
+	version   uint64
+	timestamp time.Time
+}
+
+func (rce rollbackConfigEvent) encode() map[string]interface{} {
+	return map[string]interface{}{
+		"thing_id":  rce.mfThing,
+		"version":   rce.version,
+		"timestamp": rce.timestamp.Unix(),
+		"operation": configRollback,
+	}
+}
+
 type bootstrapEvent struct {
 	externalID string
+	version    uint64
 	success    bool
 	timestamp  time.Time
 }
 
 func (be bootstrapEvent) encode() map[string]interface{} {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"external_id": be.externalID,
 		"success":     be.success,
 		"timestamp":   be.timestamp.Unix(),
 		"operation":   thingBootstrap,
 	}
+
+	if be.success {
+		val["version"] = be.version
+	}
+
+	return val
 }
 
 type changeStateEvent struct {
-	mfThing   string
-	state     bootstrap.State
-	timestamp time.Time
+	mfThing       string
+	previousState bootstrap.State
+	state         bootstrap.State
+	timestamp     time.Time
 }
 
 func (cse changeStateEvent) encode() map[string]interface{} {
 	return map[string]interface{}{
-		"thing_id":  cse.mfThing,
-		"state":     cse.state.String(),
-		"timestamp": cse.timestamp.Unix(),
-		"operation": thingStateChange,
+		"thing_id":       cse.mfThing,
+		"previous_state": cse.previousState.String(),
+		"state":          cse.state.String(),
+		"timestamp":      cse.timestamp.Unix(),
+		"operation":      thingStateChange,
 	}
 }
 
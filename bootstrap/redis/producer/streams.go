@@ -57,6 +57,34 @@ func (es eventStore) Add(token string, cfg bootstrap.Config) (bootstrap.Config,
 	return saved, err
 }
 
+func (es eventStore) AddBulk(token string, cfgs []bootstrap.Config) ([]bootstrap.Config, error) {
+	saved, err := es.svc.AddBulk(token, cfgs)
+	if err != nil {
+		return saved, err
+	}
+
+	for _, cfg := range saved {
+		var channels []string
+		for _, ch := range cfg.MFChannels {
+			channels = append(channels, ch.ID)
+		}
+
+		ev := createConfigEvent{
+			mfThing:    cfg.MFThing,
+			owner:      cfg.Owner,
+			name:       cfg.Name,
+			mfChannels: channels,
+			externalID: cfg.ExternalID,
+			content:    cfg.Content,
+			timestamp:  time.Now(),
+		}
+
+		es.add(ev)
+	}
+
+	return saved, err
+}
+
 func (es eventStore) View(token, id string) (bootstrap.Config, error) {
 	return es.svc.View(token, id)
 }
@@ -117,11 +145,59 @@ func (es eventStore) Remove(token, id string) error {
 	return nil
 }
 
+func (es eventStore) ListVersions(token, id string, offset, limit uint64) (bootstrap.ConfigVersionsPage, error) {
+	return es.svc.ListVersions(token, id, offset, limit)
+}
+
+func (es eventStore) ViewVersion(token, id string, version uint64) (bootstrap.ConfigVersion, error) {
+	return es.svc.ViewVersion(token, id, version)
+}
+
+func (es eventStore) DiffVersions(token, id string, from, to uint64) (bootstrap.VersionsDiff, error) {
+	return es.svc.DiffVersions(token, id, from, to)
+}
+
+func (es eventStore) Rollback(token, id string, version uint64) error {
+	if err := es.svc.Rollback(token, id, version); err != nil {
+		return err
+	}
+
+	ev := rollbackConfigEvent{
+		mfThing:   id,
+		version:   version,
+		timestamp: time.Now(),
+	}
+
+	es.add(ev)
+
+	return nil
+}
+
 func (es eventStore) Bootstrap(externalKey, externalID string, secure bool) (bootstrap.Config, error) {
 	cfg, err := es.svc.Bootstrap(externalKey, externalID, secure)
 
 	ev := bootstrapEvent{
 		externalID: externalID,
+		version:    cfg.Version,
+		timestamp:  time.Now(),
+		success:    true,
+	}
+
+	if err != nil {
+		ev.success = false
+	}
+
+	es.add(ev)
+
+	return cfg, err
+}
+
+func (es eventStore) CertBootstrap(externalID string) (bootstrap.Config, error) {
+	cfg, err := es.svc.CertBootstrap(externalID)
+
+	ev := bootstrapEvent{
+		externalID: externalID,
+		version:    cfg.Version,
 		timestamp:  time.Now(),
 		success:    true,
 	}
@@ -136,14 +212,21 @@ func (es eventStore) Bootstrap(externalKey, externalID string, secure bool) (boo
 }
 
 func (es eventStore) ChangeState(token, id string, state bootstrap.State) error {
+	// Previous State is retrieved so that the event describes the whole transition.
+	cfg, err := es.svc.View(token, id)
+	if err != nil {
+		return err
+	}
+
 	if err := es.svc.ChangeState(token, id, state); err != nil {
 		return err
 	}
 
 	ev := changeStateEvent{
-		mfThing:   id,
-		state:     state,
-		timestamp: time.Now(),
+		mfThing:       id,
+		previousState: cfg.State,
+		state:         state,
+		timestamp:     time.Now(),
 	}
 
 	es.add(ev)
@@ -151,6 +234,26 @@ func (es eventStore) ChangeState(token, id string, state bootstrap.State) error
 	return nil
 }
 
+func (es eventStore) AddTemplate(token string, tpl bootstrap.Template) (bootstrap.Template, error) {
+	return es.svc.AddTemplate(token, tpl)
+}
+
+func (es eventStore) ViewTemplate(token, id string) (bootstrap.Template, error) {
+	return es.svc.ViewTemplate(token, id)
+}
+
+func (es eventStore) UpdateTemplate(token string, tpl bootstrap.Template) error {
+	return es.svc.UpdateTemplate(token, tpl)
+}
+
+func (es eventStore) ListTemplates(token string, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	return es.svc.ListTemplates(token, offset, limit)
+}
+
+func (es eventStore) RemoveTemplate(token, id string) error {
+	return es.svc.RemoveTemplate(token, id)
+}
+
 func (es eventStore) RemoveConfigHandler(id string) error {
 	return es.svc.RemoveConfigHandler(id)
 }
@@ -6,6 +6,7 @@ package producer_test
 import (
 	"fmt"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
@@ -19,7 +20,9 @@ import (
 	"github.com/mainflux/mainflux/bootstrap"
 	"github.com/mainflux/mainflux/bootstrap/mocks"
 	"github.com/mainflux/mainflux/bootstrap/redis/producer"
+	"github.com/mainflux/mainflux/logger"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	httpapi "github.com/mainflux/mainflux/things/api/things/http"
 	"github.com/stretchr/testify/assert"
@@ -45,7 +48,8 @@ const (
 )
 
 var (
-	encKey = []byte("1234567891011121")
+	encKey     = []byte("1234567891011121")
+	testLog, _ = logger.New(os.Stdout, logger.Info.String())
 
 	channel = bootstrap.Channel{
 		ID:       "1",
@@ -68,7 +72,7 @@ func newService(auth mainflux.AuthNServiceClient, url string) bootstrap.Service
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, configs, sdk, encKey)
+	return bootstrap.New(auth, configs, mocks.NewTemplatesRepository(), sdk, encKey, uuidProvider.NewMock(), testLog)
 }
 
 func newThingsService(auth mainflux.AuthNServiceClient) things.Service {
@@ -426,6 +430,7 @@ func TestBootstrap(t *testing.T) {
 			event: map[string]interface{}{
 				"external_id": saved.ExternalID,
 				"success":     "1",
+				"version":     "1",
 				"timestamp":   time.Now().Unix(),
 				"operation":   thingBootstrap,
 			},
@@ -495,12 +500,49 @@ func TestChangeState(t *testing.T) {
 			state: bootstrap.Active,
 			err:   nil,
 			event: map[string]interface{}{
-				"thing_id":  saved.MFThing,
-				"state":     bootstrap.Active.String(),
-				"timestamp": time.Now().Unix(),
-				"operation": thingStateChange,
+				"thing_id":       saved.MFThing,
+				"previous_state": bootstrap.Inactive.String(),
+				"state":          bootstrap.Active.String(),
+				"timestamp":      time.Now().Unix(),
+				"operation":      thingStateChange,
+			},
+		},
+		{
+			desc:  "change state to suspended",
+			id:    saved.MFThing,
+			token: validToken,
+			state: bootstrap.Suspended,
+			err:   nil,
+			event: map[string]interface{}{
+				"thing_id":       saved.MFThing,
+				"previous_state": bootstrap.Active.String(),
+				"state":          bootstrap.Suspended.String(),
+				"timestamp":      time.Now().Unix(),
+				"operation":      thingStateChange,
+			},
+		},
+		{
+			desc:  "change state to decommissioned",
+			id:    saved.MFThing,
+			token: validToken,
+			state: bootstrap.Decommissioned,
+			err:   nil,
+			event: map[string]interface{}{
+				"thing_id":       saved.MFThing,
+				"previous_state": bootstrap.Suspended.String(),
+				"state":          bootstrap.Decommissioned.String(),
+				"timestamp":      time.Now().Unix(),
+				"operation":      thingStateChange,
 			},
 		},
+		{
+			desc:  "change state of decommissioned config",
+			id:    saved.MFThing,
+			token: validToken,
+			state: bootstrap.Active,
+			err:   bootstrap.ErrStateTransition,
+			event: nil,
+		},
 		{
 			desc:  "change state invalid credentials",
 			id:    saved.MFThing,
@@ -514,7 +556,7 @@ func TestChangeState(t *testing.T) {
 	lastID := "0"
 	for _, tc := range cases {
 		err := svc.ChangeState(tc.token, tc.id, tc.state)
-		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
 		streams := redisClient.XRead(&redis.XReadArgs{
 			Streams: []string{streamID, lastID},
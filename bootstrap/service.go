@@ -4,13 +4,17 @@
 package bootstrap
 
 import (
+	"bytes"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/hex"
+	"fmt"
+	"text/template"
 	"time"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/errors"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 )
@@ -42,11 +46,21 @@ var (
 	// ErrBootstrap indicates error in getting bootstrap configuration.
 	ErrBootstrap = errors.New("failed to read bootstrap configuration")
 
+	// ErrStateTransition indicates that Config can't transition to the requested State.
+	ErrStateTransition = errors.New("invalid config state transition")
+
+	// ErrBootstrapDisabled indicates bootstrap of the suspended or decommissioned Config.
+	ErrBootstrapDisabled = errors.New("bootstrap is disabled for suspended or decommissioned config")
+
+	// ErrDecommissioned indicates modification of the decommissioned (archived) Config.
+	ErrDecommissioned = errors.New("config is decommissioned")
+
 	errAddBootstrap       = errors.New("failed to add bootstrap configuration")
 	errUpdateConnections  = errors.New("failed to update connections")
 	errRemoveBootstrap    = errors.New("failed to remove bootstrap configuration")
 	errChangeState        = errors.New("failed to change state of bootstrap configuration")
 	errUpdateChannel      = errors.New("failed to update channel")
+	errUpdateConfig       = errors.New("failed to update bootstrap configuration")
 	errRemoveConfig       = errors.New("failed to remove bootstrap configuration")
 	errRemoveChannel      = errors.New("failed to remove channel")
 	errCreateThing        = errors.New("failed to create thing")
@@ -55,6 +69,15 @@ var (
 	errCheckChannels      = errors.New("failed to check if channels exists")
 	errConnectionChannels = errors.New("failed to check channels connections")
 	errUpdateCert         = errors.New("failed to update cert")
+	errAddBulk            = errors.New("failed to add bootstrap configurations in bulk")
+	errAddTemplate        = errors.New("failed to add template")
+	errUpdateTemplate     = errors.New("failed to update template")
+	errRemoveTemplate     = errors.New("failed to remove template")
+	errRenderTemplate     = errors.New("failed to render template")
+	errRetrieveVersions   = errors.New("failed to retrieve configuration versions")
+	errRollback           = errors.New("failed to rollback bootstrap configuration")
+	errFetchedVersion     = errors.New("failed to save fetched configuration version")
+	errDecommission       = errors.New("failed to decommission bootstrap configuration")
 )
 
 var _ Service = (*bootstrapService)(nil)
@@ -65,6 +88,10 @@ type Service interface {
 	// Add adds new Thing Config to the user identified by the provided token.
 	Add(token string, cfg Config) (Config, error)
 
+	// AddBulk adds a batch of Thing Configs to the user identified by the provided
+	// token. Either all of the Configs are added or none of them is.
+	AddBulk(token string, cfgs []Config) ([]Config, error)
+
 	// View returns Thing Config with given ID belonging to the user identified by the given token.
 	View(token, id string) (Config, error)
 
@@ -85,12 +112,51 @@ type Service interface {
 	// Remove removes Config with specified token that belongs to the user identified by the given token.
 	Remove(token, id string) error
 
+	// ListVersions returns subset of versions of the Config with given ID
+	// belonging to the user identified by the given token.
+	ListVersions(token, id string, offset, limit uint64) (ConfigVersionsPage, error)
+
+	// ViewVersion returns the given version of the Config with given ID
+	// belonging to the user identified by the given token.
+	ViewVersion(token, id string, version uint64) (ConfigVersion, error)
+
+	// DiffVersions returns the difference between two versions of the Config
+	// with given ID belonging to the user identified by the given token.
+	DiffVersions(token, id string, from, to uint64) (VersionsDiff, error)
+
+	// Rollback restores the Config with given ID to the given version. The
+	// restored Config is saved as a new version.
+	Rollback(token, id string, version uint64) error
+
 	// Bootstrap returns Config to the Thing with provided external ID using external key.
+	// The version of the returned Config is recorded as fetched.
 	Bootstrap(externalKey, externalID string, secure bool) (Config, error)
 
-	// ChangeState changes state of the Thing with given ID and owner.
+	// CertBootstrap returns Config to the Thing with provided external ID. The
+	// Thing is authenticated using the client certificate which is verified
+	// against the trusted CAs and mapped to the external ID by the caller.
+	CertBootstrap(externalID string) (Config, error)
+
+	// ChangeState changes state of the Thing with given ID and owner. Only
+	// the valid State transitions are allowed. Decommissioning revokes the
+	// Thing key, removes its certificates and archives the Config.
 	ChangeState(token, id string, state State) error
 
+	// AddTemplate adds new Config Template to the user identified by the provided token.
+	AddTemplate(token string, tpl Template) (Template, error)
+
+	// ViewTemplate returns Template with given ID belonging to the user identified by the given token.
+	ViewTemplate(token, id string) (Template, error)
+
+	// UpdateTemplate updates editable fields of the provided Template.
+	UpdateTemplate(token string, tpl Template) error
+
+	// ListTemplates returns subset of Templates that belong to the user identified by the given token.
+	ListTemplates(token string, offset, limit uint64) (TemplatesPage, error)
+
+	// RemoveTemplate removes Template with given ID that belongs to the user identified by the given token.
+	RemoveTemplate(token, id string) error
+
 	// Methods RemoveConfig, UpdateChannel, and RemoveChannel are used as
 	// handlers for events. That's why these methods surpass ownership check.
 
@@ -116,20 +182,26 @@ type ConfigReader interface {
 }
 
 type bootstrapService struct {
-	auth    mainflux.AuthNServiceClient
-	configs ConfigRepository
-	sdk     mfsdk.SDK
-	encKey  []byte
-	reader  ConfigReader
+	auth         mainflux.AuthNServiceClient
+	configs      ConfigRepository
+	templates    TemplateRepository
+	sdk          mfsdk.SDK
+	encKey       []byte
+	reader       ConfigReader
+	uuidProvider mainflux.UUIDProvider
+	logger       logger.Logger
 }
 
 // New returns new Bootstrap service.
-func New(auth mainflux.AuthNServiceClient, configs ConfigRepository, sdk mfsdk.SDK, encKey []byte) Service {
+func New(auth mainflux.AuthNServiceClient, configs ConfigRepository, templates TemplateRepository, sdk mfsdk.SDK, encKey []byte, up mainflux.UUIDProvider, logger logger.Logger) Service {
 	return &bootstrapService{
-		configs: configs,
-		sdk:     sdk,
-		auth:    auth,
-		encKey:  encKey,
+		configs:      configs,
+		templates:    templates,
+		sdk:          sdk,
+		auth:         auth,
+		encKey:       encKey,
+		uuidProvider: up,
+		logger:       logger,
 	}
 }
 
@@ -139,6 +211,12 @@ func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
 		return Config{}, err
 	}
 
+	if cfg.TemplateID != "" {
+		if _, err := bs.templates.RetrieveByID(owner, cfg.TemplateID); err != nil {
+			return Config{}, errors.Wrap(errAddBootstrap, err)
+		}
+	}
+
 	toConnect := bs.toIDList(cfg.MFChannels)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
@@ -163,6 +241,7 @@ func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
 	cfg.Owner = owner
 	cfg.State = Inactive
 	cfg.MFKey = mfThing.Key
+	cfg.Version = initialVersion
 
 	saved, err := bs.configs.Save(cfg, toConnect)
 	if err != nil {
@@ -180,6 +259,115 @@ func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
 	return cfg, nil
 }
 
+func (bs bootstrapService) AddBulk(token string, cfgs []Config) ([]Config, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return []Config{}, err
+	}
+
+	if len(cfgs) == 0 {
+		return []Config{}, ErrMalformedEntity
+	}
+
+	externalIDs := make(map[string]bool, len(cfgs))
+	templates := make(map[string]bool)
+	var toConnect []string
+	for _, cfg := range cfgs {
+		if externalIDs[cfg.ExternalID] {
+			return []Config{}, errors.Wrap(errAddBulk, ErrConflict)
+		}
+		externalIDs[cfg.ExternalID] = true
+
+		if cfg.TemplateID != "" {
+			templates[cfg.TemplateID] = true
+		}
+
+		toConnect = append(toConnect, bs.toIDList(cfg.MFChannels)...)
+	}
+	toConnect = unique(toConnect)
+
+	for id := range templates {
+		if _, err := bs.templates.RetrieveByID(owner, id); err != nil {
+			return []Config{}, errors.Wrap(errAddBulk, err)
+		}
+	}
+
+	// Check if channels exist. This is the way to prevent fetching channels that already exist.
+	existing, err := bs.configs.ListExisting(owner, toConnect)
+	if err != nil {
+		return []Config{}, errors.Wrap(errCheckChannels, err)
+	}
+
+	channels, err := bs.connectionChannels(toConnect, bs.toIDList(existing), token)
+	if err != nil {
+		return []Config{}, errors.Wrap(errConnectionChannels, err)
+	}
+
+	chans := make(map[string]Channel, len(toConnect))
+	for _, ch := range append(channels, existing...) {
+		chans[ch.ID] = ch
+	}
+
+	// Create all the missing Things using a single request.
+	var newThings []mfsdk.Thing
+	for _, cfg := range cfgs {
+		if cfg.MFThing == "" {
+			newThings = append(newThings, mfsdk.Thing{})
+		}
+	}
+
+	var created []mfsdk.Thing
+	if len(newThings) > 0 {
+		created, err = bs.sdk.CreateThings(newThings, token)
+		if err != nil {
+			return []Config{}, errors.Wrap(errAddBulk, errors.Wrap(errCreateThing, err))
+		}
+	}
+
+	var createdIDs []string
+	for _, th := range created {
+		createdIDs = append(createdIDs, th.ID)
+	}
+
+	if len(created) != len(newThings) {
+		return []Config{}, errors.Wrap(errAddBulk, bs.removeThings(createdIDs, token, errCreateThing))
+	}
+
+	next := 0
+	for i := range cfgs {
+		var mfThing mfsdk.Thing
+		if cfgs[i].MFThing == "" {
+			mfThing = created[next]
+			next++
+		} else {
+			mfThing, err = bs.thing(token, cfgs[i].MFThing)
+			if err != nil {
+				return []Config{}, errors.Wrap(errAddBulk, bs.removeThings(createdIDs, token, err))
+			}
+		}
+
+		cfgs[i].MFThing = mfThing.ID
+		cfgs[i].MFKey = mfThing.Key
+		cfgs[i].Owner = owner
+		cfgs[i].State = Inactive
+		cfgs[i].Version = initialVersion
+	}
+
+	if err := bs.configs.SaveAll(cfgs, channels); err != nil {
+		return []Config{}, errors.Wrap(errAddBulk, bs.removeThings(createdIDs, token, err))
+	}
+
+	for i, cfg := range cfgs {
+		var connected []Channel
+		for _, ch := range cfg.MFChannels {
+			connected = append(connected, chans[ch.ID])
+		}
+		cfgs[i].MFChannels = connected
+	}
+
+	return cfgs, nil
+}
+
 func (bs bootstrapService) View(token, id string) (Config, error) {
 	owner, err := bs.identify(token)
 	if err != nil {
@@ -197,6 +385,21 @@ func (bs bootstrapService) Update(token string, cfg Config) error {
 
 	cfg.Owner = owner
 
+	saved, err := bs.configs.RetrieveByID(owner, cfg.MFThing)
+	if err != nil {
+		return errors.Wrap(errUpdateConfig, err)
+	}
+
+	if saved.State == Decommissioned {
+		return errors.Wrap(errUpdateConfig, ErrDecommissioned)
+	}
+
+	if cfg.TemplateID != "" {
+		if _, err := bs.templates.RetrieveByID(owner, cfg.TemplateID); err != nil {
+			return err
+		}
+	}
+
 	return bs.configs.Update(cfg)
 }
 
@@ -205,6 +408,16 @@ func (bs bootstrapService) UpdateCert(token, thingID, clientCert, clientKey, caC
 	if err != nil {
 		return err
 	}
+
+	cfg, err := bs.configs.RetrieveByID(owner, thingID)
+	if err != nil {
+		return errors.Wrap(errUpdateCert, err)
+	}
+
+	if cfg.State == Decommissioned {
+		return errors.Wrap(errUpdateCert, ErrDecommissioned)
+	}
+
 	if err := bs.configs.UpdateCert(owner, thingID, clientCert, clientKey, caCert); err != nil {
 		return errors.Wrap(errUpdateCert, err)
 	}
@@ -222,6 +435,10 @@ func (bs bootstrapService) UpdateConnections(token, id string, connections []str
 		return errors.Wrap(errUpdateConnections, err)
 	}
 
+	if cfg.State == Decommissioned {
+		return errors.Wrap(errUpdateConnections, ErrDecommissioned)
+	}
+
 	add, remove := bs.updateList(cfg, connections)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
@@ -236,32 +453,10 @@ func (bs bootstrapService) UpdateConnections(token, id string, connections []str
 	}
 
 	cfg.MFChannels = channels
-	var connect, disconnect []string
-
-	if cfg.State == Active {
-		connect = add
-		disconnect = remove
-	}
 
-	for _, c := range disconnect {
-		if err := bs.sdk.DisconnectThing(id, c, token); err != nil {
-			if errors.Contains(err, mfsdk.ErrFailedDisconnect) {
-				continue
-			}
-			return ErrThings
-		}
-	}
-
-	for _, c := range connect {
-		conIDs := mfsdk.ConnectionIDs{
-			ChannelIDs: []string{c},
-			ThingIDs:   []string{id},
-		}
-		if err := bs.sdk.Connect(conIDs, token); err != nil {
-			if errors.Contains(err, mfsdk.ErrFailedConnect) {
-				return ErrMalformedEntity
-			}
-			return ErrThings
+	if cfg.State.connected() {
+		if err := bs.reconnect(id, add, remove, token); err != nil {
+			return err
 		}
 	}
 
@@ -288,6 +483,100 @@ func (bs bootstrapService) Remove(token, id string) error {
 	return nil
 }
 
+func (bs bootstrapService) ListVersions(token, id string, offset, limit uint64) (ConfigVersionsPage, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return ConfigVersionsPage{}, err
+	}
+
+	page, err := bs.configs.RetrieveVersions(owner, id, offset, limit)
+	if err != nil {
+		return ConfigVersionsPage{}, errors.Wrap(errRetrieveVersions, err)
+	}
+
+	return page, nil
+}
+
+func (bs bootstrapService) ViewVersion(token, id string, version uint64) (ConfigVersion, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return ConfigVersion{}, err
+	}
+
+	return bs.configs.RetrieveVersion(owner, id, version)
+}
+
+func (bs bootstrapService) DiffVersions(token, id string, from, to uint64) (VersionsDiff, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return VersionsDiff{}, err
+	}
+
+	fromVer, err := bs.configs.RetrieveVersion(owner, id, from)
+	if err != nil {
+		return VersionsDiff{}, errors.Wrap(errRetrieveVersions, err)
+	}
+
+	toVer, err := bs.configs.RetrieveVersion(owner, id, to)
+	if err != nil {
+		return VersionsDiff{}, errors.Wrap(errRetrieveVersions, err)
+	}
+
+	return Diff(fromVer, toVer), nil
+}
+
+func (bs bootstrapService) Rollback(token, id string, version uint64) error {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := bs.configs.RetrieveByID(owner, id)
+	if err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	if cfg.State == Decommissioned {
+		return errors.Wrap(errRollback, ErrDecommissioned)
+	}
+
+	ver, err := bs.configs.RetrieveVersion(owner, id, version)
+	if err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	if ver.TemplateID != "" {
+		if _, err := bs.templates.RetrieveByID(owner, ver.TemplateID); err != nil {
+			return errors.Wrap(errRollback, err)
+		}
+	}
+
+	add, remove := bs.updateList(cfg, ver.Channels)
+
+	// Check if channels exist. This is the way to prevent fetching channels that already exist.
+	existing, err := bs.configs.ListExisting(owner, ver.Channels)
+	if err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	channels, err := bs.connectionChannels(ver.Channels, bs.toIDList(existing), token)
+	if err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	if cfg.State.connected() {
+		if err := bs.reconnect(id, add, remove, token); err != nil {
+			return err
+		}
+	}
+
+	if err := bs.configs.Rollback(owner, id, ver, channels); err != nil {
+		return errors.Wrap(errRollback, err)
+	}
+
+	return nil
+}
+
 func (bs bootstrapService) Bootstrap(externalKey, externalID string, secure bool) (Config, error) {
 	cfg, err := bs.configs.RetrieveByExternalID(externalID)
 	if err != nil {
@@ -306,6 +595,37 @@ func (bs bootstrapService) Bootstrap(externalKey, externalID string, secure bool
 		return Config{}, errors.Wrap(ErrExternalKeyNotFound, ErrNotFound)
 	}
 
+	return bs.bootstrap(cfg)
+}
+
+func (bs bootstrapService) CertBootstrap(externalID string) (Config, error) {
+	cfg, err := bs.configs.RetrieveByExternalID(externalID)
+	if err != nil {
+		return cfg, errors.Wrap(ErrBootstrap, err)
+	}
+
+	return bs.bootstrap(cfg)
+}
+
+// bootstrap prepares the authenticated Thing Config and records its version as fetched.
+func (bs bootstrapService) bootstrap(cfg Config) (Config, error) {
+	if cfg.State == Suspended || cfg.State == Decommissioned {
+		return Config{}, ErrBootstrapDisabled
+	}
+
+	if cfg.TemplateID != "" {
+		content, err := bs.render(cfg)
+		if err != nil {
+			return Config{}, errors.Wrap(ErrBootstrap, err)
+		}
+		cfg.Content = content
+	}
+
+	if err := bs.configs.UpdateFetchedVersion(cfg.MFThing, cfg.Version); err != nil {
+		return Config{}, errors.Wrap(ErrBootstrap, errors.Wrap(errFetchedVersion, err))
+	}
+	cfg.FetchedVersion = cfg.Version
+
 	return cfg, nil
 }
 
@@ -324,8 +644,16 @@ func (bs bootstrapService) ChangeState(token, id string, state State) error {
 		return nil
 	}
 
-	switch state {
-	case Active:
+	if !cfg.State.CanTransition(state) {
+		return errors.Wrap(errChangeState, ErrStateTransition)
+	}
+
+	switch {
+	case state == Decommissioned:
+		if err := bs.decommission(owner, cfg, token); err != nil {
+			return err
+		}
+	case state.connected() && !cfg.State.connected():
 		for _, c := range cfg.MFChannels {
 			conIDs := mfsdk.ConnectionIDs{
 				ChannelIDs: []string{c.ID},
@@ -335,14 +663,9 @@ func (bs bootstrapService) ChangeState(token, id string, state State) error {
 				return ErrThings
 			}
 		}
-	case Inactive:
-		for _, c := range cfg.MFChannels {
-			if err := bs.sdk.DisconnectThing(cfg.MFThing, c.ID, token); err != nil {
-				if errors.Contains(err, mfsdk.ErrFailedDisconnect) {
-					continue
-				}
-				return ErrThings
-			}
+	case !state.connected() && cfg.State.connected():
+		if err := bs.disconnect(cfg, token); err != nil {
+			return err
 		}
 	}
 	if err := bs.configs.ChangeState(owner, id, state); err != nil {
@@ -351,6 +674,125 @@ func (bs bootstrapService) ChangeState(token, id string, state State) error {
 	return nil
 }
 
+// decommission disconnects the Thing from its Channels, revokes the Thing
+// certificates issued by the certs service, removes the Config certificates
+// and revokes the Thing key by replacing it with a random one. The key is
+// replaced last, so that the failed decommission can be retried. Revoking
+// the certificates is best-effort, since they may not be issued by the
+// certs service or the service may not be deployed at all.
+func (bs bootstrapService) decommission(owner string, cfg Config, token string) error {
+	if cfg.State.connected() {
+		if err := bs.disconnect(cfg, token); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ClientCert != "" {
+		if err := bs.sdk.RemoveCert(cfg.MFThing, token); err != nil {
+			bs.logger.Warn(fmt.Sprintf("Failed to revoke certificates of decommissioned thing %s: %s", cfg.MFThing, err))
+		}
+	}
+
+	if err := bs.configs.UpdateCert(owner, cfg.MFThing, "", "", ""); err != nil {
+		return errors.Wrap(errDecommission, err)
+	}
+
+	key, err := bs.uuidProvider.ID()
+	if err != nil {
+		return errors.Wrap(errDecommission, err)
+	}
+
+	if err := bs.sdk.UpdateThingKey(cfg.MFThing, key, token); err != nil {
+		return ErrThings
+	}
+
+	return nil
+}
+
+func (bs bootstrapService) disconnect(cfg Config, token string) error {
+	for _, c := range cfg.MFChannels {
+		if err := bs.sdk.DisconnectThing(cfg.MFThing, c.ID, token); err != nil {
+			if errors.Contains(err, mfsdk.ErrFailedDisconnect) {
+				continue
+			}
+			return ErrThings
+		}
+	}
+	return nil
+}
+
+func (bs bootstrapService) AddTemplate(token string, tpl Template) (Template, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return Template{}, err
+	}
+
+	if _, err := parseTemplate(tpl.Content); err != nil {
+		return Template{}, errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	tpl.ID, err = bs.uuidProvider.ID()
+	if err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+	tpl.Owner = owner
+
+	if _, err := bs.templates.Save(tpl); err != nil {
+		return Template{}, errors.Wrap(errAddTemplate, err)
+	}
+
+	return tpl, nil
+}
+
+func (bs bootstrapService) ViewTemplate(token, id string) (Template, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return Template{}, err
+	}
+
+	return bs.templates.RetrieveByID(owner, id)
+}
+
+func (bs bootstrapService) UpdateTemplate(token string, tpl Template) error {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return err
+	}
+
+	if _, err := parseTemplate(tpl.Content); err != nil {
+		return errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	tpl.Owner = owner
+	if err := bs.templates.Update(tpl); err != nil {
+		return errors.Wrap(errUpdateTemplate, err)
+	}
+
+	return nil
+}
+
+func (bs bootstrapService) ListTemplates(token string, offset, limit uint64) (TemplatesPage, error) {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return TemplatesPage{}, err
+	}
+
+	return bs.templates.RetrieveAll(owner, offset, limit)
+}
+
+func (bs bootstrapService) RemoveTemplate(token, id string) error {
+	owner, err := bs.identify(token)
+	if err != nil {
+		return err
+	}
+
+	if err := bs.templates.Remove(owner, id); err != nil {
+		return errors.Wrap(errRemoveTemplate, err)
+	}
+
+	return nil
+}
+
 func (bs bootstrapService) UpdateChannelHandler(channel Channel) error {
 	if err := bs.configs.UpdateChannel(channel); err != nil {
 		return errors.Wrap(errUpdateChannel, err)
@@ -478,6 +920,67 @@ func (bs bootstrapService) updateList(cfg Config, connections []string) (add, re
 	return
 }
 
+// Method reconnect connects the Thing to the added Channels and disconnects it
+// from the removed ones.
+func (bs bootstrapService) reconnect(id string, add, remove []string, token string) error {
+	for _, c := range remove {
+		if err := bs.sdk.DisconnectThing(id, c, token); err != nil {
+			if errors.Contains(err, mfsdk.ErrFailedDisconnect) {
+				continue
+			}
+			return ErrThings
+		}
+	}
+
+	for _, c := range add {
+		conIDs := mfsdk.ConnectionIDs{
+			ChannelIDs: []string{c},
+			ThingIDs:   []string{id},
+		}
+		if err := bs.sdk.Connect(conIDs, token); err != nil {
+			if errors.Contains(err, mfsdk.ErrFailedConnect) {
+				return ErrMalformedEntity
+			}
+			return ErrThings
+		}
+	}
+
+	return nil
+}
+
+// Method removeThings deletes Things created during the failed operation
+// and returns the original error, wrapped with the removal errors if any.
+func (bs bootstrapService) removeThings(ids []string, token string, err error) error {
+	for _, id := range ids {
+		if errT := bs.sdk.DeleteThing(id, token); errT != nil {
+			err = errors.Wrap(err, errT)
+		}
+	}
+
+	return err
+}
+
+// Method render renders the Template assigned to the Config using the Config
+// itself as the Template data.
+func (bs bootstrapService) render(cfg Config) (string, error) {
+	tpl, err := bs.templates.RetrieveByID(cfg.Owner, cfg.TemplateID)
+	if err != nil {
+		return "", errors.Wrap(errRenderTemplate, err)
+	}
+
+	t, err := parseTemplate(tpl.Content)
+	if err != nil {
+		return "", errors.Wrap(errRenderTemplate, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, cfg); err != nil {
+		return "", errors.Wrap(errRenderTemplate, err)
+	}
+
+	return buf.String(), nil
+}
+
 func (bs bootstrapService) toIDList(channels []Channel) []string {
 	var ret []string
 	for _, ch := range channels {
@@ -505,3 +1008,20 @@ func (bs bootstrapService) dec(in string) (string, error) {
 	stream.XORKeyStream(ciphertext, ciphertext)
 	return string(ciphertext), nil
 }
+
+func parseTemplate(content string) (*template.Template, error) {
+	return template.New("config").Option("missingkey=error").Parse(content)
+}
+
+func unique(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	var ret []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
@@ -10,7 +10,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
 	"strconv"
 	"testing"
 
@@ -20,8 +23,10 @@ import (
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/bootstrap"
 	"github.com/mainflux/mainflux/bootstrap/mocks"
+	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/errors"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	httpapi "github.com/mainflux/mainflux/things/api/things/http"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +42,8 @@ const (
 )
 
 var (
-	encKey = []byte("1234567891011121")
+	encKey     = []byte("1234567891011121")
+	testLog, _ = logger.New(os.Stdout, logger.Info.String())
 
 	channel = bootstrap.Channel{
 		ID:       "1",
@@ -51,16 +57,22 @@ var (
 		MFChannels:  []bootstrap.Channel{channel},
 		Content:     "config",
 	}
+
+	template = bootstrap.Template{
+		Name:    "template",
+		Content: "{{.ExternalID}}:{{.MFThing}}:{{range .MFChannels}}{{.ID}}{{end}}",
+	}
 )
 
 func newService(auth mainflux.AuthNServiceClient, url string) bootstrap.Service {
 	things := mocks.NewConfigsRepository()
 	config := mfsdk.Config{
-		BaseURL: url,
+		BaseURL:  url,
+		CertsURL: url + "/certs",
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, things, sdk, encKey)
+	return bootstrap.New(auth, things, mocks.NewTemplatesRepository(), sdk, encKey, uuidProvider.NewMock(), testLog)
 }
 
 func newThingsService(auth mainflux.AuthNServiceClient) things.Service {
@@ -149,6 +161,90 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestAddBulk(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	var cfgs []bootstrap.Config
+	for i := 0; i < 10; i++ {
+		c := config
+		c.ExternalID = fmt.Sprintf("%s-%d", config.ExternalID, i)
+		cfgs = append(cfgs, c)
+	}
+
+	duplicate := append([]bootstrap.Config{}, cfgs[:2]...)
+	duplicate[1].ExternalID = duplicate[0].ExternalID
+
+	wrongChannels := config
+	ch := channel
+	ch.ID = "invalid"
+	wrongChannels.MFChannels = append(wrongChannels.MFChannels, ch)
+
+	wrongTemplate := config
+	wrongTemplate.TemplateID = unknown
+
+	cases := []struct {
+		desc    string
+		configs []bootstrap.Config
+		token   string
+		err     error
+	}{
+		{
+			desc:    "add configs in bulk",
+			configs: cfgs,
+			token:   validToken,
+			err:     nil,
+		},
+		{
+			desc:    "add already existing configs in bulk",
+			configs: cfgs,
+			token:   validToken,
+			err:     bootstrap.ErrConflict,
+		},
+		{
+			desc:    "add configs with duplicate external IDs in bulk",
+			configs: duplicate,
+			token:   validToken,
+			err:     bootstrap.ErrConflict,
+		},
+		{
+			desc:    "add configs in bulk with wrong credentials",
+			configs: cfgs,
+			token:   invalidToken,
+			err:     bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "add configs in bulk with invalid list of channels",
+			configs: []bootstrap.Config{wrongChannels},
+			token:   validToken,
+			err:     bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:    "add configs in bulk with non-existent template",
+			configs: []bootstrap.Config{wrongTemplate},
+			token:   validToken,
+			err:     bootstrap.ErrNotFound,
+		},
+		{
+			desc:    "add an empty list of configs in bulk",
+			configs: []bootstrap.Config{},
+			token:   validToken,
+			err:     bootstrap.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		// Pass a copy since the service updates Configs in place.
+		saved, err := svc.AddBulk(tc.token, append([]bootstrap.Config{}, tc.configs...))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, saved, len(tc.configs), fmt.Sprintf("%s: expected %d configs got %d\n", tc.desc, len(tc.configs), len(saved)))
+		}
+	}
+}
+
 func TestView(t *testing.T) {
 	users := mocks.NewUsersService(map[string]string{validToken: email})
 
@@ -541,6 +637,9 @@ func TestBootstrap(t *testing.T) {
 	e, err := enc([]byte(saved.ExternalKey))
 	require.Nil(t, err, fmt.Sprintf("Encrypting external key expected to succeed: %s.\n", err))
 
+	fetched := saved
+	fetched.FetchedVersion = saved.Version
+
 	cases := []struct {
 		desc        string
 		config      bootstrap.Config
@@ -567,7 +666,7 @@ func TestBootstrap(t *testing.T) {
 		},
 		{
 			desc:        "bootstrap an existing config",
-			config:      saved,
+			config:      fetched,
 			externalID:  saved.ExternalID,
 			externalKey: saved.ExternalKey,
 			err:         nil,
@@ -575,7 +674,7 @@ func TestBootstrap(t *testing.T) {
 		},
 		{
 			desc:        "bootstrap encrypted",
-			config:      saved,
+			config:      fetched,
 			externalID:  saved.ExternalID,
 			externalKey: hex.EncodeToString(e),
 			err:         nil,
@@ -590,7 +689,373 @@ func TestBootstrap(t *testing.T) {
 	}
 }
 
-func TestChangeState(t *testing.T) {
+func TestAddTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	cases := []struct {
+		desc     string
+		template bootstrap.Template
+		token    string
+		err      error
+	}{
+		{
+			desc:     "add a new template",
+			template: template,
+			token:    validToken,
+			err:      nil,
+		},
+		{
+			desc:     "add a template with invalid syntax",
+			template: bootstrap.Template{Content: "{{.ExternalID"},
+			token:    validToken,
+			err:      bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:     "add a template with wrong credentials",
+			template: template,
+			token:    invalidToken,
+			err:      bootstrap.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.AddTemplate(tc.token, tc.template)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	saved, err := svc.AddTemplate(validToken, template)
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	modified := saved
+	modified.Content = "{{.Name}}"
+
+	invalid := saved
+	invalid.Content = "{{range}}"
+
+	nonExisting := saved
+	nonExisting.ID = unknown
+
+	cases := []struct {
+		desc     string
+		template bootstrap.Template
+		token    string
+		err      error
+	}{
+		{
+			desc:     "update a template",
+			template: modified,
+			token:    validToken,
+			err:      nil,
+		},
+		{
+			desc:     "update a template with invalid syntax",
+			template: invalid,
+			token:    validToken,
+			err:      bootstrap.ErrMalformedEntity,
+		},
+		{
+			desc:     "update a non-existing template",
+			template: nonExisting,
+			token:    validToken,
+			err:      bootstrap.ErrNotFound,
+		},
+		{
+			desc:     "update a template with wrong credentials",
+			template: modified,
+			token:    invalidToken,
+			err:      bootstrap.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateTemplate(tc.token, tc.template)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCertBootstrap(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	saved, err := svc.Add(validToken, config)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	fetched := saved
+	fetched.FetchedVersion = saved.Version
+
+	c := config
+	c.ExternalID = "suspended-external-id"
+	suspended, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+	err = svc.ChangeState(validToken, suspended.MFThing, bootstrap.Active)
+	require.Nil(t, err, fmt.Sprintf("Changing state expected to succeed: %s.\n", err))
+	err = svc.ChangeState(validToken, suspended.MFThing, bootstrap.Suspended)
+	require.Nil(t, err, fmt.Sprintf("Changing state expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc       string
+		config     bootstrap.Config
+		externalID string
+		err        error
+	}{
+		{
+			desc:       "bootstrap using unknown external id",
+			config:     bootstrap.Config{},
+			externalID: "invalid",
+			err:        bootstrap.ErrNotFound,
+		},
+		{
+			desc:       "bootstrap suspended config",
+			config:     bootstrap.Config{},
+			externalID: suspended.ExternalID,
+			err:        bootstrap.ErrBootstrapDisabled,
+		},
+		{
+			desc:       "bootstrap an existing config",
+			config:     fetched,
+			externalID: saved.ExternalID,
+			err:        nil,
+		},
+	}
+
+	for _, tc := range cases {
+		config, err := svc.CertBootstrap(tc.externalID)
+		assert.Equal(t, tc.config, config, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.config, config))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestBootstrapTemplate(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	tpl, err := svc.AddTemplate(validToken, template)
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	missing, err := svc.AddTemplate(validToken, bootstrap.Template{Content: "{{.Unknown}}"})
+	require.Nil(t, err, fmt.Sprintf("Saving template expected to succeed: %s.\n", err))
+
+	c := config
+	c.TemplateID = tpl.ID
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	invalid := config
+	invalid.ExternalID = "invalid-template"
+	invalid.TemplateID = missing.ID
+	_, err = svc.Add(validToken, invalid)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc       string
+		externalID string
+		content    string
+		err        error
+	}{
+		{
+			desc:       "bootstrap a config with template",
+			externalID: saved.ExternalID,
+			content:    fmt.Sprintf("%s:%s:%s", saved.ExternalID, saved.MFThing, channel.ID),
+			err:        nil,
+		},
+		{
+			desc:       "bootstrap a config with template referencing unknown field",
+			externalID: invalid.ExternalID,
+			content:    "",
+			err:        bootstrap.ErrBootstrap,
+		},
+	}
+
+	for _, tc := range cases {
+		cfg, err := svc.Bootstrap(config.ExternalKey, tc.externalID, false)
+		assert.Equal(t, tc.content, cfg.Content, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.content, cfg.Content))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	saved, err := svc.Add(validToken, config)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	// Name is not versioned.
+	saved.Name = "new name"
+	err = svc.Update(validToken, saved)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	saved.Content = "new content"
+	err = svc.Update(validToken, saved)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	err = svc.UpdateCert(validToken, saved.MFThing, "cert", "key", "ca")
+	require.Nil(t, err, fmt.Sprintf("Updating certs expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		offset uint64
+		limit  uint64
+		size   int
+		total  uint64
+		err    error
+	}{
+		{
+			desc:   "list all versions",
+			token:  validToken,
+			id:     saved.MFThing,
+			offset: 0,
+			limit:  10,
+			size:   3,
+			total:  3,
+			err:    nil,
+		},
+		{
+			desc:   "list a page of versions",
+			token:  validToken,
+			id:     saved.MFThing,
+			offset: 1,
+			limit:  1,
+			size:   1,
+			total:  3,
+			err:    nil,
+		},
+		{
+			desc:   "list versions of a non-existing config",
+			token:  validToken,
+			id:     unknown,
+			offset: 0,
+			limit:  10,
+			size:   0,
+			total:  0,
+			err:    bootstrap.ErrNotFound,
+		},
+		{
+			desc:   "list versions with wrong credentials",
+			token:  invalidToken,
+			id:     saved.MFThing,
+			offset: 0,
+			limit:  10,
+			size:   0,
+			total:  0,
+			err:    bootstrap.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListVersions(tc.token, tc.id, tc.offset, tc.limit)
+		assert.Equal(t, tc.size, len(page.Versions), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Versions)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ver, err := svc.ViewVersion(validToken, saved.MFThing, 3)
+	require.Nil(t, err, fmt.Sprintf("Viewing version expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.KeyDigest("key"), ver.ClientKeyDigest, fmt.Sprintf("expected client key digest %s got %s\n", bootstrap.KeyDigest("key"), ver.ClientKeyDigest))
+}
+
+func TestDiffVersions(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	c := config
+	c.Content = "line1\nline2\nline3"
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	saved.Content = "line1\nchanged\nline3"
+	err = svc.Update(validToken, saved)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	err = svc.UpdateConnections(validToken, saved.MFThing, []string{"2"})
+	require.Nil(t, err, fmt.Sprintf("Updating connections expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		from  uint64
+		to    uint64
+		diff  bootstrap.VersionsDiff
+		err   error
+	}{
+		{
+			desc:  "diff versions with changed content",
+			token: validToken,
+			id:    saved.MFThing,
+			from:  1,
+			to:    2,
+			diff: bootstrap.VersionsDiff{
+				From:    1,
+				To:      2,
+				Changed: []string{"content"},
+				Content: []string{" line1", "-line2", "+changed", " line3"},
+			},
+			err: nil,
+		},
+		{
+			desc:  "diff versions with changed connections",
+			token: validToken,
+			id:    saved.MFThing,
+			from:  2,
+			to:    3,
+			diff: bootstrap.VersionsDiff{
+				From:            2,
+				To:              3,
+				Changed:         []string{"channels"},
+				AddedChannels:   []string{"2"},
+				RemovedChannels: []string{"1"},
+			},
+			err: nil,
+		},
+		{
+			desc:  "diff non-existing versions",
+			token: validToken,
+			id:    saved.MFThing,
+			from:  1,
+			to:    10,
+			diff:  bootstrap.VersionsDiff{},
+			err:   bootstrap.ErrNotFound,
+		},
+		{
+			desc:  "diff versions with wrong credentials",
+			token: invalidToken,
+			id:    saved.MFThing,
+			from:  1,
+			to:    2,
+			diff:  bootstrap.VersionsDiff{},
+			err:   bootstrap.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		diff, err := svc.DiffVersions(tc.token, tc.id, tc.from, tc.to)
+		assert.Equal(t, tc.diff, diff, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.diff, diff))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRollback(t *testing.T) {
 	users := mocks.NewUsersService(map[string]string{validToken: email})
 
 	server := newThingsServer(newThingsService(users))
@@ -599,6 +1064,138 @@ func TestChangeState(t *testing.T) {
 	saved, err := svc.Add(validToken, config)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
+	modified := saved
+	modified.Content = "new content"
+	err = svc.Update(validToken, modified)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	err = svc.UpdateConnections(validToken, saved.MFThing, []string{"2", "3"})
+	require.Nil(t, err, fmt.Sprintf("Updating connections expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		id      string
+		version uint64
+		err     error
+	}{
+		{
+			desc:    "rollback to a non-existing version",
+			token:   validToken,
+			id:      saved.MFThing,
+			version: 10,
+			err:     bootstrap.ErrNotFound,
+		},
+		{
+			desc:    "rollback a non-existing config",
+			token:   validToken,
+			id:      unknown,
+			version: 1,
+			err:     bootstrap.ErrNotFound,
+		},
+		{
+			desc:    "rollback with wrong credentials",
+			token:   invalidToken,
+			id:      saved.MFThing,
+			version: 1,
+			err:     bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "rollback to the initial version",
+			token:   validToken,
+			id:      saved.MFThing,
+			version: 1,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Rollback(tc.token, tc.id, tc.version)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	cfg, err := svc.View(validToken, saved.MFThing)
+	require.Nil(t, err, fmt.Sprintf("Viewing config expected to succeed: %s.\n", err))
+	assert.Equal(t, saved.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", saved.Content, cfg.Content))
+	assert.Equal(t, len(saved.MFChannels), len(cfg.MFChannels), fmt.Sprintf("expected %d channels got %d\n", len(saved.MFChannels), len(cfg.MFChannels)))
+	assert.Equal(t, saved.MFChannels[0].ID, cfg.MFChannels[0].ID, fmt.Sprintf("expected channels %v got %v\n", saved.MFChannels, cfg.MFChannels))
+	assert.Equal(t, uint64(4), cfg.Version, fmt.Sprintf("expected version 4 got %d\n", cfg.Version))
+}
+
+func TestRollbackCerts(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	server := newThingsServer(newThingsService(users))
+	svc := newService(users, server.URL)
+
+	saved, err := svc.Add(validToken, config)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	err = svc.UpdateCert(validToken, saved.MFThing, "cert", "key", "ca")
+	require.Nil(t, err, fmt.Sprintf("Updating certs expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc    string
+		cert    string
+		key     string
+		ca      string
+		version uint64
+		expCert string
+		expCA   string
+	}{
+		{
+			desc:    "rollback certs issued for the same key",
+			cert:    "renewed cert",
+			key:     "key",
+			ca:      "renewed ca",
+			version: 2,
+			expCert: "cert",
+			expCA:   "ca",
+		},
+		{
+			desc:    "rollback certs issued for the other key",
+			cert:    "new cert",
+			key:     "new key",
+			ca:      "new ca",
+			version: 2,
+			expCert: "new cert",
+			expCA:   "new ca",
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateCert(validToken, saved.MFThing, tc.cert, tc.key, tc.ca)
+		require.Nil(t, err, fmt.Sprintf("%s: updating certs expected to succeed: %s.\n", tc.desc, err))
+
+		err = svc.Rollback(validToken, saved.MFThing, tc.version)
+		require.Nil(t, err, fmt.Sprintf("%s: rollback expected to succeed: %s.\n", tc.desc, err))
+
+		cfg, err := svc.View(validToken, saved.MFThing)
+		require.Nil(t, err, fmt.Sprintf("%s: viewing config expected to succeed: %s.\n", tc.desc, err))
+		assert.Equal(t, tc.expCert, cfg.ClientCert, fmt.Sprintf("%s: expected client cert %s got %s\n", tc.desc, tc.expCert, cfg.ClientCert))
+		assert.Equal(t, tc.key, cfg.ClientKey, fmt.Sprintf("%s: expected client key %s got %s\n", tc.desc, tc.key, cfg.ClientKey))
+		assert.Equal(t, tc.expCA, cfg.CACert, fmt.Sprintf("%s: expected CA cert %s got %s\n", tc.desc, tc.expCA, cfg.CACert))
+	}
+}
+
+func TestChangeState(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	revoked := []string{}
+	mux := http.NewServeMux()
+	mux.Handle("/", httpapi.MakeHandler(mocktracer.New(), newThingsService(users)))
+	mux.HandleFunc("/certs/", func(w http.ResponseWriter, r *http.Request) {
+		revoked = append(revoked, path.Base(r.URL.Path))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	svc := newService(users, server.URL)
+
+	saved, err := svc.Add(validToken, config)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+	err = svc.UpdateCert(validToken, saved.MFThing, "cert", "key", "ca")
+	require.Nil(t, err, fmt.Sprintf("Updating config certs expected to succeed: %s.\n", err))
+
 	cases := []struct {
 		desc  string
 		state bootstrap.State
@@ -641,11 +1238,115 @@ func TestChangeState(t *testing.T) {
 			token: validToken,
 			err:   nil,
 		},
+		{
+			desc:  "change state from Inactive to Suspended",
+			state: bootstrap.Suspended,
+			id:    saved.MFThing,
+			token: validToken,
+			err:   bootstrap.ErrStateTransition,
+		},
+		{
+			desc:  "change state to unknown state",
+			state: bootstrap.State(42),
+			id:    saved.MFThing,
+			token: validToken,
+			err:   bootstrap.ErrStateTransition,
+		},
+		{
+			desc:  "change state from Inactive to Active",
+			state: bootstrap.Active,
+			id:    saved.MFThing,
+			token: validToken,
+			err:   nil,
+		},
+		{
+			desc:  "change state to Suspended",
+			state: bootstrap.Suspended,
+			id:    saved.MFThing,
+			token: validToken,
+			err:   nil,
+		},
+		{
+			desc:  "change state to Decommissioned",
+			state: bootstrap.Decommissioned,
+			id:    saved.MFThing,
+			token: validToken,
+			err:   nil,
+		},
+		{
+			desc:  "change state of Decommissioned config",
+			state: bootstrap.Active,
+			id:    saved.MFThing,
+			token: validToken,
+			err:   bootstrap.ErrStateTransition,
+		},
 	}
 
 	for _, tc := range cases {
 		err := svc.ChangeState(tc.token, tc.id, tc.state)
-		assert.True(t, errors.Contains(err, tc.err), err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	cfg, err := svc.View(validToken, saved.MFThing)
+	require.Nil(t, err, fmt.Sprintf("Viewing config expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.Decommissioned, cfg.State, fmt.Sprintf("expected state %d got %d\n", bootstrap.Decommissioned, cfg.State))
+	assert.Empty(t, cfg.ClientCert+cfg.ClientKey+cfg.CACert, "expected certificates of decommissioned config to be removed\n")
+	assert.Equal(t, []string{saved.MFThing}, revoked, fmt.Sprintf("expected certificates of %s to be revoked got %v\n", saved.MFThing, revoked))
+
+	_, err = svc.Bootstrap(cfg.ExternalKey, cfg.ExternalID, false)
+	assert.True(t, errors.Contains(err, bootstrap.ErrBootstrapDisabled), fmt.Sprintf("expected %s got %s\n", bootstrap.ErrBootstrapDisabled, err))
+
+	err = svc.UpdateConnections(validToken, saved.MFThing, []string{})
+	assert.True(t, errors.Contains(err, bootstrap.ErrDecommissioned), fmt.Sprintf("expected %s got %s\n", bootstrap.ErrDecommissioned, err))
+
+	cfg.Content = "decommissioned"
+	err = svc.Update(validToken, cfg)
+	assert.True(t, errors.Contains(err, bootstrap.ErrDecommissioned), fmt.Sprintf("expected %s got %s\n", bootstrap.ErrDecommissioned, err))
+
+	err = svc.UpdateCert(validToken, saved.MFThing, "cert", "key", "ca")
+	assert.True(t, errors.Contains(err, bootstrap.ErrDecommissioned), fmt.Sprintf("expected %s got %s\n", bootstrap.ErrDecommissioned, err))
+}
+
+func TestDecommissionRevokeCertsFailure(t *testing.T) {
+	users := mocks.NewUsersService(map[string]string{validToken: email})
+
+	cases := []struct {
+		desc   string
+		status int
+	}{
+		{
+			desc:   "decommission with certs not found",
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "decommission with certs service unavailable",
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		mux := http.NewServeMux()
+		mux.Handle("/", httpapi.MakeHandler(mocktracer.New(), newThingsService(users)))
+		mux.HandleFunc("/certs/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+		})
+		server := httptest.NewServer(mux)
+		svc := newService(users, server.URL)
+
+		saved, err := svc.Add(validToken, config)
+		require.Nil(t, err, fmt.Sprintf("%s: saving config expected to succeed: %s.\n", tc.desc, err))
+		err = svc.UpdateCert(validToken, saved.MFThing, "cert", "key", "ca")
+		require.Nil(t, err, fmt.Sprintf("%s: updating config certs expected to succeed: %s.\n", tc.desc, err))
+
+		err = svc.ChangeState(validToken, saved.MFThing, bootstrap.Decommissioned)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected decommission to succeed got %s\n", tc.desc, err))
+
+		cfg, err := svc.View(validToken, saved.MFThing)
+		require.Nil(t, err, fmt.Sprintf("%s: viewing config expected to succeed: %s.\n", tc.desc, err))
+		assert.Equal(t, bootstrap.Decommissioned, cfg.State, fmt.Sprintf("%s: expected state %d got %d\n", tc.desc, bootstrap.Decommissioned, cfg.State))
+		assert.Empty(t, cfg.ClientCert+cfg.ClientKey+cfg.CACert, fmt.Sprintf("%s: expected certificates of decommissioned config to be removed\n", tc.desc))
+
+		server.Close()
 	}
 }
 
@@ -692,7 +1393,7 @@ func TestRemoveChannelHandler(t *testing.T) {
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
 
-	_, err := svc.Add(validToken, config)
+	saved, err := svc.Add(validToken, config)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
 	cases := []struct {
@@ -716,6 +1417,11 @@ func TestRemoveChannelHandler(t *testing.T) {
 		err := svc.RemoveChannelHandler(tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	cfg, err := svc.View(validToken, saved.MFThing)
+	require.Nil(t, err, fmt.Sprintf("Viewing config expected to succeed: %s.\n", err))
+	assert.Empty(t, cfg.MFChannels, fmt.Sprintf("expected removed channel to be disconnected got %v\n", cfg.MFChannels))
+	assert.Equal(t, saved.Version+1, cfg.Version, fmt.Sprintf("expected version %d got %d\n", saved.Version+1, cfg.Version))
 }
 
 func TestRemoveCoinfigHandler(t *testing.T) {
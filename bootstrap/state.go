@@ -10,17 +10,52 @@ const (
 	Inactive State = iota
 	// Active Thing is created, configured, and whitelisted.
 	Active
+	// Suspended Thing is temporarily blocked from bootstrapping, but keeps its connections.
+	Suspended
+	// Decommissioned Thing has its key revoked and certificates removed, while its Config is archived.
+	Decommissioned
 )
 
 // State represents corresponding Mainflux Thing state. The possible Config States
 // as well as description of what that State represents are given in the table:
-// | State    | What it means                                                                  |
-// |----------+--------------------------------------------------------------------------------|
-// | Inactive | Thing is created, but isn't able to communicate over Mainflux                  |
-// | Active   | Thing is able to communicate using Mainflux                                    |
+// | State          | What it means                                                            |
+// |----------------+--------------------------------------------------------------------------|
+// | Inactive       | Thing is created, but isn't able to communicate over Mainflux            |
+// | Active         | Thing is able to communicate using Mainflux                              |
+// | Suspended      | Thing is connected, but temporarily unable to bootstrap                  |
+// | Decommissioned | Thing key is revoked and Config is archived; this is the final State     |
 type State int
 
+// transitions contains the States each State is allowed to transition to.
+var transitions = map[State][]State{
+	Inactive:       {Active, Decommissioned},
+	Active:         {Inactive, Suspended, Decommissioned},
+	Suspended:      {Inactive, Active, Decommissioned},
+	Decommissioned: {},
+}
+
 // String returns string representation of State.
 func (s State) String() string {
 	return strconv.Itoa(int(s))
 }
+
+// Valid returns true if State is one of the known States.
+func (s State) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition returns true if State is allowed to transition to the given State.
+func (s State) CanTransition(to State) bool {
+	for _, st := range transitions[s] {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+// connected returns true if Thing in this State is connected to its Channels.
+func (s State) connected() bool {
+	return s == Active || s == Suspended
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+// Template represents reusable Config content. Template Content is a Go
+// text/template which is rendered against the Config it's assigned to at
+// bootstrap time, so any Config field can be used as a variable (e.g.
+// {{.ExternalID}}, {{.MFThing}} or {{range .MFChannels}}{{.ID}}{{end}}).
+type Template struct {
+	ID      string
+	Owner   string
+	Name    string
+	Content string
+}
+
+// TemplatesPage contains page related metadata as well as list of Templates
+// that belong to this page.
+type TemplatesPage struct {
+	Total     uint64
+	Offset    uint64
+	Limit     uint64
+	Templates []Template
+}
+
+// TemplateRepository specifies a Template persistence API.
+type TemplateRepository interface {
+	// Save persists the Template. Successful operation is indicated by non-nil
+	// error response.
+	Save(tpl Template) (string, error)
+
+	// RetrieveByID retrieves the Template having the provided identifier, that is owned
+	// by the specified user.
+	RetrieveByID(owner, id string) (Template, error)
+
+	// RetrieveAll retrieves a subset of Templates that are owned by the specific user.
+	RetrieveAll(owner string, offset, limit uint64) (TemplatesPage, error)
+
+	// Update updates an existing Template. A non-nil error is returned
+	// to indicate operation failure.
+	Update(tpl Template) error
+
+	// Remove removes the Template having the provided identifier, that is owned
+	// by the specified user.
+	Remove(owner, id string) error
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// initialVersion is the version of the newly created Config.
+const initialVersion uint64 = 1
+
+// ConfigVersion represents a snapshot of the Config taken every time its
+// Content, connections or certificates change. Versions are numbered
+// sequentially per Config, starting from 1. The client key isn't kept in
+// the versions, only its digest.
+type ConfigVersion struct {
+	Version         uint64
+	Content         string
+	TemplateID      string
+	ClientCert      string
+	ClientKeyDigest string
+	CACert          string
+	Channels        []string
+	CreatedAt       time.Time
+}
+
+// ConfigVersionsPage contains page related metadata as well as list of
+// Config versions that belong to this page.
+type ConfigVersionsPage struct {
+	Total    uint64
+	Offset   uint64
+	Limit    uint64
+	Versions []ConfigVersion
+}
+
+// VersionsDiff represents the difference between two Config versions.
+// Changed contains names of the changed fields. Content is a line diff of
+// the Config Content, where each line is prefixed with "+" if added, "-" if
+// removed or " " if unchanged.
+type VersionsDiff struct {
+	From            uint64
+	To              uint64
+	Changed         []string
+	Content         []string
+	AddedChannels   []string
+	RemovedChannels []string
+}
+
+// Diff returns the difference between the two given Config versions.
+func Diff(from, to ConfigVersion) VersionsDiff {
+	d := VersionsDiff{
+		From: from.Version,
+		To:   to.Version,
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"content", from.Content, to.Content},
+		{"template_id", from.TemplateID, to.TemplateID},
+		{"client_cert", from.ClientCert, to.ClientCert},
+		{"client_key", from.ClientKeyDigest, to.ClientKeyDigest},
+		{"ca_cert", from.CACert, to.CACert},
+	}
+	for _, f := range fields {
+		if f.from != f.to {
+			d.Changed = append(d.Changed, f.name)
+		}
+	}
+
+	if from.Content != to.Content {
+		d.Content = diffLines(from.Content, to.Content)
+	}
+
+	d.AddedChannels = subtract(to.Channels, from.Channels)
+	d.RemovedChannels = subtract(from.Channels, to.Channels)
+	if len(d.AddedChannels) > 0 || len(d.RemovedChannels) > 0 {
+		d.Changed = append(d.Changed, "channels")
+	}
+
+	return d
+}
+
+// KeyDigest returns the hex encoded SHA-256 digest of the Config client key,
+// or an empty string if there is no key.
+func KeyDigest(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// diffLines calculates the line diff using the longest common subsequence.
+func diffLines(from, to string) []string {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ret []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ret = append(ret, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ret = append(ret, "-"+a[i])
+			i++
+		default:
+			ret = append(ret, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ret = append(ret, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		ret = append(ret, "+"+b[j])
+	}
+
+	return ret
+}
+
+// subtract returns sorted elements of a that are not present in b.
+func subtract(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, s := range b {
+		exclude[s] = true
+	}
+
+	var ret []string
+	for _, s := range a {
+		if !exclude[s] {
+			ret = append(ret, s)
+		}
+	}
+	sort.Strings(ret)
+
+	return ret
+}
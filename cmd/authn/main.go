@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -44,6 +46,8 @@ const (
 	defHTTPPort      = "8180"
 	defGRPCPort      = "8181"
 	defSecret        = "authn"
+	defSigningKey    = ""
+	defVerifyKeys    = ""
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
@@ -61,6 +65,8 @@ const (
 	envHTTPPort      = "MF_AUTHN_HTTP_PORT"
 	envGRPCPort      = "MF_AUTHN_GRPC_PORT"
 	envSecret        = "MF_AUTHN_SECRET"
+	envSigningKey    = "MF_AUTHN_SIGNING_KEY"
+	envVerifyKeys    = "MF_AUTHN_VERIFICATION_KEYS"
 	envServerCert    = "MF_AUTHN_SERVER_CERT"
 	envServerKey     = "MF_AUTHN_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
@@ -72,6 +78,8 @@ type config struct {
 	httpPort   string
 	grpcPort   string
 	secret     string
+	signingKey string
+	verifyKeys []string
 	serverCert string
 	serverKey  string
 	jaegerURL  string
@@ -100,7 +108,8 @@ func main() {
 	dbTracer, dbCloser := initJaeger("authn_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, cfg.secret, logger)
+	t := newTokenizer(cfg, logger)
+	svc := newService(db, dbTracer, t, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
@@ -135,6 +144,8 @@ func loadConfig() config {
 		httpPort:   mainflux.Env(envHTTPPort, defHTTPPort),
 		grpcPort:   mainflux.Env(envGRPCPort, defGRPCPort),
 		secret:     mainflux.Env(envSecret, defSecret),
+		signingKey: mainflux.Env(envSigningKey, defSigningKey),
+		verifyKeys: splitPaths(mainflux.Env(envVerifyKeys, defVerifyKeys)),
 		serverCert: mainflux.Env(envServerCert, defServerCert),
 		serverKey:  mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:  mainflux.Env(envJaegerURL, defJaegerURL),
@@ -175,12 +186,61 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger logger.Logger) authn.Service {
+func splitPaths(paths string) []string {
+	var ret []string
+	for _, p := range strings.Split(paths, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+func newTokenizer(cfg config, logger logger.Logger) authn.Tokenizer {
+	if cfg.signingKey == "" {
+		return jwt.New(cfg.secret)
+	}
+
+	data, err := ioutil.ReadFile(cfg.signingKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read signing key: %s", err))
+		os.Exit(1)
+	}
+	signingKey, err := jwt.ParsePrivateKey(data)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to parse signing key: %s", err))
+		os.Exit(1)
+	}
+
+	var verifyKeys []crypto.PublicKey
+	for _, path := range cfg.verifyKeys {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to read verification key %s: %s", path, err))
+			os.Exit(1)
+		}
+		key, err := jwt.ParsePublicKey(data)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to parse verification key %s: %s", path, err))
+			os.Exit(1)
+		}
+		verifyKeys = append(verifyKeys, key)
+	}
+
+	t, err := jwt.NewAsymmetric(signingKey, verifyKeys...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create tokenizer: %s", err))
+		os.Exit(1)
+	}
+
+	return t
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, t authn.Tokenizer, logger logger.Logger) authn.Service {
 	database := postgres.NewDatabase(db)
 	repo := tracing.New(postgres.New(database), tracer)
 
 	up := uuidProvider.New()
-	t := jwt.New(secret)
 	svc := authn.New(repo, up, t)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
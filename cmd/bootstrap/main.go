@@ -4,6 +4,8 @@ package main
 
 import (
 	"crypto/aes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -31,6 +33,7 @@ import (
 	"github.com/mainflux/mainflux/bootstrap/postgres"
 	mflog "github.com/mainflux/mainflux/logger"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/uuid"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -54,8 +57,11 @@ const (
 	defPort           = "8180"
 	defServerCert     = ""
 	defServerKey      = ""
+	defClientCACerts  = ""
+	defCertIDField    = bootstrap.CommonNameField
 	defBaseURL        = "http://localhost"
 	defThingsPrefix   = ""
+	defCertsURL       = "http://localhost/certs"
 	defThingsESURL    = "localhost:6379"
 	defThingsESPass   = ""
 	defThingsESDB     = "0"
@@ -83,8 +89,11 @@ const (
 	envPort           = "MF_BOOTSTRAP_PORT"
 	envServerCert     = "MF_BOOTSTRAP_SERVER_CERT"
 	envServerKey      = "MF_BOOTSTRAP_SERVER_KEY"
+	envClientCACerts  = "MF_BOOTSTRAP_CLIENT_CA_CERTS"
+	envCertIDField    = "MF_BOOTSTRAP_CERT_ID_FIELD"
 	envBaseURL        = "MF_SDK_BASE_URL"
 	envThingsPrefix   = "MF_SDK_THINGS_PREFIX"
+	envCertsURL       = "MF_SDK_CERTS_URL"
 	envThingsESURL    = "MF_THINGS_ES_URL"
 	envThingsESPass   = "MF_THINGS_ES_PASS"
 	envThingsESDB     = "MF_THINGS_ES_DB"
@@ -106,8 +115,11 @@ type config struct {
 	httpPort       string
 	serverCert     string
 	serverKey      string
+	clientCACerts  string
+	certIDField    string
 	baseURL        string
 	thingsPrefix   string
+	certsURL       string
 	esThingsURL    string
 	esThingsPass   string
 	esThingsDB     string
@@ -193,6 +205,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envEncryptKey, err.Error())
 	}
 
+	certIDField := mainflux.Env(envCertIDField, defCertIDField)
+	if !bootstrap.ValidCertField(certIDField) {
+		log.Fatalf("Invalid %s value: %s", envCertIDField, certIDField)
+	}
+
 	return config{
 		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:       dbConfig,
@@ -202,8 +219,11 @@ func loadConfig() config {
 		httpPort:       mainflux.Env(envPort, defPort),
 		serverCert:     mainflux.Env(envServerCert, defServerCert),
 		serverKey:      mainflux.Env(envServerKey, defServerKey),
+		clientCACerts:  mainflux.Env(envClientCACerts, defClientCACerts),
+		certIDField:    certIDField,
 		baseURL:        mainflux.Env(envBaseURL, defBaseURL),
 		thingsPrefix:   mainflux.Env(envThingsPrefix, defThingsPrefix),
+		certsURL:       mainflux.Env(envCertsURL, defCertsURL),
 		esThingsURL:    mainflux.Env(envThingsESURL, defThingsESURL),
 		esThingsPass:   mainflux.Env(envThingsESPass, defThingsESPass),
 		esThingsDB:     mainflux.Env(envThingsESDB, defThingsESDB),
@@ -266,15 +286,17 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 
 func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logger, esClient *r.Client, cfg config) bootstrap.Service {
 	thingsRepo := postgres.NewConfigRepository(db, logger)
+	templatesRepo := postgres.NewTemplateRepository(db, logger)
 
 	config := mfsdk.Config{
 		BaseURL:      cfg.baseURL,
 		ThingsPrefix: cfg.thingsPrefix,
+		CertsURL:     cfg.certsURL,
 	}
 
 	sdk := mfsdk.NewSDK(config)
 
-	svc := bootstrap.New(auth, thingsRepo, sdk, cfg.encKey)
+	svc := bootstrap.New(auth, thingsRepo, templatesRepo, sdk, cfg.encKey, uuid.New(), logger)
 	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -322,14 +344,49 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 
 func startHTTPServer(svc bootstrap.Service, cfg config, logger mflog.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
+	readers := map[string]bootstrap.ConfigReader{
+		bootstrap.JSONFormat: bootstrap.NewConfigReader(cfg.encKey),
+		bootstrap.TOMLFormat: bootstrap.NewTOMLReader(cfg.encKey),
+		bootstrap.YAMLFormat: bootstrap.NewYAMLReader(cfg.encKey),
+		bootstrap.RawFormat:  bootstrap.NewRawReader(cfg.encKey),
+	}
+	server := &http.Server{
+		Addr:    p,
+		Handler: api.MakeHandler(svc, readers, cfg.certIDField),
+	}
 	if cfg.serverCert != "" || cfg.serverKey != "" {
+		if cfg.clientCACerts != "" {
+			server.TLSConfig = clientTLSConfig(cfg.clientCACerts, logger)
+			logger.Info(fmt.Sprintf("Bootstrap using client certificates enabled with trusted CAs %s", cfg.clientCACerts))
+		}
 		logger.Info(fmt.Sprintf("Bootstrap service started using https on port %s with cert %s key %s",
 			cfg.httpPort, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(svc, bootstrap.NewConfigReader(cfg.encKey)))
+		errs <- server.ListenAndServeTLS(cfg.serverCert, cfg.serverKey)
 		return
 	}
 	logger.Info(fmt.Sprintf("Bootstrap service started using http on port %s", cfg.httpPort))
-	errs <- http.ListenAndServe(p, api.MakeHandler(svc, bootstrap.NewConfigReader(cfg.encKey)))
+	errs <- server.ListenAndServe()
+}
+
+// clientTLSConfig returns TLS config which verifies client certificates, if
+// provided, against the trusted CAs loaded from the given PEM file.
+func clientTLSConfig(caCerts string, logger mflog.Logger) *tls.Config {
+	pem, err := ioutil.ReadFile(caCerts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load client CA certificates: %s", err))
+		os.Exit(1)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		logger.Error(fmt.Sprintf("Failed to parse client CA certificates from %s", caCerts))
+		os.Exit(1)
+	}
+
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
 }
 
 func subscribeToThingsES(svc bootstrap.Service, client *r.Client, consumer string, logger mflog.Logger) {
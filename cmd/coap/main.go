@@ -4,10 +4,13 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,15 +18,18 @@ import (
 	"syscall"
 	"time"
 
-	gocoap "github.com/dustin/go-coap"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/coap"
 	"github.com/mainflux/mainflux/coap/api"
 	logger "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pion/dtls/v2"
+	"github.com/pion/udp"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -31,6 +37,10 @@ import (
 )
 
 const (
+	// DTLS modes.
+	pskMode  = "psk"
+	certMode = "cert"
+
 	defPort              = "5683"
 	defNatsURL           = "nats://localhost:4222"
 	defLogLevel          = "error"
@@ -40,6 +50,22 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defDTLSPort          = "5684"
+	defDTLSMode          = ""
+	defServerCert        = ""
+	defServerKey         = ""
+	defClientCACerts     = ""
+	defAuthCacheURL      = "localhost:6379"
+	defAuthCachePass     = ""
+	defAuthCacheDB       = "0"
+	defESURL             = "localhost:6379"
+	defESPass            = ""
+	defESDB              = "0"
+	defESConsumerName    = "coap"
+	defRateLimit         = "0"
+	defBurstLimit        = "0"
+	defDailyQuota        = "0"
+	defAdapterSecret     = ""
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envNatsURL           = "MF_NATS_URL"
@@ -50,6 +76,24 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envDTLSPort          = "MF_COAP_ADAPTER_DTLS_PORT"
+	envDTLSMode          = "MF_COAP_ADAPTER_DTLS_MODE"
+	envServerCert        = "MF_COAP_ADAPTER_SERVER_CERT"
+	envServerKey         = "MF_COAP_ADAPTER_SERVER_KEY"
+	envClientCACerts     = "MF_COAP_ADAPTER_CLIENT_CA_CERTS"
+	envAuthCacheURL      = "MF_AUTH_CACHE_URL"
+	envAuthCachePass     = "MF_AUTH_CACHE_PASS"
+	envAuthCacheDB       = "MF_AUTH_CACHE_DB"
+	envESURL             = "MF_COAP_ADAPTER_ES_URL"
+	envESPass            = "MF_COAP_ADAPTER_ES_PASS"
+	envESDB              = "MF_COAP_ADAPTER_ES_DB"
+	envESConsumerName    = "MF_COAP_ADAPTER_EVENT_CONSUMER"
+	envRateLimit         = "MF_COAP_ADAPTER_RATE_LIMIT"
+	envBurstLimit        = "MF_COAP_ADAPTER_BURST_LIMIT"
+	envDailyQuota        = "MF_COAP_ADAPTER_DAILY_QUOTA"
+	envAdapterSecret     = "MF_THINGS_ADAPTER_SECRET"
+
+	cacheGroup = "mainflux.coap.cache"
 )
 
 type config struct {
@@ -62,6 +106,20 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	dtlsPort          string
+	dtlsMode          string
+	serverCert        string
+	serverKey         string
+	clientCACerts     string
+	authCacheURL      string
+	authCachePass     string
+	authCacheDB       string
+	esURL             string
+	esPass            string
+	esDB              string
+	esConsumerName    string
+	limits            auth.Limits
+	adapterSecret     string
 }
 
 func main() {
@@ -79,6 +137,17 @@ func main() {
 	defer thingsCloser.Close()
 
 	cc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
+
+	cacheClient := connectToRedis(cfg.authCacheURL, cfg.authCachePass, cfg.authCacheDB, logger)
+	defer cacheClient.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	limiter := auth.NewLimiter(cacheClient, cc, cfg.limits)
+	schemas := auth.NewSchemas(cacheClient, cc, logger)
+	go subscribeToThingsES(cacheClient, esClient, schemas, cfg.esConsumerName, logger)
+
 	respChan := make(chan string, 10000)
 
 	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
@@ -108,10 +177,15 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	errs := make(chan error, 2)
+	errs := make(chan error, 3)
+
+	h := api.MakeCOAPHandler(svc, cc, limiter, schemas, logger, respChan, cfg.pingPeriod)
 
 	go startHTTPServer(cfg.port, logger, errs)
-	go startCOAPServer(cfg, svc, cc, respChan, logger, errs)
+	go startCOAPServer(cfg, h, logger, errs)
+	if cfg.dtlsMode != "" {
+		go startDTLSServer(cfg, h, logger, errs)
+	}
 
 	go func() {
 		c := make(chan os.Signal)
@@ -143,6 +217,16 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	dtlsMode := mainflux.Env(envDTLSMode, defDTLSMode)
+	if dtlsMode != "" && dtlsMode != pskMode && dtlsMode != certMode {
+		log.Fatalf("Invalid value passed for %s\n", envDTLSMode)
+	}
+
+	adapterSecret := mainflux.Env(envAdapterSecret, defAdapterSecret)
+	if dtlsMode == pskMode && adapterSecret == "" {
+		log.Fatalf("Value of %s must be set in %s DTLS mode", envAdapterSecret, pskMode)
+	}
+
 	return config{
 		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -153,7 +237,43 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		dtlsPort:          mainflux.Env(envDTLSPort, defDTLSPort),
+		dtlsMode:          dtlsMode,
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		clientCACerts:     mainflux.Env(envClientCACerts, defClientCACerts),
+		authCacheURL:      mainflux.Env(envAuthCacheURL, defAuthCacheURL),
+		authCachePass:     mainflux.Env(envAuthCachePass, defAuthCachePass),
+		authCacheDB:       mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		esURL:             mainflux.Env(envESURL, defESURL),
+		esPass:            mainflux.Env(envESPass, defESPass),
+		esDB:              mainflux.Env(envESDB, defESDB),
+		esConsumerName:    mainflux.Env(envESConsumerName, defESConsumerName),
+		limits:            loadLimits(),
+		adapterSecret:     adapterSecret,
+	}
+}
+
+func loadLimits() auth.Limits {
+	var limits auth.Limits
+	values := []struct {
+		limit *uint64
+		env   string
+		def   string
+	}{
+		{&limits.Rate, envRateLimit, defRateLimit},
+		{&limits.Burst, envBurstLimit, defBurstLimit},
+		{&limits.Quota, envDailyQuota, defDailyQuota},
+	}
+	for _, v := range values {
+		n, err := strconv.ParseUint(mainflux.Env(v.env, v.def), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", v.env, err.Error())
+		}
+		*v.limit = n
 	}
+
+	return limits
 }
 
 func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
@@ -171,6 +291,9 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 		opts = append(opts, grpc.WithInsecure())
 	}
+	if cfg.adapterSecret != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(thingsapi.NewAdapterCredentials(cfg.adapterSecret, cfg.clientTLS)))
+	}
 
 	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
 	if err != nil {
@@ -180,6 +303,28 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func subscribeToThingsES(cache, client *redis.Client, schemas auth.Schemas, consumer string, logger logger.Logger) {
+	eventStore := auth.NewEventStore(cache, client, schemas, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(cacheGroup); err != nil {
+		logger.Warn(fmt.Sprintf("Things event store subscription failed: %s", err))
+	}
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -210,8 +355,58 @@ func startHTTPServer(port string, logger logger.Logger, errs chan error) {
 	errs <- http.ListenAndServe(p, api.MakeHTTPHandler())
 }
 
-func startCOAPServer(cfg config, svc coap.Service, auth mainflux.ThingsServiceClient, respChan chan<- string, l logger.Logger, errs chan error) {
+func startCOAPServer(cfg config, h *api.Handler, l logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	l.Info(fmt.Sprintf("CoAP adapter service started, exposed port %s", cfg.port))
-	errs <- gocoap.ListenAndServe("udp", p, api.MakeCOAPHandler(svc, auth, l, respChan, cfg.pingPeriod))
+	errs <- h.ListenAndServe(p)
+}
+
+func startDTLSServer(cfg config, h *api.Handler, l logger.Logger, errs chan error) {
+	addr, err := net.ResolveUDPAddr("udp", fmt.Sprintf(":%s", cfg.dtlsPort))
+	if err != nil {
+		errs <- err
+		return
+	}
+
+	dtlsCfg := dtls.Config{}
+	if cfg.dtlsMode == certMode {
+		dtlsCfg = certConfig(cfg, l)
+	}
+
+	ln, err := udp.Listen("udp", addr)
+	if err != nil {
+		errs <- err
+		return
+	}
+
+	l.Info(fmt.Sprintf("CoAP adapter DTLS server started in %s mode, exposed port %s", cfg.dtlsMode, cfg.dtlsPort))
+	errs <- h.ServeDTLS(ln, dtlsCfg)
+}
+
+// certConfig returns DTLS config which requires client certificates signed by
+// the trusted CAs loaded from the given PEM file.
+func certConfig(cfg config, l logger.Logger) dtls.Config {
+	cert, err := tls.LoadX509KeyPair(cfg.serverCert, cfg.serverKey)
+	if err != nil {
+		l.Error(fmt.Sprintf("Failed to load server certificate: %s", err))
+		os.Exit(1)
+	}
+
+	pem, err := ioutil.ReadFile(cfg.clientCACerts)
+	if err != nil {
+		l.Error(fmt.Sprintf("Failed to read client CA certificates: %s", err))
+		os.Exit(1)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		l.Error(fmt.Sprintf("Failed to parse client CA certificates from %s", cfg.clientCACerts))
+		os.Exit(1)
+	}
+
+	return dtls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   dtls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
 }
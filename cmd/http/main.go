@@ -18,10 +18,12 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/opentracing/opentracing-go"
@@ -39,6 +41,16 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defAuthCacheURL      = "localhost:6379"
+	defAuthCachePass     = ""
+	defAuthCacheDB       = "0"
+	defESURL             = "localhost:6379"
+	defESPass            = ""
+	defESDB              = "0"
+	defESConsumerName    = "http"
+	defRateLimit         = "0"
+	defBurstLimit        = "0"
+	defDailyQuota        = "0"
 
 	envLogLevel          = "MF_HTTP_ADAPTER_LOG_LEVEL"
 	envClientTLS         = "MF_HTTP_ADAPTER_CLIENT_TLS"
@@ -48,6 +60,18 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthCacheURL      = "MF_AUTH_CACHE_URL"
+	envAuthCachePass     = "MF_AUTH_CACHE_PASS"
+	envAuthCacheDB       = "MF_AUTH_CACHE_DB"
+	envESURL             = "MF_HTTP_ADAPTER_ES_URL"
+	envESPass            = "MF_HTTP_ADAPTER_ES_PASS"
+	envESDB              = "MF_HTTP_ADAPTER_ES_DB"
+	envESConsumerName    = "MF_HTTP_ADAPTER_EVENT_CONSUMER"
+	envRateLimit         = "MF_HTTP_ADAPTER_RATE_LIMIT"
+	envBurstLimit        = "MF_HTTP_ADAPTER_BURST_LIMIT"
+	envDailyQuota        = "MF_HTTP_ADAPTER_DAILY_QUOTA"
+
+	cacheGroup = "mainflux.http.cache"
 )
 
 type config struct {
@@ -59,6 +83,14 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	authCacheURL      string
+	authCachePass     string
+	authCacheDB       string
+	esURL             string
+	esPass            string
+	esDB              string
+	esConsumerName    string
+	limits            auth.Limits
 }
 
 func main() {
@@ -85,8 +117,19 @@ func main() {
 	}
 	defer pub.Close()
 
+	cacheClient := connectToRedis(cfg.authCacheURL, cfg.authCachePass, cfg.authCacheDB, logger)
+	defer cacheClient.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
-	svc := adapter.New(pub, tc)
+	aliases := auth.NewAliases(cacheClient, tc)
+	limiter := auth.NewLimiter(cacheClient, tc, cfg.limits)
+	schemas := auth.NewSchemas(cacheClient, tc, logger)
+	go subscribeToThingsES(cacheClient, esClient, schemas, cfg.esConsumerName, logger)
+
+	svc := adapter.New(pub, tc, aliases, limiter, schemas)
 
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -143,7 +186,37 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		authCacheURL:      mainflux.Env(envAuthCacheURL, defAuthCacheURL),
+		authCachePass:     mainflux.Env(envAuthCachePass, defAuthCachePass),
+		authCacheDB:       mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		esURL:             mainflux.Env(envESURL, defESURL),
+		esPass:            mainflux.Env(envESPass, defESPass),
+		esDB:              mainflux.Env(envESDB, defESDB),
+		esConsumerName:    mainflux.Env(envESConsumerName, defESConsumerName),
+		limits:            loadLimits(),
+	}
+}
+
+func loadLimits() auth.Limits {
+	var limits auth.Limits
+	values := []struct {
+		limit *uint64
+		env   string
+		def   string
+	}{
+		{&limits.Rate, envRateLimit, defRateLimit},
+		{&limits.Burst, envBurstLimit, defBurstLimit},
+		{&limits.Quota, envDailyQuota, defDailyQuota},
 	}
+	for _, v := range values {
+		n, err := strconv.ParseUint(mainflux.Env(v.env, v.def), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", v.env, err.Error())
+		}
+		*v.limit = n
+	}
+
+	return limits
 }
 
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
@@ -193,3 +266,25 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	}
 	return conn
 }
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func subscribeToThingsES(cache, client *redis.Client, schemas auth.Schemas, consumer string, logger logger.Logger) {
+	eventStore := auth.NewEventStore(cache, client, schemas, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(cacheGroup); err != nil {
+		logger.Warn(fmt.Sprintf("Things event store subscription failed: %s", err))
+	}
+}
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/mainflux/mainflux/lora"
 	"github.com/mainflux/mainflux/lora/api"
 	"github.com/mainflux/mainflux/lora/mqtt"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -38,6 +40,8 @@ const (
 	defRouteMapURL    = "localhost:6379"
 	defRouteMapPass   = ""
 	defRouteMapDB     = "0"
+	defPublishMeta    = "false"
+	defChirpStack     = "false"
 
 	envHTTPPort       = "MF_LORA_ADAPTER_HTTP_PORT"
 	envLoraMsgURL     = "MF_LORA_ADAPTER_MESSAGES_URL"
@@ -50,11 +54,21 @@ const (
 	envRouteMapURL    = "MF_LORA_ADAPTER_ROUTE_MAP_URL"
 	envRouteMapPass   = "MF_LORA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_LORA_ADAPTER_ROUTE_MAP_DB"
+	envPublishMeta    = "MF_LORA_ADAPTER_PUBLISH_METADATA"
+	envChirpStack     = "MF_LORA_ADAPTER_CHIRPSTACK_V3"
 
-	loraServerTopic = "application/+/device/+/rx"
+	loraServerTopic       = "application/+/device/+/rx"
+	loraServerAckTopic    = "application/+/device/+/ack"
+	loraServerErrorTopic  = "application/+/device/+/error"
+	loraServerJoinTopic   = "application/+/device/+/join"
+	loraServerStatusTopic = "application/+/device/+/status"
+	chirpStackEventsTopic = "application/+/device/+/event/+"
+
+	protocol = "lora"
 
 	thingsRMPrefix   = "thing"
 	channelsRMPrefix = "channel"
+	codecsPrefix     = "codec"
 )
 
 type config struct {
@@ -69,6 +83,8 @@ type config struct {
 	routeMapURL    string
 	routeMapPass   string
 	routeMapDB     string
+	publishMeta    bool
+	chirpStack     bool
 }
 
 func main() {
@@ -85,19 +101,22 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pub, err := nats.NewPublisher(cfg.natsURL)
+	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
 	}
-	defer pub.Close()
+	defer pubSub.Close()
 
 	thingRM := newRouteMapRepositoy(rmConn, thingsRMPrefix, logger)
 	chanRM := newRouteMapRepositoy(rmConn, channelsRMPrefix, logger)
+	codecs := redis.NewCodecRepository(rmConn, codecsPrefix)
 
 	mqttConn := connectToMQTTBroker(cfg.loraMsgURL, logger)
 
-	svc := lora.New(pub, thingRM, chanRM)
+	downlinks := mqtt.NewPublisher(mqttConn, cfg.chirpStack)
+
+	svc := lora.New(pubSub, downlinks, thingRM, chanRM, codecs, cfg.publishMeta)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -115,9 +134,14 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	go subscribeToLoRaBroker(svc, mqttConn, logger)
+	go subscribeToLoRaBroker(svc, mqttConn, cfg.chirpStack, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
+	if err := subscribeToNats(pubSub, svc, chanRM); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to NATS: %s", err))
+		os.Exit(1)
+	}
+
 	errs := make(chan error, 2)
 
 	go startHTTPServer(cfg, logger, errs)
@@ -133,6 +157,16 @@ func main() {
 }
 
 func loadConfig() config {
+	publishMeta, err := strconv.ParseBool(mainflux.Env(envPublishMeta, defPublishMeta))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envPublishMeta)
+	}
+
+	chirpStack, err := strconv.ParseBool(mainflux.Env(envChirpStack, defChirpStack))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envChirpStack)
+	}
+
 	return config{
 		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
 		loraMsgURL:     mainflux.Env(envLoraMsgURL, defLoraMsgURL),
@@ -145,6 +179,8 @@ func loadConfig() config {
 		routeMapURL:    mainflux.Env(envRouteMapURL, defRouteMapURL),
 		routeMapPass:   mainflux.Env(envRouteMapPass, defRouteMapPass),
 		routeMapDB:     mainflux.Env(envRouteMapDB, defRouteMapDB),
+		publishMeta:    publishMeta,
+		chirpStack:     chirpStack,
 	}
 }
 
@@ -184,13 +220,36 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToLoRaBroker(svc lora.Service, mc mqttPaho.Client, logger logger.Logger) {
+func subscribeToLoRaBroker(svc lora.Service, mc mqttPaho.Client, chirpStack bool, logger logger.Logger) {
 	mqtt := mqtt.NewBroker(svc, mc, logger)
 	logger.Info("Subscribed to Lora MQTT broker")
-	if err := mqtt.Subscribe(loraServerTopic); err != nil {
-		logger.Error(fmt.Sprintf("Failed to subscribe to Lora MQTT broker: %s", err))
-		os.Exit(1)
+
+	topics := []string{loraServerTopic, loraServerAckTopic, loraServerErrorTopic, loraServerJoinTopic, loraServerStatusTopic}
+	if chirpStack {
+		topics = []string{chirpStackEventsTopic}
 	}
+
+	for _, topic := range topics {
+		if err := mqtt.Subscribe(topic); err != nil {
+			logger.Error(fmt.Sprintf("Failed to subscribe to Lora MQTT broker: %s", err))
+			os.Exit(1)
+		}
+	}
+}
+
+func subscribeToNats(ps messaging.Subscriber, svc lora.Service, chanRM lora.RouteMapRepository) error {
+	return ps.Subscribe(nats.SubjectAllChannels, func(msg messaging.Message) error {
+		// Skip messages published by the adapter itself, as well as
+		// messages on Channels which are not mapped to LoRa applications.
+		if msg.Protocol == protocol {
+			return nil
+		}
+		if _, err := chanRM.Get(msg.Channel); err != nil {
+			return nil
+		}
+
+		return svc.Downlink(context.Background(), msg)
+	})
 }
 
 func subscribeToThingsES(svc lora.Service, client *r.Client, consumer string, logger logger.Logger) {
@@ -22,9 +22,6 @@ import (
 	mqttpub "github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
-	mp "github.com/mainflux/mproxy/pkg/mqtt"
-	"github.com/mainflux/mproxy/pkg/session"
-	ws "github.com/mainflux/mproxy/pkg/websocket"
 	opentracing "github.com/opentracing/opentracing-go"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -79,6 +76,10 @@ const (
 	defESURL  = "localhost:6379"
 	defESPass = ""
 	defESDB   = "0"
+
+	envESConsumerName = "MF_MQTT_ADAPTER_EVENT_CONSUMER"
+	defESConsumerName = "mqtt"
+	cacheGroup        = "mainflux.mqtt.cache"
 	// Auth cache
 	envAuthCacheURL  = "MF_AUTH_CACHE_URL"
 	envAuthCachePass = "MF_AUTH_CACHE_PASS"
@@ -86,6 +87,13 @@ const (
 	defAuthcacheURL  = "localhost:6379"
 	defAuthCachePass = ""
 	defAuthCacheDB   = "0"
+	// Limits
+	envRateLimit  = "MF_MQTT_ADAPTER_RATE_LIMIT"
+	envBurstLimit = "MF_MQTT_ADAPTER_BURST_LIMIT"
+	envDailyQuota = "MF_MQTT_ADAPTER_DAILY_QUOTA"
+	defRateLimit  = "0"
+	defBurstLimit = "0"
+	defDailyQuota = "0"
 )
 
 type config struct {
@@ -109,9 +117,11 @@ type config struct {
 	esURL                string
 	esPass               string
 	esDB                 string
+	esConsumerName       string
 	authURL              string
 	authPass             string
 	authDB               string
+	limits               auth.Limits
 }
 
 func main() {
@@ -164,17 +174,21 @@ func main() {
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
 
 	authClient := auth.New(ac, tc)
+	aliases := auth.NewAliases(ac, tc)
+	limiter := auth.NewLimiter(ac, tc, cfg.limits)
+	schemas := auth.NewSchemas(ac, tc, logger)
+	go subscribeToThingsES(ac, ec, schemas, cfg.esConsumerName, logger)
 
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient)
+	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, limiter, schemas)
 
 	errs := make(chan error, 2)
 
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.mqttPort))
-	go proxyMQTT(cfg, logger, h, errs)
+	go proxyMQTT(cfg, logger, h, aliases, errs)
 
 	logger.Info(fmt.Sprintf("Starting MQTT over WS  proxy on port %s", cfg.httpPort))
-	go proxyWS(cfg, logger, h, errs)
+	go proxyWS(cfg, logger, h, aliases, errs)
 
 	go func() {
 		c := make(chan os.Signal, 1)
@@ -223,10 +237,34 @@ func loadConfig() config {
 		esURL:                mainflux.Env(envESURL, defESURL),
 		esPass:               mainflux.Env(envESPass, defESPass),
 		esDB:                 mainflux.Env(envESDB, defESDB),
+		esConsumerName:       mainflux.Env(envESConsumerName, defESConsumerName),
 		authURL:              mainflux.Env(envAuthCacheURL, defAuthcacheURL),
 		authPass:             mainflux.Env(envAuthCachePass, defAuthCachePass),
 		authDB:               mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		limits:               loadLimits(),
+	}
+}
+
+func loadLimits() auth.Limits {
+	var limits auth.Limits
+	values := []struct {
+		limit *uint64
+		env   string
+		def   string
+	}{
+		{&limits.Rate, envRateLimit, defRateLimit},
+		{&limits.Burst, envBurstLimit, defBurstLimit},
+		{&limits.Quota, envDailyQuota, defDailyQuota},
+	}
+	for _, v := range values {
+		n, err := strconv.ParseUint(mainflux.Env(v.env, v.def), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", v.env, err.Error())
+		}
+		*v.limit = n
 	}
+
+	return limits
 }
 
 func initJaeger(svcName, url string, logger mflog.Logger) (opentracing.Tracer, io.Closer) {
@@ -291,18 +329,26 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger mflog.Logger) *r
 	})
 }
 
-func proxyMQTT(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
+func proxyMQTT(cfg config, logger mflog.Logger, handler mqtt.Handler, aliases auth.Aliases, errs chan error) {
 	address := fmt.Sprintf(":%s", cfg.mqttPort)
 	target := fmt.Sprintf("%s:%s", cfg.mqttTargetHost, cfg.mqttTargetPort)
-	mp := mp.New(address, target, handler, logger)
+	mp := mqtt.NewProxy(address, target, handler, aliases, logger)
 
 	errs <- mp.Proxy()
 }
-func proxyWS(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
+func proxyWS(cfg config, logger mflog.Logger, handler mqtt.Handler, aliases auth.Aliases, errs chan error) {
 	target := fmt.Sprintf("%s:%s", cfg.httpTargetHost, cfg.httpTargetPort)
-	wp := ws.New(target, cfg.httpTargetPath, "ws", handler, logger)
+	wp := mqtt.NewWSProxy(target, cfg.httpTargetPath, "ws", handler, aliases, logger)
 	http.Handle("/mqtt", wp.Handler())
 
 	p := fmt.Sprintf(":%s", cfg.httpPort)
 	errs <- http.ListenAndServe(p, nil)
 }
+
+func subscribeToThingsES(cache, client *redis.Client, schemas auth.Schemas, consumer string, logger mflog.Logger) {
+	eventStore := auth.NewEventStore(cache, client, schemas, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(cacheGroup); err != nil {
+		logger.Warn(fmt.Sprintf("Things event store subscription failed: %s", err))
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/mainflux/mainflux/opcua/db"
 	"github.com/mainflux/mainflux/opcua/gopcua"
 	"github.com/mainflux/mainflux/opcua/redis"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -60,9 +61,12 @@ const (
 	envRouteMapPass   = "MF_OPCUA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_OPCUA_ADAPTER_ROUTE_MAP_DB"
 
+	protocol = "opcua"
+
 	thingsRMPrefix     = "thing"
 	channelsRMPrefix   = "channel"
 	connectionRMPrefix = "connection"
+	userAuthPrefix     = "auth"
 )
 
 type config struct {
@@ -93,6 +97,7 @@ func main() {
 	thingRM := newRouteMapRepositoy(rmConn, thingsRMPrefix, logger)
 	chanRM := newRouteMapRepositoy(rmConn, channelsRMPrefix, logger)
 	connRM := newRouteMapRepositoy(rmConn, connectionRMPrefix, logger)
+	authRepo := redis.NewUserAuthRepository(rmConn, userAuthPrefix)
 
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
@@ -105,10 +110,13 @@ func main() {
 	defer pubSub.Close()
 
 	ctx := context.Background()
-	sub := gopcua.NewSubscriber(ctx, pubSub, thingRM, chanRM, connRM, logger)
+	sessions := gopcua.NewSessions()
+	sub := gopcua.NewSubscriber(ctx, sessions, pubSub, thingRM, chanRM, connRM, logger)
 	browser := gopcua.NewBrowser(ctx, logger)
+	writer := gopcua.NewWriter(ctx, sessions, logger)
+	history := gopcua.NewHistoryReader(ctx, sessions, logger)
 
-	svc := opcua.New(sub, browser, thingRM, chanRM, connRM, cfg.opcuaConfig, logger)
+	svc := opcua.New(sub, browser, writer, history, pubSub, thingRM, chanRM, connRM, authRepo, cfg.opcuaConfig, logger)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -126,9 +134,14 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	go subscribeToStoredSubs(sub, cfg.opcuaConfig, logger)
+	go subscribeToStoredSubs(sub, chanRM, authRepo, cfg.opcuaConfig, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
+	if err := subscribeToNats(pubSub, svc, chanRM); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to NATS: %s", err))
+		os.Exit(1)
+	}
+
 	errs := make(chan error, 2)
 
 	go startHTTPServer(svc, cfg, logger, errs)
@@ -180,7 +193,7 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToStoredSubs(sub opcua.Subscriber, cfg opcua.Config, logger logger.Logger) {
+func subscribeToStoredSubs(sub opcua.Subscriber, chanRM opcua.RouteMapRepository, authRepo opcua.UserAuthRepository, cfg opcua.Config, logger logger.Logger) {
 	// Get all stored subscriptions
 	nodes, err := db.ReadAll()
 	if err != nil {
@@ -190,11 +203,18 @@ func subscribeToStoredSubs(sub opcua.Subscriber, cfg opcua.Config, logger logger
 	for _, n := range nodes {
 		cfg.ServerURI = n.ServerURI
 		cfg.NodeID = n.NodeID
-		go func() {
+		cfg.Auth = opcua.UserAuth{}
+		if chanID, err := chanRM.Get(n.ServerURI); err == nil {
+			if cfg.Auth, err = authRepo.Get(chanID); err != nil {
+				logger.Warn(fmt.Sprintf("Read user identity of channel %s failed: %s", chanID, err))
+			}
+		}
+
+		go func(cfg opcua.Config) {
 			if err := sub.Subscribe(cfg); err != nil {
 				logger.Warn(fmt.Sprintf("Subscription failed: %s", err))
 			}
-		}()
+		}(cfg)
 	}
 }
 
@@ -211,6 +231,21 @@ func subscribeToThingsES(svc opcua.Service, client *r.Client, prefix string, log
 	}
 }
 
+func subscribeToNats(ps messaging.Subscriber, svc opcua.Service, chanRM opcua.RouteMapRepository) error {
+	return ps.Subscribe(nats.SubjectAllChannels, func(msg messaging.Message) error {
+		// Skip messages published by the adapter itself, as well as
+		// messages on Channels which are not mapped to OPC-UA Servers.
+		if msg.Protocol == protocol {
+			return nil
+		}
+		if _, err := chanRM.Get(msg.Channel); err != nil {
+			return nil
+		}
+
+		return svc.Write(msg)
+	})
+}
+
 func newRouteMapRepositoy(client *r.Client, prefix string, logger logger.Logger) opcua.RouteMapRepository {
 	logger.Info(fmt.Sprintf("Connected to %s Redis Route-map", prefix))
 	return redis.NewRouteMapRepository(client, prefix)
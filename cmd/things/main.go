@@ -70,6 +70,7 @@ const (
 	defJaegerURL       = ""
 	defAuthnURL        = "localhost:8181"
 	defAuthnTimeout    = "1s"
+	defAdapterSecret   = ""
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -99,6 +100,7 @@ const (
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthnURL        = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout    = "MF_AUTHN_GRPC_TIMEOUT"
+	envAdapterSecret   = "MF_THINGS_ADAPTER_SECRET"
 )
 
 type config struct {
@@ -122,6 +124,7 @@ type config struct {
 	jaegerURL       string
 	authnURL        string
 	authnTimeout    time.Duration
+	adapterSecret   string
 }
 
 func main() {
@@ -217,6 +220,7 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authnURL:        mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout:    authnTimeout,
+		adapterSecret:   mainflux.Env(envAdapterSecret, defAdapterSecret),
 	}
 }
 
@@ -373,6 +377,6 @@ func startGRPCServer(svc things.Service, tracer opentracing.Tracer, cfg config,
 		server = grpc.NewServer()
 	}
 
-	mainflux.RegisterThingsServiceServer(server, authgrpcapi.NewServer(tracer, svc))
+	mainflux.RegisterThingsServiceServer(server, authgrpcapi.NewServer(tracer, svc, cfg.adapterSecret))
 	errs <- server.Serve(listener)
 }
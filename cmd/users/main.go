@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -12,12 +13,15 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/mainflux/mainflux/internal/email"
 	"github.com/mainflux/mainflux/users"
 	"github.com/mainflux/mainflux/users/emailer"
+	"github.com/mainflux/mainflux/users/oidc"
+	"github.com/mainflux/mainflux/users/totp"
 	"github.com/mainflux/mainflux/users/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -68,6 +72,16 @@ const (
 	defAuthnURL     = "localhost:8181"
 	defAuthnTimeout = "1s"
 
+	defOIDCIssuer       = ""
+	defOIDCClientID     = ""
+	defOIDCClientSecret = ""
+	defOIDCRedirectURL  = "http://localhost/oidc/callback"
+	defOIDCScopes       = "openid,email,profile"
+	defOIDCGroupsClaim  = "groups"
+	defOIDCTrustMFA     = "false"
+
+	defTOTPIssuer = "Mainflux"
+
 	envLogLevel      = "MF_USERS_LOG_LEVEL"
 	envDBHost        = "MF_USERS_DB_HOST"
 	envDBPort        = "MF_USERS_DB_PORT"
@@ -99,6 +113,18 @@ const (
 	envAuthnCACerts = "MF_AUTHN_CA_CERTS"
 	envAuthnURL     = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout = "MF_AUTHN_GRPC_TIMEOUT"
+
+	envOIDCIssuer       = "MF_USERS_OIDC_ISSUER"
+	envOIDCClientID     = "MF_USERS_OIDC_CLIENT_ID"
+	envOIDCClientSecret = "MF_USERS_OIDC_CLIENT_SECRET"
+	envOIDCRedirectURL  = "MF_USERS_OIDC_REDIRECT_URL"
+	envOIDCScopes       = "MF_USERS_OIDC_SCOPES"
+	envOIDCGroupsClaim  = "MF_USERS_OIDC_GROUPS_CLAIM"
+	envOIDCTrustMFA     = "MF_USERS_OIDC_TRUST_MFA"
+
+	envTOTPIssuer = "MF_USERS_TOTP_ISSUER"
+
+	oidcTimeout = 10 * time.Second
 )
 
 type config struct {
@@ -114,6 +140,8 @@ type config struct {
 	authnCACerts string
 	authnURL     string
 	authnTimeout time.Duration
+	oidcConf     oidc.Config
+	totpIssuer   string
 }
 
 func main() {
@@ -190,6 +218,21 @@ func loadConfig() config {
 		Template:    mainflux.Env(envEmailTemplate, defEmailTemplate),
 	}
 
+	trustMFA, err := strconv.ParseBool(mainflux.Env(envOIDCTrustMFA, defOIDCTrustMFA))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envOIDCTrustMFA)
+	}
+
+	oidcConf := oidc.Config{
+		Issuer:       mainflux.Env(envOIDCIssuer, defOIDCIssuer),
+		ClientID:     mainflux.Env(envOIDCClientID, defOIDCClientID),
+		ClientSecret: mainflux.Env(envOIDCClientSecret, defOIDCClientSecret),
+		RedirectURL:  mainflux.Env(envOIDCRedirectURL, defOIDCRedirectURL),
+		Scopes:       strings.Split(mainflux.Env(envOIDCScopes, defOIDCScopes), ","),
+		GroupsClaim:  mainflux.Env(envOIDCGroupsClaim, defOIDCGroupsClaim),
+		TrustMFA:     trustMFA,
+	}
+
 	return config{
 		logLevel:     mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:     dbConfig,
@@ -203,6 +246,8 @@ func loadConfig() config {
 		authnCACerts: mainflux.Env(envAuthnCACerts, defAuthnCACerts),
 		authnURL:     mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout: authnTimeout,
+		oidcConf:     oidcConf,
+		totpIssuer:   mainflux.Env(envTOTPIssuer, defTOTPIssuer),
 	}
 
 }
@@ -275,7 +320,8 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthNServi
 		logger.Error(fmt.Sprintf("Failed to configure e-mailing util: %s", err.Error()))
 	}
 
-	svc := users.New(repo, hasher, auth, emailer)
+	idp := newIdentityProvider(c.oidcConf, logger)
+	svc := users.New(repo, hasher, totp.New(c.totpIssuer), auth, emailer, idp)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -296,6 +342,24 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthNServi
 	return svc
 }
 
+func newIdentityProvider(cfg oidc.Config, logger logger.Logger) users.IdentityProvider {
+	if cfg.Issuer == "" {
+		logger.Info("OpenID Connect login is disabled")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcTimeout)
+	defer cancel()
+
+	idp, err := oidc.New(ctx, cfg, &http.Client{Timeout: oidcTimeout})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to configure OpenID Connect identity provider: %s", err))
+		os.Exit(1)
+	}
+
+	return idp
+}
+
 func startHTTPServer(tracer opentracing.Tracer, svc users.Service, port string, certFile string, keyFile string, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	if certFile != "" || keyFile != "" {
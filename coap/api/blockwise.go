@@ -0,0 +1,283 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	gocoap "github.com/dustin/go-coap"
+)
+
+// Block-wise transfer options and response codes (RFC 7959), which aren't
+// defined by the CoAP library.
+const (
+	block2 gocoap.OptionID = 23
+	block1 gocoap.OptionID = 27
+	size2  gocoap.OptionID = 28
+
+	continueCode            gocoap.COAPCode = 95  // 2.31
+	requestEntityIncomplete gocoap.COAPCode = 136 // 4.08
+)
+
+const (
+	// maxSZX is the size exponent of 1024 bytes blocks, the largest allowed.
+	maxSZX = 6
+	// maxBodySize is the maximum size of the payload transferred in blocks.
+	maxBodySize = 1 << 20
+	// transferLifetime is the time after which unfinished transfer is dropped.
+	// It matches EXCHANGE_LIFETIME from RFC 7252.
+	transferLifetime = 247 * time.Second
+	// maxTransfers is the maximum number of the block-wise uploads, as well
+	// as the downloads, in progress.
+	maxTransfers = 256
+)
+
+var (
+	errBadBlock         = errors.New("malformed block option")
+	errEntityIncomplete = errors.New("missing preceding blocks")
+	errEntityTooLarge   = errors.New("payload exceeds max body size")
+	errTooManyTransfers = errors.New("too many block-wise transfers in progress")
+)
+
+// block represents Block1 or Block2 option value.
+type block struct {
+	num  uint32
+	more bool
+	szx  uint32
+}
+
+func (b block) size() int {
+	return 1 << (b.szx + 4)
+}
+
+func (b block) value() uint32 {
+	v := b.num<<4 | b.szx
+	if b.more {
+		v |= 1 << 3
+	}
+	return v
+}
+
+// blockOption returns Block1 or Block2 option of the message, if present.
+func blockOption(msg *gocoap.Message, id gocoap.OptionID) (block, bool, error) {
+	v, ok := msg.Option(id).(uint32)
+	if !ok {
+		return block{}, false, nil
+	}
+
+	b := block{
+		num:  v >> 4,
+		more: v&(1<<3) != 0,
+		szx:  v & 0x7,
+	}
+	if b.szx > maxSZX {
+		return block{}, true, errBadBlock
+	}
+
+	return b, true, nil
+}
+
+// parseMessage parses CoAP message keeping the block-wise transfer options,
+// which are dropped by the CoAP library since it doesn't recognize them.
+func parseMessage(data []byte) (gocoap.Message, error) {
+	msg, err := gocoap.ParseMessage(data)
+	if err != nil {
+		return msg, err
+	}
+
+	b := data[4+int(data[0]&0xf):]
+	prev := 0
+	for len(b) > 0 && b[0] != 0xff {
+		delta, length := int(b[0]>>4), int(b[0]&0xf)
+		b = b[1:]
+
+		var ok bool
+		if delta, b, ok = extendedOption(delta, b); !ok {
+			break
+		}
+		if length, b, ok = extendedOption(length, b); !ok || len(b) < length {
+			break
+		}
+
+		id := gocoap.OptionID(prev + delta)
+		prev = int(id)
+		switch id {
+		case block1, block2, size2:
+			if length <= 4 {
+				msg.SetOption(id, decodeUint(b[:length]))
+			}
+		}
+		b = b[length:]
+	}
+
+	return msg, nil
+}
+
+// extendedOption returns option delta or length, reading the extended value
+// from the given buffer if needed.
+func extendedOption(v int, b []byte) (int, []byte, bool) {
+	switch v {
+	case 13:
+		if len(b) < 1 {
+			return 0, nil, false
+		}
+		return int(b[0]) + 13, b[1:], true
+	case 14:
+		if len(b) < 2 {
+			return 0, nil, false
+		}
+		return int(binary.BigEndian.Uint16(b)) + 269, b[2:], true
+	case 15:
+		return 0, nil, false
+	default:
+		return v, b, true
+	}
+}
+
+func decodeUint(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+type transfer struct {
+	id      string
+	payload []byte
+	expires time.Time
+}
+
+// transfers contains payloads of the block-wise transfers in progress.
+type transfers struct {
+	mu  sync.Mutex
+	max int
+	// order contains the transfers ordered by expiration time. Transfers
+	// have the same lifetime, so it's the order of the last update, and
+	// the expired transfers are always at the front.
+	order *list.List
+	items map[string]*list.Element
+}
+
+// newTransfers returns transfers with at most max transfers in progress.
+func newTransfers(max int) *transfers {
+	return &transfers{
+		max:   max,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// upload appends the received block to the payload of the transfer with the
+// given ID, and returns the whole payload once the last block is received.
+// New transfers are rejected while the max number of transfers is in
+// progress.
+func (ts *transfers) upload(id string, b block, data []byte) ([]byte, bool, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.expire()
+
+	if b.more && len(data) != b.size() {
+		return nil, false, errBadBlock
+	}
+
+	var payload []byte
+	if b.num > 0 {
+		e, ok := ts.items[id]
+		if !ok || len(e.Value.(*transfer).payload) != int(b.num)*b.size() {
+			return nil, false, errEntityIncomplete
+		}
+		payload = e.Value.(*transfer).payload
+	}
+
+	if len(payload)+len(data) > maxBodySize {
+		ts.remove(id)
+		return nil, false, errEntityTooLarge
+	}
+	payload = append(payload, data...)
+
+	if !b.more {
+		ts.remove(id)
+		return payload, true, nil
+	}
+
+	if _, ok := ts.items[id]; !ok && len(ts.items) >= ts.max {
+		return nil, false, errTooManyTransfers
+	}
+	ts.put(id, payload)
+
+	return nil, false, nil
+}
+
+// store saves the payload to be retrieved in blocks by the transfer ID. If
+// the max number of transfers is in progress, the oldest one is dropped.
+func (ts *transfers) store(id string, payload []byte) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.expire()
+	if _, ok := ts.items[id]; !ok && len(ts.items) >= ts.max {
+		ts.remove(ts.order.Front().Value.(*transfer).id)
+	}
+	ts.put(id, payload)
+}
+
+// load returns the payload stored by the transfer ID.
+func (ts *transfers) load(id string) ([]byte, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	e, ok := ts.items[id]
+	if !ok {
+		return nil, false
+	}
+	t := e.Value.(*transfer)
+	if time.Now().After(t.expires) {
+		return nil, false
+	}
+
+	return t.payload, true
+}
+
+// put saves the payload of the transfer and renews its lifetime.
+func (ts *transfers) put(id string, payload []byte) {
+	t := &transfer{
+		id:      id,
+		payload: payload,
+		expires: time.Now().Add(transferLifetime),
+	}
+
+	if e, ok := ts.items[id]; ok {
+		e.Value = t
+		ts.order.MoveToBack(e)
+		return
+	}
+	ts.items[id] = ts.order.PushBack(t)
+}
+
+func (ts *transfers) remove(id string) {
+	if e, ok := ts.items[id]; ok {
+		ts.order.Remove(e)
+		delete(ts.items, id)
+	}
+}
+
+// expire drops the expired transfers. Only the expired transfers are
+// visited, since they're at the front of the order.
+func (ts *transfers) expire() {
+	now := time.Now()
+	for e := ts.order.Front(); e != nil; e = ts.order.Front() {
+		t := e.Value.(*transfer)
+		if !now.After(t.expires) {
+			return
+		}
+		ts.order.Remove(e)
+		delete(ts.items, t.id)
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	gocoap "github.com/dustin/go-coap"
+	"github.com/stretchr/testify/assert"
+)
+
+// header is the header of the confirmable POST message with ID 1 and without
+// the token.
+var header = []byte{0x40, 0x02, 0x00, 0x01}
+
+func message(parts ...[]byte) []byte {
+	return bytes.Join(append([][]byte{header}, parts...), nil)
+}
+
+func TestParseMessage(t *testing.T) {
+	cases := []struct {
+		desc    string
+		data    []byte
+		options map[gocoap.OptionID]interface{}
+		path    string
+		payload string
+		err     bool
+	}{
+		{
+			desc:    "message with block1 option",
+			data:    message([]byte{0xd1, 0x0e, 0x0e, 0xff}, []byte("data")),
+			options: map[gocoap.OptionID]interface{}{block1: uint32(0x0e)},
+			payload: "data",
+		},
+		{
+			desc: "message with path and two bytes block1 option",
+			data: message(
+				[]byte{0xb8}, []byte("channels"),
+				[]byte{0xd2, 0x03, 0x01, 0x2e},
+			),
+			options: map[gocoap.OptionID]interface{}{block1: uint32(0x12e)},
+			path:    "channels",
+		},
+		{
+			desc:    "message with block2 and size2 options",
+			data:    message([]byte{0xd1, 0x0a, 0x26, 0x52, 0x08, 0x00}),
+			options: map[gocoap.OptionID]interface{}{block2: uint32(0x26), size2: uint32(2048)},
+		},
+		{
+			desc:    "message with empty block1 option",
+			data:    message([]byte{0xd0, 0x0e}),
+			options: map[gocoap.OptionID]interface{}{block1: uint32(0)},
+		},
+		{
+			desc:    "message with too long block1 option",
+			data:    message([]byte{0xd5, 0x0e, 0x01, 0x02, 0x03, 0x04, 0x05}),
+			options: map[gocoap.OptionID]interface{}{block1: nil},
+		},
+		{
+			desc:    "message without options",
+			data:    message([]byte{0xff}, []byte("data")),
+			options: map[gocoap.OptionID]interface{}{block1: nil, block2: nil, size2: nil},
+			payload: "data",
+		},
+		{
+			desc: "truncated message",
+			data: header[:3],
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		msg, err := parseMessage(tc.data)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error: %v\n", tc.desc, err))
+		if tc.err {
+			continue
+		}
+		for id, v := range tc.options {
+			assert.Equal(t, v, msg.Option(id), fmt.Sprintf("%s: expected option %d to be %v got %v\n", tc.desc, id, v, msg.Option(id)))
+		}
+		assert.Equal(t, tc.path, msg.PathString(), fmt.Sprintf("%s: expected path %s got %s\n", tc.desc, tc.path, msg.PathString()))
+		assert.Equal(t, tc.payload, string(msg.Payload), fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, msg.Payload))
+	}
+}
+
+func TestExtendedOption(t *testing.T) {
+	cases := []struct {
+		desc  string
+		v     int
+		b     []byte
+		ret   int
+		rest  []byte
+		valid bool
+	}{
+		{
+			desc:  "value without extension",
+			v:     12,
+			b:     []byte{0x01},
+			ret:   12,
+			rest:  []byte{0x01},
+			valid: true,
+		},
+		{
+			desc:  "one byte extension",
+			v:     13,
+			b:     []byte{0x0e, 0x01},
+			ret:   27,
+			rest:  []byte{0x01},
+			valid: true,
+		},
+		{
+			desc:  "max one byte extension",
+			v:     13,
+			b:     []byte{0xff},
+			ret:   268,
+			rest:  []byte{},
+			valid: true,
+		},
+		{
+			desc:  "two bytes extension",
+			v:     14,
+			b:     []byte{0x01, 0x00, 0x01},
+			ret:   525,
+			rest:  []byte{0x01},
+			valid: true,
+		},
+		{
+			desc:  "missing one byte extension",
+			v:     13,
+			b:     []byte{},
+			valid: false,
+		},
+		{
+			desc:  "truncated two bytes extension",
+			v:     14,
+			b:     []byte{0x01},
+			valid: false,
+		},
+		{
+			desc:  "reserved value",
+			v:     15,
+			b:     []byte{0x01},
+			valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		ret, rest, valid := extendedOption(tc.v, tc.b)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected valid %t got %t\n", tc.desc, tc.valid, valid))
+		if !tc.valid {
+			continue
+		}
+		assert.Equal(t, tc.ret, ret, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.ret, ret))
+		assert.Equal(t, tc.rest, rest, fmt.Sprintf("%s: expected rest %v got %v\n", tc.desc, tc.rest, rest))
+	}
+}
+
+func TestUpload(t *testing.T) {
+	const id = "127.0.0.1:5683/channels/1/messages"
+	// Blocks of 16 bytes.
+	first := bytes.Repeat([]byte{'a'}, 16)
+	second := bytes.Repeat([]byte{'b'}, 16)
+	last := []byte("c")
+
+	ts := newTransfers(2)
+
+	cases := []struct {
+		desc     string
+		id       string
+		block    block
+		data     []byte
+		payload  []byte
+		complete bool
+		err      error
+	}{
+		{
+			desc:  "upload first block",
+			id:    id,
+			block: block{num: 0, more: true, szx: 0},
+			data:  first,
+		},
+		{
+			desc:  "upload second block",
+			id:    id,
+			block: block{num: 1, more: true, szx: 0},
+			data:  second,
+		},
+		{
+			desc:  "upload block of wrong size",
+			id:    id,
+			block: block{num: 2, more: true, szx: 0},
+			data:  last,
+			err:   errBadBlock,
+		},
+		{
+			desc:  "upload block out of order",
+			id:    id,
+			block: block{num: 3, more: false, szx: 0},
+			data:  last,
+			err:   errEntityIncomplete,
+		},
+		{
+			desc:     "upload last block",
+			id:       id,
+			block:    block{num: 2, more: false, szx: 0},
+			data:     last,
+			payload:  append(append(append([]byte{}, first...), second...), last...),
+			complete: true,
+		},
+		{
+			desc:  "upload block of finished transfer",
+			id:    id,
+			block: block{num: 3, more: false, szx: 0},
+			data:  last,
+			err:   errEntityIncomplete,
+		},
+		{
+			desc:     "upload single block",
+			id:       "single",
+			block:    block{num: 0, more: false, szx: 0},
+			data:     last,
+			payload:  last,
+			complete: true,
+		},
+		{
+			desc:  "upload first block of transfer 1",
+			id:    "1",
+			block: block{num: 0, more: true, szx: 0},
+			data:  first,
+		},
+		{
+			desc:  "upload first block of transfer 2",
+			id:    "2",
+			block: block{num: 0, more: true, szx: 0},
+			data:  first,
+		},
+		{
+			desc:  "upload first block of transfer over the limit",
+			id:    "3",
+			block: block{num: 0, more: true, szx: 0},
+			data:  first,
+			err:   errTooManyTransfers,
+		},
+		{
+			desc:     "upload single block over the limit",
+			id:       "3",
+			block:    block{num: 0, more: false, szx: 0},
+			data:     last,
+			payload:  last,
+			complete: true,
+		},
+		{
+			desc:  "restart transfer at the limit",
+			id:    "1",
+			block: block{num: 0, more: true, szx: 0},
+			data:  second,
+		},
+		{
+			desc:     "finish transfer at the limit",
+			id:       "1",
+			block:    block{num: 1, more: false, szx: 0},
+			data:     last,
+			payload:  append(append([]byte{}, second...), last...),
+			complete: true,
+		},
+		{
+			desc:  "upload first block of transfer after the other is finished",
+			id:    "3",
+			block: block{num: 0, more: true, szx: 0},
+			data:  first,
+		},
+		{
+			desc:  "upload block exceeding max body size",
+			id:    "3",
+			block: block{num: 1, more: false, szx: 0},
+			data:  make([]byte, maxBodySize),
+			err:   errEntityTooLarge,
+		},
+	}
+
+	for _, tc := range cases {
+		payload, complete, err := ts.upload(tc.id, tc.block, tc.data)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.complete, complete, fmt.Sprintf("%s: expected complete %t got %t\n", tc.desc, tc.complete, complete))
+		assert.Equal(t, tc.payload, payload, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, payload))
+	}
+}
+
+func TestStore(t *testing.T) {
+	ts := newTransfers(2)
+	for _, id := range []string{"1", "2", "3"} {
+		ts.store(id, []byte(id))
+	}
+
+	cases := []struct {
+		desc    string
+		id      string
+		payload []byte
+		ok      bool
+	}{
+		{
+			desc: "load oldest payload over the limit",
+			id:   "1",
+			ok:   false,
+		},
+		{
+			desc:    "load stored payload",
+			id:      "2",
+			payload: []byte("2"),
+			ok:      true,
+		},
+		{
+			desc:    "load last stored payload",
+			id:      "3",
+			payload: []byte("3"),
+			ok:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		payload, ok := ts.load(tc.id)
+		assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected ok %t got %t\n", tc.desc, tc.ok, ok))
+		assert.Equal(t, tc.payload, payload, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, payload))
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	gocoap "github.com/dustin/go-coap"
+	"github.com/mainflux/mainflux"
+	"github.com/pion/dtls/v2"
+)
+
+const maxPktLen = 1500
+
+// pskCipherSuites are used if no cipher suites are configured, since the
+// default ones don't include PSK cipher suites.
+var pskCipherSuites = []dtls.CipherSuiteID{
+	dtls.TLS_PSK_WITH_AES_128_CCM_8,
+	dtls.TLS_PSK_WITH_AES_128_CCM,
+	dtls.TLS_PSK_WITH_AES_128_GCM_SHA256,
+}
+
+var (
+	errMissingCert    = errors.New("missing client certificate")
+	errMissingThingID = errors.New("client certificate doesn't contain thing ID")
+)
+
+// ServeDTLS serves CoAP messages received over DTLS connections accepted by
+// the given listener. If the config contains no certificates, things are
+// authenticated using pre-shared keys, where PSK identity is the thing ID and
+// PSK is the one provisioned for the thing. Otherwise, things are
+// authenticated using client certificates whose Common Name is the thing ID.
+func (h *Handler) ServeDTLS(l net.Listener, cfg dtls.Config) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go h.serveDTLS(conn, cfg)
+	}
+}
+
+func (h *Handler) serveDTLS(raw net.Conn, cfg dtls.Config) {
+	var thingID string
+	if len(cfg.Certificates) == 0 {
+		if len(cfg.CipherSuites) == 0 {
+			cfg.CipherSuites = pskCipherSuites
+		}
+		cfg.PSK = func(identity []byte) ([]byte, error) {
+			psk, err := h.retrievePSK(string(identity))
+			if err != nil {
+				return nil, err
+			}
+			thingID = string(identity)
+			return []byte(psk), nil
+		}
+	}
+
+	conn, err := dtls.Server(raw, &cfg)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("Failed to establish DTLS connection with %s: %s", raw.RemoteAddr(), err))
+		raw.Close()
+		return
+	}
+	defer conn.Close()
+
+	if thingID == "" {
+		if thingID, err = certThingID(conn.ConnectionState().PeerCertificates); err != nil {
+			h.logger.Warn(fmt.Sprintf("Failed to authenticate %s: %s", raw.RemoteAddr(), err))
+			return
+		}
+	}
+
+	c := client{
+		addr:    raw.RemoteAddr().String(),
+		thingID: thingID,
+		tx: func(m gocoap.Message) error {
+			data, err := m.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			_, err = conn.Write(data)
+			return err
+		},
+	}
+
+	buf := make([]byte, maxPktLen)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+
+		msg, err := parseMessage(buf[:n])
+		if err != nil {
+			h.logger.Warn(fmt.Sprintf("Failed to parse message: %s", err))
+			continue
+		}
+
+		res := h.mux(c, &msg)
+		if res == nil {
+			continue
+		}
+		if err := c.tx(*res); err != nil {
+			h.logger.Warn(fmt.Sprintf("Failed to send response: %s", err))
+		}
+	}
+}
+
+func (h *Handler) retrievePSK(id string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	psk, err := h.auth.RetrievePSK(ctx, &mainflux.ThingID{Value: id})
+	if err != nil {
+		return "", err
+	}
+
+	return psk.GetValue(), nil
+}
+
+// certThingID returns ID of the thing the client certificate is issued to.
+// The certificate carries no secret, since DTLS 1.2 sends it in clear.
+func certThingID(certs [][]byte) (string, error) {
+	if len(certs) == 0 {
+		return "", errMissingCert
+	}
+
+	cert, err := x509.ParseCertificate(certs[0])
+	if err != nil {
+		return "", err
+	}
+
+	if cert.Subject.CommonName == "" {
+		return "", errMissingThingID
+	}
+
+	return cert.Subject.CommonName, nil
+}
@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -21,7 +20,11 @@ import (
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/coap"
 	log "github.com/mainflux/mainflux/logger"
+	mfauth "github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"github.com/mainflux/mainflux/things"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,22 +34,45 @@ const (
 	protocol                   = "coap"
 	senMLJSON gocoap.MediaType = 110
 	senMLCBOR gocoap.MediaType = 112
+
+	// tooManyRequests is 4.29 response code, defined by RFC 8516.
+	tooManyRequests gocoap.COAPCode = 157
 )
 
 var (
 	errBadRequest        = errors.New("bad request")
 	errBadOption         = errors.New("bad option")
 	errMalformedSubtopic = errors.New("malformed subtopic")
+	errReservedSubtopic  = errors.New("publish to reserved subtopic")
 	channelRegExp        = regexp.MustCompile(`^/?channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 )
 
-var (
+// transmitter sends CoAP message to the client.
+type transmitter func(msg gocoap.Message) error
+
+// client contains transport details of the client which sent the message.
+type client struct {
+	// addr is the client address, used to match blocks of the same transfer.
+	addr string
+	// thingID is the thing ID, if the client is authenticated by the transport.
+	thingID string
+	tx      transmitter
+}
+
+var _ gocoap.Handler = (*Handler)(nil)
+
+// Handler handles CoAP messages received over UDP and DTLS.
+type Handler struct {
+	svc        coap.Service
 	auth       mainflux.ThingsServiceClient
+	limiter    mfauth.Limiter
+	schemas    mfauth.Schemas
 	logger     log.Logger
+	responses  chan<- string
 	pingPeriod time.Duration
-)
-
-type handler func(conn *net.UDPConn, addr *net.UDPAddr, msg *gocoap.Message) *gocoap.Message
+	uploads    *transfers
+	downloads  *transfers
+}
 
 //MakeHTTPHandler creates handler for version endpoint.
 func MakeHTTPHandler() http.Handler {
@@ -58,36 +84,97 @@ func MakeHTTPHandler() http.Handler {
 }
 
 // MakeCOAPHandler creates handler for CoAP messages.
-func MakeCOAPHandler(svc coap.Service, tc mainflux.ThingsServiceClient, l log.Logger, responses chan<- string, pp time.Duration) gocoap.Handler {
-	auth = tc
-	logger = l
-	pingPeriod = pp
-	return mux(svc, responses)
+func MakeCOAPHandler(svc coap.Service, tc mainflux.ThingsServiceClient, lim mfauth.Limiter, sch mfauth.Schemas, l log.Logger, responses chan<- string, pp time.Duration) *Handler {
+	return &Handler{
+		svc:        svc,
+		auth:       tc,
+		limiter:    lim,
+		schemas:    sch,
+		logger:     l,
+		responses:  responses,
+		pingPeriod: pp,
+		uploads:    newTransfers(maxTransfers),
+		downloads:  newTransfers(maxTransfers),
+	}
 }
 
-func mux(svc coap.Service, responses chan<- string) gocoap.Handler {
-	return gocoap.FuncHandler(func(conn *net.UDPConn, addr *net.UDPAddr, msg *gocoap.Message) *gocoap.Message {
-		path := msg.PathString()
-		if !channelRegExp.Match([]byte(path)) {
-			logger.Info(fmt.Sprintf("path %s not found", path))
-			return &gocoap.Message{
-				Type:      gocoap.NonConfirmable,
-				Code:      gocoap.NotFound,
-				MessageID: msg.MessageID,
-				Token:     msg.Token,
+// ServeCOAP handles CoAP message received over UDP from the given address.
+func (h *Handler) ServeCOAP(conn *net.UDPConn, addr *net.UDPAddr, msg *gocoap.Message) *gocoap.Message {
+	c := client{
+		addr: addr.String(),
+		tx: func(m gocoap.Message) error {
+			return gocoap.Transmit(conn, addr, m)
+		},
+	}
+	return h.mux(c, msg)
+}
+
+// ListenAndServe serves CoAP messages received over UDP on the given address.
+// Unlike the CoAP library server, it keeps block-wise transfer options of the
+// received messages.
+func (h *Handler) ListenAndServe(addr string) error {
+	uaddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenUDP("udp", uaddr)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, maxPktLen)
+	for {
+		n, raddr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if neterr, ok := err.(net.Error); ok && (neterr.Temporary() || neterr.Timeout()) {
+				time.Sleep(5 * time.Millisecond)
+				continue
 			}
+			return err
 		}
-		// Allow "/" to be a part of the path.
-		if strings.HasPrefix(path, "/") {
-			msg.SetPathString(path[1:])
+
+		data := make([]byte, n)
+		copy(data, buf)
+		go h.serveUDP(conn, raddr, data)
+	}
+}
+
+func (h *Handler) serveUDP(conn *net.UDPConn, addr *net.UDPAddr, data []byte) {
+	msg, err := parseMessage(data)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("Failed to parse message: %s", err))
+		return
+	}
+
+	if res := h.ServeCOAP(conn, addr, &msg); res != nil {
+		if err := gocoap.Transmit(conn, addr, *res); err != nil {
+			h.logger.Warn(fmt.Sprintf("Failed to send response: %s", err))
 		}
-		switch msg.Code {
-		case gocoap.GET:
-			return observe(svc, responses)(conn, addr, msg)
-		default:
-			return receive(svc, msg)
+	}
+}
+
+func (h *Handler) mux(c client, msg *gocoap.Message) *gocoap.Message {
+	path := msg.PathString()
+	if !channelRegExp.Match([]byte(path)) {
+		h.logger.Info(fmt.Sprintf("path %s not found", path))
+		return &gocoap.Message{
+			Type:      gocoap.NonConfirmable,
+			Code:      gocoap.NotFound,
+			MessageID: msg.MessageID,
+			Token:     msg.Token,
 		}
-	})
+	}
+	// Allow "/" to be a part of the path.
+	if strings.HasPrefix(path, "/") {
+		msg.SetPathString(path[1:])
+	}
+	switch msg.Code {
+	case gocoap.GET:
+		return h.observe(c, msg)
+	default:
+		return h.receive(c, msg)
+	}
 }
 
 func id(msg *gocoap.Message) string {
@@ -112,48 +199,71 @@ func subtopic(msg *gocoap.Message) string {
 	return ""
 }
 
-func authorize(msg *gocoap.Message, res *gocoap.Message, cid string) (string, error) {
-	// Device Key is passed as Uri-Query parameter, which option ID is 15 (0xf).
+// authorize returns ID of the thing which sent the message, if it's allowed
+// to access the channel. Things authenticated by the transport are identified
+// by the client, and the others by the key sent in the message.
+func (h *Handler) authorize(c client, msg *gocoap.Message, res *gocoap.Message, cid, access string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if c.thingID != "" {
+		req := &mainflux.AccessByIDReq{ThingID: c.thingID, ChanID: cid, Access: access}
+		if _, err := h.auth.CanAccessByID(ctx, req); err != nil {
+			authError(res, err)
+			return "", err
+		}
+		return c.thingID, nil
+	}
+
+	key, err := queryKey(msg)
+	if err != nil {
+		res.Code = gocoap.BadRequest
+		return "", err
+	}
+
+	id, err := h.auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: cid, Access: access})
+	if err != nil {
+		authError(res, err)
+		return "", err
+	}
+
+	return id.GetValue(), nil
+}
+
+func authError(res *gocoap.Message, err error) {
+	e, ok := status.FromError(err)
+	if !ok {
+		res.Code = gocoap.InternalServerError
+		return
+	}
+
+	switch e.Code() {
+	case codes.PermissionDenied:
+		res.Code = gocoap.Forbidden
+	default:
+		res.Code = gocoap.ServiceUnavailable
+	}
+}
+
+// queryKey returns the device key passed as Uri-Query parameter, which option ID is 15 (0xf).
+func queryKey(msg *gocoap.Message) (string, error) {
 	query := msg.Option(gocoap.URIQuery)
 	queryStr, ok := query.(string)
 	if !ok {
-		res.Code = gocoap.BadRequest
 		return "", errBadRequest
 	}
 
 	params, err := url.ParseQuery(queryStr)
 	if err != nil {
-		res.Code = gocoap.BadRequest
 		return "", errBadRequest
 	}
 
 	auths, ok := params["authorization"]
 	if !ok || len(auths) != 1 {
-		res.Code = gocoap.BadRequest
 		return "", errBadRequest
 	}
 
-	key := auths[0]
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	id, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: cid})
-	if err != nil {
-		e, ok := status.FromError(err)
-		if ok {
-			switch e.Code() {
-			case codes.PermissionDenied:
-				res.Code = gocoap.Forbidden
-			default:
-				res.Code = gocoap.ServiceUnavailable
-			}
-			return "", err
-		}
-		res.Code = gocoap.InternalServerError
-	}
-
-	return id.GetValue(), nil
+	return auths[0], nil
 }
 
 func fmtSubtopic(msg *gocoap.Message) (string, error) {
@@ -183,7 +293,7 @@ func fmtSubtopic(msg *gocoap.Message) (string, error) {
 	return subtopic, nil
 }
 
-func receive(svc coap.Service, msg *gocoap.Message) *gocoap.Message {
+func (h *Handler) receive(c client, msg *gocoap.Message) *gocoap.Message {
 	// By default message is NonConfirmable, so
 	// NonConfirmable response is sent back.
 	res := &gocoap.Message{
@@ -217,13 +327,49 @@ func receive(svc coap.Service, msg *gocoap.Message) *gocoap.Message {
 		res.Code = gocoap.BadRequest
 		return res
 	}
+	if messaging.Reserved(subtopic) {
+		res.Code = gocoap.Forbidden
+		return res
+	}
 
-	publisher, err := authorize(msg, res, chanID)
+	publisher, err := h.authorize(c, msg, res, chanID, things.PublishAccess)
 	if err != nil {
 		res.Code = gocoap.Forbidden
 		return res
 	}
 
+	b, ok, err := blockOption(msg, block1)
+	if err != nil {
+		res.Code = gocoap.BadOption
+		return res
+	}
+	if ok {
+		payload, complete, err := h.upload(c, msg, b, res)
+		if err != nil || !complete {
+			return res
+		}
+		msg.Payload = payload
+	}
+
+	// Block-wise upload is limited as a single message, once it's complete.
+	if err := h.limiter.Allow(publisher); err != nil {
+		res.Code = tooManyRequests
+		return res
+	}
+
+	if err := h.schemas.Validate(chanID, msg.Payload); err != nil {
+		if !errors.Contains(err, schema.ErrInvalidPayload) {
+			res.Code = gocoap.ServiceUnavailable
+			return res
+		}
+		// Rejection reason is sent as the diagnostic payload, which has
+		// no content format, as defined by RFC 7252.
+		res.Code = gocoap.BadRequest
+		res.RemoveOption(gocoap.ContentFormat)
+		res.Payload = []byte(err.Error())
+		return res
+	}
+
 	m := messaging.Message{
 		Channel:   chanID,
 		Subtopic:  subtopic,
@@ -233,70 +379,82 @@ func receive(svc coap.Service, msg *gocoap.Message) *gocoap.Message {
 		Created:   time.Now().UnixNano(),
 	}
 
-	if err := svc.Publish(m); err != nil {
+	if err := h.svc.Publish(m); err != nil {
 		res.Code = gocoap.InternalServerError
 	}
 
 	return res
 }
 
-func observe(svc coap.Service, responses chan<- string) handler {
-	return func(conn *net.UDPConn, addr *net.UDPAddr, msg *gocoap.Message) *gocoap.Message {
-		res := &gocoap.Message{
-			Type:      gocoap.Acknowledgement,
-			Code:      gocoap.Content,
-			MessageID: msg.MessageID,
-			Token:     msg.Token,
-			Payload:   []byte{},
-		}
-		res.SetOption(gocoap.ContentFormat, gocoap.AppJSON)
+func (h *Handler) observe(c client, msg *gocoap.Message) *gocoap.Message {
+	res := &gocoap.Message{
+		Type:      gocoap.Acknowledgement,
+		Code:      gocoap.Content,
+		MessageID: msg.MessageID,
+		Token:     msg.Token,
+		Payload:   []byte{},
+	}
+	res.SetOption(gocoap.ContentFormat, gocoap.AppJSON)
 
-		chanID := id(msg)
-		if chanID == "" {
-			res.Code = gocoap.NotFound
-			return res
-		}
+	chanID := id(msg)
+	if chanID == "" {
+		res.Code = gocoap.NotFound
+		return res
+	}
 
-		subtopic, err := fmtSubtopic(msg)
-		if err != nil {
-			res.Code = gocoap.BadRequest
-			return res
-		}
+	subtopic, err := fmtSubtopic(msg)
+	if err != nil {
+		res.Code = gocoap.BadRequest
+		return res
+	}
 
-		publisher, err := authorize(msg, res, chanID)
-		if err != nil {
-			res.Code = gocoap.Forbidden
-			logger.Warn(fmt.Sprintf("Failed to authorize: %s", err))
-			return res
-		}
+	publisher, err := h.authorize(c, msg, res, chanID, things.SubscribeAccess)
+	if err != nil {
+		res.Code = gocoap.Forbidden
+		h.logger.Warn(fmt.Sprintf("Failed to authorize: %s", err))
+		return res
+	}
 
-		obsID := fmt.Sprintf("%x-%s-%s", msg.Token, publisher, chanID)
+	b, ok, err := blockOption(msg, block2)
+	if err != nil {
+		res.Code = gocoap.BadOption
+		return res
+	}
+	// Blocks of the notification are retrieved by requests without Observe option.
+	if _, observing := msg.Option(gocoap.Observe).(uint32); ok && !observing && msg.Type != gocoap.Acknowledgement {
+		return h.download(c, msg, b, res)
+	}
+	szx := uint32(maxSZX)
+	if ok && b.szx < szx {
+		szx = b.szx
+	}
 
-		if msg.Type == gocoap.Acknowledgement {
-			responses <- obsID
-			return nil
-		}
+	obsID := fmt.Sprintf("%x-%s-%s", msg.Token, publisher, chanID)
 
-		if value, ok := msg.Option(gocoap.Observe).(uint32); (ok && value == 1) || msg.Type == gocoap.Reset {
-			svc.Unsubscribe(obsID)
-		}
+	if msg.Type == gocoap.Acknowledgement {
+		h.responses <- obsID
+		return nil
+	}
 
-		if value, ok := msg.Option(gocoap.Observe).(uint32); ok && value == 0 {
-			res.AddOption(gocoap.Observe, 1)
-			o := coap.NewObserver()
-			if err := svc.Subscribe(chanID, subtopic, obsID, o); err != nil {
-				logger.Warn(err.Error())
-				res.Code = gocoap.InternalServerError
-				return res
-			}
+	if value, ok := msg.Option(gocoap.Observe).(uint32); (ok && value == 1) || msg.Type == gocoap.Reset {
+		h.svc.Unsubscribe(obsID)
+	}
 
-			go handleMessage(conn, addr, o, msg)
-			go ping(svc, obsID, conn, addr, o, msg)
-			go cancel(o)
+	if value, ok := msg.Option(gocoap.Observe).(uint32); ok && value == 0 {
+		res.AddOption(gocoap.Observe, 1)
+		o := coap.NewObserver()
+		if err := h.svc.Subscribe(chanID, subtopic, obsID, o); err != nil {
+			h.logger.Warn(err.Error())
+			res.Code = gocoap.InternalServerError
+			return res
 		}
 
-		return res
+		go h.handleMessage(c, o, msg, szx)
+		go h.ping(obsID, c.tx, o, msg)
+		go cancel(o)
 	}
+
+	return res
 }
 
 func cancel(observer *coap.Observer) {
@@ -305,7 +463,7 @@ func cancel(observer *coap.Observer) {
 	observer.StoreExpired(true)
 }
 
-func handleMessage(conn *net.UDPConn, addr *net.UDPAddr, o *coap.Observer, msg *gocoap.Message) {
+func (h *Handler) handleMessage(c client, o *coap.Observer, msg *gocoap.Message, szx uint32) {
 	notifyMsg := *msg
 	notifyMsg.Type = gocoap.NonConfirmable
 	notifyMsg.Code = gocoap.Content
@@ -317,31 +475,104 @@ func handleMessage(conn *net.UDPConn, addr *net.UDPAddr, o *coap.Observer, msg *
 		}
 
 		notifyMsg.Payload = msg.Payload
+		notifyMsg.RemoveOption(block2)
+		notifyMsg.RemoveOption(size2)
+		// Notification which doesn't fit in a block is sent as the first block,
+		// and the client retrieves the rest of it using GET requests.
+		if b := (block{szx: szx}); len(msg.Payload) > b.size() {
+			b.more = true
+			h.downloads.store(c.addr+notifyMsg.PathString(), msg.Payload)
+			notifyMsg.Payload = msg.Payload[:b.size()]
+			notifyMsg.SetOption(block2, b.value())
+			notifyMsg.SetOption(size2, uint32(len(msg.Payload)))
+		}
 		notifyMsg.MessageID = o.LoadMessageID()
 		buff := new(bytes.Buffer)
 		observe := uint64(notifyMsg.MessageID)
 		if err := binary.Write(buff, binary.BigEndian, observe); err != nil {
-			logger.Warn(fmt.Sprintf("Failed to generate Observe option value: %s", err))
+			h.logger.Warn(fmt.Sprintf("Failed to generate Observe option value: %s", err))
 			continue
 		}
 
 		observeVal := buff.Bytes()
 		notifyMsg.SetOption(gocoap.Observe, observeVal[len(observeVal)-3:])
 
-		if err := gocoap.Transmit(conn, addr, notifyMsg); err != nil {
-			logger.Warn(fmt.Sprintf("Failed to send message to observer: %s", err))
+		if err := c.tx(notifyMsg); err != nil {
+			h.logger.Warn(fmt.Sprintf("Failed to send message to observer: %s", err))
 		}
 	}
 }
 
-func ping(svc coap.Service, obsID string, conn *net.UDPConn, addr *net.UDPAddr, o *coap.Observer, msg *gocoap.Message) {
+// upload handles the request block and returns the whole request payload once
+// the last block is received. Otherwise, the response to the block is set.
+func (h *Handler) upload(c client, msg *gocoap.Message, b block, res *gocoap.Message) ([]byte, bool, error) {
+	if size, ok := msg.Option(gocoap.Size1).(uint32); ok && b.num == 0 && size > maxBodySize {
+		res.Code = gocoap.RequestEntityTooLarge
+		res.SetOption(gocoap.Size1, uint32(maxBodySize))
+		return nil, false, errEntityTooLarge
+	}
+
+	payload, complete, err := h.uploads.upload(c.addr+msg.PathString(), b, msg.Payload)
+	switch err {
+	case nil:
+	case errEntityIncomplete:
+		res.Code = requestEntityIncomplete
+		return nil, false, err
+	case errEntityTooLarge:
+		res.Code = gocoap.RequestEntityTooLarge
+		res.SetOption(gocoap.Size1, uint32(maxBodySize))
+		return nil, false, err
+	case errTooManyTransfers:
+		res.Code = gocoap.ServiceUnavailable
+		return nil, false, err
+	default:
+		res.Code = gocoap.BadRequest
+		return nil, false, err
+	}
+
+	res.SetOption(block1, b.value())
+	if !complete {
+		res.Code = continueCode
+	}
+
+	return payload, complete, nil
+}
+
+// download responds with the requested block of the last notification which
+// didn't fit in a single block.
+func (h *Handler) download(c client, msg *gocoap.Message, b block, res *gocoap.Message) *gocoap.Message {
+	payload, ok := h.downloads.load(c.addr + msg.PathString())
+	if !ok {
+		res.Code = gocoap.NotFound
+		return res
+	}
+
+	start := int(b.num) * b.size()
+	if start >= len(payload) {
+		res.Code = gocoap.BadOption
+		return res
+	}
+
+	end := start + b.size()
+	if end > len(payload) {
+		end = len(payload)
+	}
+	b.more = end < len(payload)
+
+	res.Payload = payload[start:end]
+	res.SetOption(block2, b.value())
+	res.SetOption(size2, uint32(len(payload)))
+	return res
+}
+
+func (h *Handler) ping(obsID string, tx transmitter, o *coap.Observer, msg *gocoap.Message) {
 	pingMsg := *msg
 	pingMsg.Payload = []byte{}
 	pingMsg.Type = gocoap.Confirmable
 	pingMsg.RemoveOption(gocoap.URIQuery)
 	// According to RFC (https://tools.ietf.org/html/rfc7641#page-18), CON message must be sent at least every
 	// 24 hours. Deafault value of pingPeriod is 12.
-	t := time.NewTicker(pingPeriod * time.Hour)
+	t := time.NewTicker(h.pingPeriod * time.Hour)
 	defer t.Stop()
 	for {
 		select {
@@ -352,10 +583,10 @@ func ping(svc coap.Service, obsID string, conn *net.UDPConn, addr *net.UDPAddr,
 
 			o.StoreExpired(true)
 			timeout := float64(coap.AckTimeout)
-			logger.Info(fmt.Sprintf("Ping client %s.", obsID))
+			h.logger.Info(fmt.Sprintf("Ping client %s.", obsID))
 			for i := 0; i < coap.MaxRetransmit; i++ {
 				pingMsg.MessageID = o.LoadMessageID()
-				gocoap.Transmit(conn, addr, pingMsg)
+				tx(pingMsg)
 				time.Sleep(time.Duration(timeout * coap.AckRandomFactor))
 				if !o.LoadExpired() {
 					break
@@ -364,7 +595,7 @@ func ping(svc coap.Service, obsID string, conn *net.UDPConn, addr *net.UDPAddr,
 			}
 
 			if o.LoadExpired() {
-				svc.Unsubscribe(obsID)
+				h.svc.Unsubscribe(obsID)
 				return
 			}
 		case <-o.Cancel:
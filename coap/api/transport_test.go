@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	gocoap "github.com/dustin/go-coap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveReservedSubtopic(t *testing.T) {
+	// Reserved subtopics are rejected before the publisher is authorized,
+	// so the handler doesn't need any of its dependencies.
+	h := &Handler{}
+
+	cases := []struct {
+		desc string
+		path string
+		code gocoap.COAPCode
+	}{
+		{
+			desc: "publish to MQTT events subtopic",
+			path: "channels/1/messages/_mqtt",
+			code: gocoap.Forbidden,
+		},
+		{
+			desc: "publish to MQTT will subtopic",
+			path: "channels/1/messages/_mqtt/will",
+			code: gocoap.Forbidden,
+		},
+		{
+			desc: "publish to MQTT disconnect subtopic",
+			path: "channels/1/messages/_mqtt.disconnect",
+			code: gocoap.Forbidden,
+		},
+		{
+			desc: "publish to malformed subtopic",
+			path: "channels/1/messages/_mqtt/wi*",
+			code: gocoap.BadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &gocoap.Message{
+			Type:      gocoap.Confirmable,
+			Code:      gocoap.POST,
+			MessageID: 1,
+			Payload:   []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+		}
+		msg.SetPathString(tc.path)
+
+		res := h.receive(client{addr: "127.0.0.1:5683"}, msg)
+		assert.Equal(t, tc.code, res.Code, fmt.Sprintf("%s: expected code %s got %s\n", tc.desc, tc.code, res.Code))
+	}
+}
@@ -17,6 +17,7 @@ require (
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/protobuf v1.4.2
 	github.com/gopcua/opcua v0.1.6
+	github.com/gorilla/websocket v1.4.2
 	github.com/hokaccha/go-prettyjson v0.0.0-20190818114111-108c894c2c0e
 	github.com/influxdata/influxdb v1.8.0
 	github.com/jmoiron/sqlx v1.2.1-0.20190319043955-cdf62fdf55f6
@@ -27,6 +28,8 @@ require (
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/ory/dockertest/v3 v3.6.0
 	github.com/pelletier/go-toml v1.8.0
+	github.com/pion/dtls/v2 v2.0.1
+	github.com/pion/udp v0.1.0
 	github.com/prometheus/client_golang v1.6.0
 	github.com/rubenv/sql-migrate v0.0.0-20200429072036-ae26b214fa43
 	github.com/spf13/cobra v1.0.0
@@ -38,4 +41,5 @@ require (
 	golang.org/x/net v0.0.0-20200602114024-627f9648deb9
 	gonum.org/v1/gonum v0.7.0
 	google.golang.org/grpc v1.29.1
+	gopkg.in/yaml.v2 v2.3.0
 )
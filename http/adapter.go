@@ -9,7 +9,9 @@ import (
 	"context"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 )
 
 // Service specifies coap service API.
@@ -23,20 +25,41 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	publisher messaging.Publisher
 	things    mainflux.ThingsServiceClient
+	aliases   auth.Aliases
+	limiter   auth.Limiter
+	schemas   auth.Schemas
 }
 
 // New instantiates the HTTP adapter implementation.
-func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Service {
+func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient, aliases auth.Aliases, limiter auth.Limiter, schemas auth.Schemas) Service {
 	return &adapterService{
 		publisher: publisher,
 		things:    things,
+		aliases:   aliases,
+		limiter:   limiter,
+		schemas:   schemas,
 	}
 }
 
 func (as *adapterService) Publish(ctx context.Context, token string, msg messaging.Message) error {
+	// Channel alias is resolved in the scope of the thing owner.
+	if auth.IsAlias(msg.Channel) {
+		thid, err := as.things.Identify(ctx, &mainflux.Token{Value: token})
+		if err != nil {
+			return err
+		}
+
+		chanID, err := as.aliases.ChannelID(thid.GetValue(), msg.Channel)
+		if err != nil {
+			return err
+		}
+		msg.Channel = chanID
+	}
+
 	ar := &mainflux.AccessByKeyReq{
 		Token:  token,
 		ChanID: msg.Channel,
+		Access: things.PublishAccess,
 	}
 	thid, err := as.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -44,5 +67,13 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	}
 	msg.Publisher = thid.GetValue()
 
+	if err := as.limiter.Allow(msg.Publisher); err != nil {
+		return err
+	}
+
+	if err := as.schemas.Validate(msg.Channel, msg.Payload); err != nil {
+		return err
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
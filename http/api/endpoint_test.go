@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,12 +18,13 @@ import (
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/http/mocks"
+	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/stretchr/testify/assert"
 )
 
-func newService(cc mainflux.ThingsServiceClient) adapter.Service {
+func newService(cc mainflux.ThingsServiceClient, aliases auth.Aliases, limiter auth.Limiter, schemas auth.Schemas) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, cc)
+	return adapter.New(pub, cc, aliases, limiter, schemas)
 }
 
 func newHTTPServer(svc adapter.Service) *httptest.Server {
@@ -55,21 +57,29 @@ func (tr testRequest) make() (*http.Response, error) {
 
 func TestPublish(t *testing.T) {
 	chanID := "1"
+	chanName := "@sensors"
+	schemaChanID := "2"
 	contentType := "application/senml+json"
 	token := "auth_token"
 	invalidToken := "invalid_token"
+	limitedToken := "limited_token"
 	msg := `[{"n":"current","t":-1,"v":1.6}]`
-	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
-	svc := newService(thingsClient)
+	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID, limitedToken: "2"})
+	aliases := mocks.NewAliases(map[string]string{chanID + ":sensors": chanID})
+	limiter := mocks.NewLimiter(map[string]uint64{"2": 0})
+	schemas := mocks.NewSchemas(map[string]string{schemaChanID: `"senml"`})
+	svc := newService(thingsClient, aliases, limiter, schemas)
 	ts := newHTTPServer(svc)
 	defer ts.Close()
 
 	cases := map[string]struct {
 		chanID      string
+		subtopic    string
 		msg         string
 		contentType string
 		auth        string
 		status      int
+		err         string
 	}{
 		"publish message": {
 			chanID:      chanID,
@@ -106,6 +116,81 @@ func TestPublish(t *testing.T) {
 			auth:        token,
 			status:      http.StatusBadRequest,
 		},
+		"publish message to channel alias": {
+			chanID:      chanName,
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusAccepted,
+		},
+		"publish message to unknown channel alias": {
+			chanID:      "@unknown",
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		"publish message to channel alias with invalid authorization token": {
+			chanID:      chanName,
+			msg:         msg,
+			contentType: contentType,
+			auth:        invalidToken,
+			status:      http.StatusForbidden,
+		},
+		"publish message exceeding limits": {
+			chanID:      chanID,
+			msg:         msg,
+			contentType: contentType,
+			auth:        limitedToken,
+			status:      http.StatusTooManyRequests,
+		},
+		"publish message conforming to channel schema": {
+			chanID:      schemaChanID,
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusAccepted,
+		},
+		"publish message not conforming to channel schema": {
+			chanID:      schemaChanID,
+			msg:         `{"current":1.6}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			err:         "payload doesn't conform to the channel schema",
+		},
+		"publish message to subtopic": {
+			chanID:      chanID,
+			subtopic:    "/temperature/room1",
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusAccepted,
+		},
+		"publish message to MQTT will subtopic": {
+			chanID:      chanID,
+			subtopic:    "/_mqtt/will",
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusForbidden,
+		},
+		"publish message to MQTT disconnect subtopic": {
+			chanID:      chanID,
+			subtopic:    "/_mqtt.disconnect",
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusForbidden,
+		},
+		"publish message to MQTT events subtopic": {
+			chanID:      chanID,
+			subtopic:    "/_mqtt",
+			msg:         msg,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusForbidden,
+		},
 		"publish message unable to authorize": {
 			chanID:      chanID,
 			msg:         msg,
@@ -119,7 +204,7 @@ func TestPublish(t *testing.T) {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, tc.chanID),
+			url:         fmt.Sprintf("%s/channels/%s/messages%s", ts.URL, tc.chanID, tc.subtopic),
 			contentType: tc.contentType,
 			token:       tc.auth,
 			body:        strings.NewReader(tc.msg),
@@ -127,5 +212,13 @@ func TestPublish(t *testing.T) {
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		if tc.err != "" {
+			var body struct {
+				Err string `json:"error"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Contains(t, body.Err, tc.err, fmt.Sprintf("%s: expected error %s got %s", desc, tc.err, body.Err))
+		}
 	}
 }
@@ -5,7 +5,7 @@ package api
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -19,7 +19,10 @@ import (
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	adapter "github.com/mainflux/mainflux/http"
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -27,14 +30,18 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const protocol = "http"
+const (
+	protocol    = "http"
+	contentType = "application/json"
+)
 
 var (
 	errMalformedData     = errors.New("malformed request data")
 	errMalformedSubtopic = errors.New("malformed subtopic")
+	errReservedSubtopic  = errors.New("publish to reserved subtopic")
 )
 
-var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
+var channelPartRegExp = regexp.MustCompile(`^/channels/(@?[\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc adapter.Service, tracer opentracing.Tracer) http.Handler {
@@ -103,6 +110,9 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if messaging.Reserved(subtopic) {
+		return nil, errReservedSubtopic
+	}
 
 	payload, err := decodePayload(r.Body)
 	if err != nil {
@@ -141,16 +151,31 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 }
 
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	// Publisher is told why the payload was rejected, so the devices
+	// publishing malformed messages can be fixed.
+	if errors.Contains(err, schema.ErrInvalidPayload) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(errorRes{Err: err.Error()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
 	switch err {
 	case errMalformedData, errMalformedSubtopic:
 		w.WriteHeader(http.StatusBadRequest)
-	case things.ErrUnauthorizedAccess:
+	case things.ErrUnauthorizedAccess, errReservedSubtopic:
 		w.WriteHeader(http.StatusForbidden)
+	case auth.ErrRateLimited, auth.ErrQuotaExceeded:
+		w.WriteHeader(http.StatusTooManyRequests)
 	default:
 		if e, ok := status.FromError(err); ok {
 			switch e.Code() {
 			case codes.PermissionDenied:
 				w.WriteHeader(http.StatusForbidden)
+			case codes.NotFound:
+				w.WriteHeader(http.StatusNotFound)
 			default:
 				w.WriteHeader(http.StatusServiceUnavailable)
 			}
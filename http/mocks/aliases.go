@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/mainflux/mainflux/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ auth.Aliases = (*aliases)(nil)
+
+type aliases struct {
+	channels map[string]string
+}
+
+// NewAliases returns mock implementation of channel aliases cache. Channels
+// are mapped by thing ID and channel name, without the alias prefix.
+func NewAliases(channels map[string]string) auth.Aliases {
+	return aliases{channels}
+}
+
+func (a aliases) ChannelID(thingID, channel string) (string, error) {
+	if !auth.IsAlias(channel) {
+		return channel, nil
+	}
+
+	id, ok := a.channels[thingID+":"+auth.AliasName(channel)]
+	if !ok {
+		return "", status.Error(codes.NotFound, "entity does not exist")
+	}
+
+	return id, nil
+}
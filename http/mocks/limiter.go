@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/auth"
+)
+
+var _ auth.Limiter = (*limiter)(nil)
+
+type limiter struct {
+	mu     sync.Mutex
+	quotas map[string]uint64
+}
+
+// NewLimiter returns mock implementation of thing publishing limiter. Things
+// are limited by the number of messages they can publish, while the things
+// not present in quotas are unlimited.
+func NewLimiter(quotas map[string]uint64) auth.Limiter {
+	return &limiter{quotas: quotas}
+}
+
+func (l *limiter) Allow(thingID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	quota, ok := l.quotas[thingID]
+	if !ok {
+		return nil
+	}
+	if quota == 0 {
+		return auth.ErrQuotaExceeded
+	}
+	l.quotas[thingID] = quota - 1
+
+	return nil
+}
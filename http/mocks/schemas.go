@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/schema"
+)
+
+var _ auth.Schemas = (*schemas)(nil)
+
+type schemas struct {
+	schemas map[string]string
+}
+
+// NewSchemas returns mock implementation of channel payload schemas cache.
+// Schemas are JSON encoded and mapped by channel ID, while the channels not
+// present in schemas don't declare one.
+func NewSchemas(chanSchemas map[string]string) auth.Schemas {
+	return schemas{chanSchemas}
+}
+
+func (s schemas) Validate(chanID string, payload []byte) error {
+	raw, ok := s.schemas[chanID]
+	if !ok {
+		return nil
+	}
+
+	sch, err := schema.Parse([]byte(raw))
+	if err != nil {
+		return err
+	}
+
+	return sch.Validate(payload)
+}
+
+func (s schemas) Invalidate(chanID string) error {
+	return nil
+}
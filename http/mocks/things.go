@@ -55,5 +55,31 @@ func (tc thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, .
 }
 
 func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := req.GetValue()
+	if key == ServiceErrToken {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	id, ok := tc.things[key]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid credentials provided")
+	}
+
+	return &mainflux.ThingID{Value: id}, nil
+}
+
+func (tc thingsClient) RetrievePSK(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) ChannelIDByName(context.Context, *mainflux.ChannelByNameReq, ...grpc.CallOption) (*mainflux.ChannelID, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) RetrieveLimits(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.Limits, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) RetrieveSchema(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.Schema, error) {
 	panic("not implemented")
 }
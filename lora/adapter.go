@@ -5,15 +5,35 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/senml"
 )
 
 const (
 	protocol      = "lora"
 	thingSuffix   = "thing"
 	channelSuffix = "channel"
+
+	// AckSubtopic is the Channel subtopic of downlink acknowledgements.
+	AckSubtopic = "ack"
+
+	// ErrorSubtopic is the Channel subtopic of LoRa Server errors.
+	ErrorSubtopic = "error"
+
+	// JoinSubtopic is the Channel subtopic of device joins.
+	JoinSubtopic = "join"
+
+	// StatusSubtopic is the Channel subtopic of device status.
+	StatusSubtopic = "status"
+
+	// MetadataSubtopic is the Channel subtopic of uplink radio metadata and
+	// device status.
+	MetadataSubtopic = "metadata"
+
+	maxFPort = 223
 )
 
 var (
@@ -25,18 +45,21 @@ var (
 
 	// ErrNotFoundApp indicates a non-existent route map for an application ID.
 	ErrNotFoundApp = errors.New("route map not found for this application ID")
+
+	// ErrNotFoundThing indicates a non-existent route map for a thing ID.
+	ErrNotFoundThing = errors.New("route map not found for this thing ID")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
-	// CreateThing creates thingID:devEUI route-map
-	CreateThing(thingID string, devEUI string) error
+	// CreateThing creates thingID:devEUI route-map and stores the Thing codec
+	CreateThing(thingID string, devEUI string, codec CodecConfig) error
 
-	// UpdateThing updates thingID:devEUI route-map
-	UpdateThing(thingID string, devEUI string) error
+	// UpdateThing updates thingID:devEUI route-map and the Thing codec
+	UpdateThing(thingID string, devEUI string, codec CodecConfig) error
 
-	// RemoveThing removes thingID:devEUI route-map
+	// RemoveThing removes thingID:devEUI route-map and the Thing codec
 	RemoveThing(thingID string) error
 
 	// CreateChannel creates channelID:appID route-map
@@ -50,22 +73,46 @@ type Service interface {
 
 	// Publish forwards messages from the LoRa MQTT broker to Mainflux NATS broker
 	Publish(ctx context.Context, token string, msg Message) error
+
+	// PublishAck forwards downlink acknowledgements from the LoRa MQTT broker
+	// to Mainflux NATS broker
+	PublishAck(ctx context.Context, token string, msg AckMessage) error
+
+	// PublishError forwards errors from the LoRa MQTT broker to Mainflux NATS broker
+	PublishError(ctx context.Context, token string, msg ErrorMessage) error
+
+	// PublishJoin forwards device joins from the LoRa MQTT broker to Mainflux NATS broker
+	PublishJoin(ctx context.Context, token string, msg JoinMessage) error
+
+	// PublishStatus forwards device status from the LoRa MQTT broker to Mainflux NATS broker
+	PublishStatus(ctx context.Context, token string, msg StatusMessage) error
+
+	// Downlink forwards messages from Mainflux NATS broker to the LoRa MQTT
+	// broker as downlinks to the device of the Thing given by message subtopic
+	Downlink(ctx context.Context, msg messaging.Message) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	publisher  messaging.Publisher
-	thingsRM   RouteMapRepository
-	channelsRM RouteMapRepository
+	publisher   messaging.Publisher
+	downlinks   DownlinkPublisher
+	thingsRM    RouteMapRepository
+	channelsRM  RouteMapRepository
+	codecs      CodecRepository
+	publishMeta bool
 }
 
-// New instantiates the LoRa adapter implementation.
-func New(publisher messaging.Publisher, thingsRM, channelsRM RouteMapRepository) Service {
+// New instantiates the LoRa adapter implementation. If publishMeta is set,
+// uplink radio metadata and device status are published on MetadataSubtopic.
+func New(publisher messaging.Publisher, downlinks DownlinkPublisher, thingsRM, channelsRM RouteMapRepository, codecs CodecRepository, publishMeta bool) Service {
 	return &adapterService{
-		publisher:  publisher,
-		thingsRM:   thingsRM,
-		channelsRM: channelsRM,
+		publisher:   publisher,
+		downlinks:   downlinks,
+		thingsRM:    thingsRM,
+		channelsRM:  channelsRM,
+		codecs:      codecs,
+		publishMeta: publishMeta,
 	}
 }
 
@@ -84,7 +131,8 @@ func (as *adapterService) Publish(ctx context.Context, token string, m Message)
 	}
 
 	// Use the SenML message decoded on LoRa server application if
-	// field Object isn't empty. Otherwise, decode standard field Data.
+	// field Object isn't empty. Otherwise, decode standard field Data
+	// and convert it to SenML using the Thing codec, if any.
 	var payload []byte
 	switch m.Object {
 	case nil:
@@ -92,6 +140,10 @@ func (as *adapterService) Publish(ctx context.Context, token string, m Message)
 		if err != nil {
 			return ErrMalformedMessage
 		}
+
+		if payload, err = as.decode(thing, payload, m.FPort); err != nil {
+			return err
+		}
 	default:
 		jo, err := json.Marshal(m.Object)
 		if err != nil {
@@ -109,19 +161,219 @@ func (as *adapterService) Publish(ctx context.Context, token string, m Message)
 		Created:   time.Now().UnixNano(),
 	}
 
+	if err := as.publisher.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+
+	if !as.publishMeta {
+		return nil
+	}
+
+	return as.publishEvent(m.ApplicationID, m.DevEUI, MetadataSubtopic, metadata(m))
+}
+
+// metadata returns SenML records of the uplink radio metadata and device
+// status. Gateway records are repeated for each gateway that received the uplink.
+func metadata(m Message) []senml.Record {
+	fCnt := float64(m.FCnt)
+	freq := m.TxInfo.Frequency
+	sf := float64(m.TxInfo.DataRate.SpreadFactor)
+	records := []senml.Record{
+		{Name: "fCnt", Value: &fCnt},
+		{Name: "frequency", Unit: "Hz", Value: &freq},
+		{Name: "spreadingFactor", Value: &sf},
+	}
+
+	if battery, err := strconv.ParseFloat(m.DeviceStatusBattery, 64); err == nil {
+		records = append(records, senml.Record{Name: "battery", Value: &battery})
+	}
+	if margin, err := strconv.ParseFloat(m.DeviceStatusMrgin, 64); err == nil {
+		records = append(records, senml.Record{Name: "margin", Unit: "dB", Value: &margin})
+	}
+
+	for _, rx := range m.RxInfo {
+		gateway, rssi, snr := rx.Mac, rx.Rssi, rx.LoRaSNR
+		if gateway == "" {
+			gateway = rx.GatewayID
+		}
+		records = append(records,
+			senml.Record{Name: "gateway", StringValue: &gateway},
+			senml.Record{Name: "rssi", Unit: "dBm", Value: &rssi},
+			senml.Record{Name: "snr", Unit: "dB", Value: &snr},
+		)
+	}
+
+	return records
+}
+
+// PublishAck forwards downlink acknowledgements from Lora MQTT broker to Mainflux NATS broker
+func (as *adapterService) PublishAck(ctx context.Context, token string, m AckMessage) error {
+	fCnt := float64(m.FCnt)
+	records := []senml.Record{
+		{Name: "acknowledged", BoolValue: &m.Acknowledged},
+		{Name: "fCnt", Value: &fCnt},
+	}
+
+	return as.publishEvent(m.ApplicationID, m.DevEUI, AckSubtopic, records)
+}
+
+// PublishError forwards errors from Lora MQTT broker to Mainflux NATS broker
+func (as *adapterService) PublishError(ctx context.Context, token string, m ErrorMessage) error {
+	fCnt := float64(m.FCnt)
+	records := []senml.Record{
+		{Name: "type", StringValue: &m.Type},
+		{Name: "error", StringValue: &m.Error},
+		{Name: "fCnt", Value: &fCnt},
+	}
+
+	return as.publishEvent(m.ApplicationID, m.DevEUI, ErrorSubtopic, records)
+}
+
+// PublishJoin forwards device joins from Lora MQTT broker to Mainflux NATS broker
+func (as *adapterService) PublishJoin(ctx context.Context, token string, m JoinMessage) error {
+	records := []senml.Record{
+		{Name: "devAddr", StringValue: &m.DevAddr},
+	}
+
+	return as.publishEvent(m.ApplicationID, m.DevEUI, JoinSubtopic, records)
+}
+
+// PublishStatus forwards device status from Lora MQTT broker to Mainflux NATS broker
+func (as *adapterService) PublishStatus(ctx context.Context, token string, m StatusMessage) error {
+	records := []senml.Record{
+		{Name: "margin", Unit: "dB", Value: &m.Margin},
+		{Name: "externalPowerSource", BoolValue: &m.ExternalPowerSource},
+	}
+
+	switch {
+	case m.BatteryLevel != nil && !m.BatteryLevelUnavailable:
+		records = append(records, senml.Record{Name: "batteryLevel", Unit: "%EL", Value: m.BatteryLevel})
+	case m.Battery != nil:
+		records = append(records, senml.Record{Name: "battery", Value: m.Battery})
+	}
+
+	return as.publishEvent(m.ApplicationID, m.DevEUI, StatusSubtopic, records)
+}
+
+// publishEvent publishes LoRa Server event of the device as SenML on the
+// given subtopic of the Channel mapped to the application.
+func (as *adapterService) publishEvent(appID, devEUI, subtopic string, records []senml.Record) error {
+	thing, err := as.thingsRM.Get(devEUI)
+	if err != nil {
+		return ErrNotFoundDev
+	}
+
+	channel, err := as.channelsRM.Get(appID)
+	if err != nil {
+		return ErrNotFoundApp
+	}
+
+	now := time.Now()
+	records[0].BaseTime = float64(now.Unix())
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Publisher: thing,
+		Protocol:  protocol,
+		Channel:   channel,
+		Subtopic:  subtopic,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
 
-func (as *adapterService) CreateThing(thingID string, devEUI string) error {
-	return as.thingsRM.Save(thingID, devEUI)
+// Downlink forwards messages from Mainflux NATS broker to Lora MQTT broker
+func (as *adapterService) Downlink(ctx context.Context, msg messaging.Message) error {
+	// Get route map of mainflux channel
+	app, err := as.channelsRM.Get(msg.Channel)
+	if err != nil {
+		return ErrNotFoundApp
+	}
+
+	// Get route map of mainflux thing
+	devEUI, err := as.thingsRM.Get(msg.Subtopic)
+	if err != nil {
+		return ErrNotFoundThing
+	}
+
+	var dl DownlinkMessage
+	if err := json.Unmarshal(msg.Payload, &dl); err != nil {
+		return ErrMalformedMessage
+	}
+
+	if dl.FPort < 1 || dl.FPort > maxFPort {
+		return ErrMalformedMessage
+	}
+
+	// Downlink payload is either the object encoded by LoRa Server
+	// application codec or base64 encoded raw data.
+	if dl.Object == nil {
+		if _, err := base64.StdEncoding.DecodeString(dl.Data); dl.Data == "" || err != nil {
+			return ErrMalformedMessage
+		}
+	}
+
+	return as.downlinks.Publish(app, devEUI, dl)
+}
+
+// decode converts raw frame to SenML using the codec of the Thing. The frame
+// is returned as it is if the Thing has no codec.
+func (as *adapterService) decode(thingID string, data []byte, fPort int) ([]byte, error) {
+	cfg, err := as.codecs.Get(thingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Type == "" {
+		return data, nil
+	}
+
+	codec, err := NewCodec(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := codec.Decode(data, fPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(records)
+}
+
+func (as *adapterService) CreateThing(thingID string, devEUI string, codec CodecConfig) error {
+	if err := as.thingsRM.Save(thingID, devEUI); err != nil {
+		return err
+	}
+	return as.saveCodec(thingID, codec)
 }
 
-func (as *adapterService) UpdateThing(thingID string, devEUI string) error {
-	return as.thingsRM.Save(thingID, devEUI)
+func (as *adapterService) UpdateThing(thingID string, devEUI string, codec CodecConfig) error {
+	if err := as.thingsRM.Save(thingID, devEUI); err != nil {
+		return err
+	}
+	return as.saveCodec(thingID, codec)
 }
 
 func (as *adapterService) RemoveThing(thingID string) error {
-	return as.thingsRM.Remove(thingID)
+	if err := as.thingsRM.Remove(thingID); err != nil {
+		return err
+	}
+	return as.codecs.Remove(thingID)
+}
+
+func (as *adapterService) saveCodec(thingID string, codec CodecConfig) error {
+	if codec.Type != "" {
+		if _, err := NewCodec(codec); err != nil {
+			return err
+		}
+	}
+	return as.codecs.Save(thingID, codec)
 }
 
 func (as *adapterService) CreateChannel(chanID string, appID string) error {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*loggingMiddleware)(nil)
@@ -27,7 +28,7 @@ func LoggingMiddleware(svc lora.Service, logger logger.Logger) lora.Service {
 	}
 }
 
-func (lm loggingMiddleware) CreateThing(mfxThing string, loraDevEUI string) (err error) {
+func (lm loggingMiddleware) CreateThing(mfxThing string, loraDevEUI string, codec lora.CodecConfig) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("create_thing mfx:lora:%s:%s took %s to complete", mfxThing, loraDevEUI, time.Since(begin))
 		if err != nil {
@@ -37,10 +38,10 @@ func (lm loggingMiddleware) CreateThing(mfxThing string, loraDevEUI string) (err
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CreateThing(mfxThing, loraDevEUI)
+	return lm.svc.CreateThing(mfxThing, loraDevEUI, codec)
 }
 
-func (lm loggingMiddleware) UpdateThing(mfxThing string, loraDevEUI string) (err error) {
+func (lm loggingMiddleware) UpdateThing(mfxThing string, loraDevEUI string, codec lora.CodecConfig) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("update_thing mfx:lora:%s:%s took %s to complete", mfxThing, loraDevEUI, time.Since(begin))
 		if err != nil {
@@ -50,7 +51,7 @@ func (lm loggingMiddleware) UpdateThing(mfxThing string, loraDevEUI string) (err
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.UpdateThing(mfxThing, loraDevEUI)
+	return lm.svc.UpdateThing(mfxThing, loraDevEUI, codec)
 }
 
 func (lm loggingMiddleware) RemoveThing(mfxThing string) (err error) {
@@ -117,3 +118,68 @@ func (lm loggingMiddleware) Publish(ctx context.Context, token string, m lora.Me
 
 	return lm.svc.Publish(ctx, token, m)
 }
+
+func (lm loggingMiddleware) PublishAck(ctx context.Context, token string, m lora.AckMessage) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("publish application/%s/device/%s/ack took %s to complete", m.ApplicationID, m.DevEUI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishAck(ctx, token, m)
+}
+
+func (lm loggingMiddleware) PublishError(ctx context.Context, token string, m lora.ErrorMessage) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("publish application/%s/device/%s/error took %s to complete", m.ApplicationID, m.DevEUI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishError(ctx, token, m)
+}
+
+func (lm loggingMiddleware) Downlink(ctx context.Context, msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("downlink mfx:lora:%s:%s took %s to complete", msg.Channel, msg.Subtopic, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Downlink(ctx, msg)
+}
+
+func (lm loggingMiddleware) PublishJoin(ctx context.Context, token string, m lora.JoinMessage) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("publish application/%s/device/%s/join took %s to complete", m.ApplicationID, m.DevEUI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishJoin(ctx, token, m)
+}
+
+func (lm loggingMiddleware) PublishStatus(ctx context.Context, token string, m lora.StatusMessage) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("publish application/%s/device/%s/status took %s to complete", m.ApplicationID, m.DevEUI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishStatus(ctx, token, m)
+}
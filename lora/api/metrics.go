@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*metricsMiddleware)(nil)
@@ -28,22 +29,22 @@ func MetricsMiddleware(svc lora.Service, counter metrics.Counter, latency metric
 	}
 }
 
-func (mm *metricsMiddleware) CreateThing(mfxDevID string, loraDevEUI string) error {
+func (mm *metricsMiddleware) CreateThing(mfxDevID string, loraDevEUI string, codec lora.CodecConfig) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "create_thing").Add(1)
 		mm.latency.With("method", "create_thing").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.CreateThing(mfxDevID, loraDevEUI)
+	return mm.svc.CreateThing(mfxDevID, loraDevEUI, codec)
 }
 
-func (mm *metricsMiddleware) UpdateThing(mfxDevID string, loraDevEUI string) error {
+func (mm *metricsMiddleware) UpdateThing(mfxDevID string, loraDevEUI string, codec lora.CodecConfig) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "update_thing").Add(1)
 		mm.latency.With("method", "update_thing").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.UpdateThing(mfxDevID, loraDevEUI)
+	return mm.svc.UpdateThing(mfxDevID, loraDevEUI, codec)
 }
 
 func (mm *metricsMiddleware) RemoveThing(mfxDevID string) error {
@@ -90,3 +91,48 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, token string, m lora.M
 
 	return mm.svc.Publish(ctx, token, m)
 }
+
+func (mm *metricsMiddleware) PublishAck(ctx context.Context, token string, m lora.AckMessage) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_ack").Add(1)
+		mm.latency.With("method", "publish_ack").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishAck(ctx, token, m)
+}
+
+func (mm *metricsMiddleware) PublishError(ctx context.Context, token string, m lora.ErrorMessage) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_error").Add(1)
+		mm.latency.With("method", "publish_error").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishError(ctx, token, m)
+}
+
+func (mm *metricsMiddleware) Downlink(ctx context.Context, msg messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "downlink").Add(1)
+		mm.latency.With("method", "downlink").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Downlink(ctx, msg)
+}
+
+func (mm *metricsMiddleware) PublishJoin(ctx context.Context, token string, m lora.JoinMessage) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_join").Add(1)
+		mm.latency.With("method", "publish_join").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishJoin(ctx, token, m)
+}
+
+func (mm *metricsMiddleware) PublishStatus(ctx context.Context, token string, m lora.StatusMessage) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_status").Add(1)
+		mm.latency.With("method", "publish_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishStatus(ctx, token, m)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mainflux/senml"
+)
+
+const standardGravity = 9.80665
+
+// Cayenne LPP data types (https://github.com/myDevicesIoT/cayenne-docs/blob/master/docs/LORA.md).
+const (
+	lppDigitalInput  = 0
+	lppDigitalOutput = 1
+	lppAnalogInput   = 2
+	lppAnalogOutput  = 3
+	lppIlluminance   = 101
+	lppPresence      = 102
+	lppTemperature   = 103
+	lppHumidity      = 104
+	lppAccelerometer = 113
+	lppBarometer     = 115
+	lppGyrometer     = 134
+	lppGPS           = 136
+)
+
+// lppType describes decoding of the Cayenne LPP data type values.
+type lppType struct {
+	name   string
+	size   int
+	signed bool
+	// Value is raw value divided by div and multiplied by mul, if set.
+	div  float64
+	mul  float64
+	unit string
+	// axes contains the names of the values of multi-value types.
+	axes []string
+}
+
+var lppTypes = map[byte]lppType{
+	lppDigitalInput:  {name: "digital_input", size: 1, div: 1},
+	lppDigitalOutput: {name: "digital_output", size: 1, div: 1},
+	lppAnalogInput:   {name: "analog_input", size: 2, signed: true, div: 100},
+	lppAnalogOutput:  {name: "analog_output", size: 2, signed: true, div: 100},
+	lppIlluminance:   {name: "illuminance", size: 2, div: 1, unit: "lx"},
+	lppPresence:      {name: "presence", size: 1, div: 1},
+	lppTemperature:   {name: "temperature", size: 2, signed: true, div: 10, unit: "Cel"},
+	lppHumidity:      {name: "humidity", size: 1, div: 2, unit: "%RH"},
+	lppAccelerometer: {name: "accelerometer", size: 2, signed: true, div: 1000, mul: standardGravity, unit: "m/s2", axes: []string{"x", "y", "z"}},
+	lppBarometer:     {name: "barometer", size: 2, div: 1, mul: 10, unit: "Pa"},
+	lppGyrometer:     {name: "gyrometer", size: 2, signed: true, div: 100, axes: []string{"x", "y", "z"}},
+}
+
+var _ Codec = (*cayenneLPP)(nil)
+
+type cayenneLPP struct{}
+
+// Decode decodes Cayenne LPP frame to SenML records named <type>_<channel>,
+// with the axis suffix for multi-value types.
+func (c cayenneLPP) Decode(data []byte, fPort int) ([]senml.Record, error) {
+	records := []senml.Record{}
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, ErrMalformedMessage
+		}
+		channel, typ := data[0], data[1]
+		data = data[2:]
+
+		if typ == lppGPS {
+			if len(data) < 9 {
+				return nil, ErrMalformedMessage
+			}
+			name := fmt.Sprintf("gps_%d", channel)
+			lat := float64(int24(data[0:3])) / 10000
+			lon := float64(int24(data[3:6])) / 10000
+			alt := float64(int24(data[6:9])) / 100
+			records = append(records,
+				senml.Record{Name: name + "_lat", Unit: "lat", Value: &lat},
+				senml.Record{Name: name + "_lon", Unit: "lon", Value: &lon},
+				senml.Record{Name: name + "_alt", Unit: "m", Value: &alt},
+			)
+			data = data[9:]
+			continue
+		}
+
+		t, ok := lppTypes[typ]
+		if !ok {
+			return nil, ErrMalformedMessage
+		}
+
+		axes := t.axes
+		if len(axes) == 0 {
+			axes = []string{""}
+		}
+		if len(data) < t.size*len(axes) {
+			return nil, ErrMalformedMessage
+		}
+
+		for _, axis := range axes {
+			name := fmt.Sprintf("%s_%d", t.name, channel)
+			if axis != "" {
+				name = fmt.Sprintf("%s_%s", name, axis)
+			}
+
+			var raw float64
+			switch {
+			case t.size == 1:
+				raw = float64(data[0])
+			case t.signed:
+				raw = float64(int16(binary.BigEndian.Uint16(data)))
+			default:
+				raw = float64(binary.BigEndian.Uint16(data))
+			}
+
+			val := raw / t.div
+			if t.mul != 0 {
+				val *= t.mul
+			}
+			records = append(records, senml.Record{Name: name, Unit: t.unit, Value: &val})
+			data = data[t.size:]
+		}
+	}
+
+	return records, nil
+}
+
+// int24 decodes big-endian signed 24-bit integer.
+func int24(b []byte) int32 {
+	v := int32(b[0])<<16 | int32(b[1])<<8 | int32(b[2])
+	if v&0x800000 != 0 {
+		v -= 1 << 24
+	}
+	return v
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"errors"
+
+	"github.com/mainflux/senml"
+)
+
+const (
+	// CayenneLPP is the Cayenne Low Power Payload codec.
+	CayenneLPP = "cayenne_lpp"
+
+	// Layout is the declarative byte layout codec.
+	Layout = "layout"
+)
+
+// ErrUnsupportedCodec indicates unknown codec or malformed codec layout.
+var ErrUnsupportedCodec = errors.New("unsupported codec")
+
+// CodecConfig represents the codec of the device raw frames, as specified in
+// the Thing metadata. Raw frames are published as they are if codec is not set.
+type CodecConfig struct {
+	Type   string  `json:"codec,omitempty"`
+	Layout []Field `json:"layout,omitempty"`
+}
+
+// Field represents a value at the given offset of the raw frame decoded by
+// the layout codec. Field is decoded only from the frames received on FPort,
+// unless FPort is zero. Multi-byte values are big-endian unless LittleEndian
+// is set, and decoded value is multiplied by Scale if it's set.
+type Field struct {
+	Name         string  `json:"name"`
+	FPort        int     `json:"fport,omitempty"`
+	Offset       int     `json:"offset"`
+	Type         string  `json:"type"`
+	LittleEndian bool    `json:"little_endian,omitempty"`
+	Scale        float64 `json:"scale,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+}
+
+// Codec decodes raw LoRa frames to SenML records.
+type Codec interface {
+	// Decode decodes raw frame received on the given fPort.
+	Decode(data []byte, fPort int) ([]senml.Record, error)
+}
+
+// CodecRepository stores Thing codecs.
+type CodecRepository interface {
+	// Save stores the codec of the Thing.
+	Save(string, CodecConfig) error
+
+	// Get returns the codec of the Thing. Empty codec is returned if the
+	// Thing has none stored.
+	Get(string) (CodecConfig, error)
+
+	// Remove removes the codec of the Thing.
+	Remove(string) error
+}
+
+// NewCodec returns the codec of the given config.
+func NewCodec(cfg CodecConfig) (Codec, error) {
+	switch cfg.Type {
+	case CayenneLPP:
+		return cayenneLPP{}, nil
+	case Layout:
+		return newLayoutCodec(cfg.Layout)
+	default:
+		return nil, ErrUnsupportedCodec
+	}
+}
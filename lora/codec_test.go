@@ -0,0 +1,369 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const delta = 1e-9
+
+// record is the expected decoded record. Val is ignored for bool records.
+type record struct {
+	name string
+	unit string
+	val  float64
+	bool *bool
+}
+
+func assertRecords(t *testing.T, desc string, expected []record, records []senml.Record) {
+	require.Equal(t, len(expected), len(records), fmt.Sprintf("%s: expected %d records got %d\n", desc, len(expected), len(records)))
+	for i, r := range records {
+		e := expected[i]
+		assert.Equal(t, e.name, r.Name, fmt.Sprintf("%s: expected name %s got %s\n", desc, e.name, r.Name))
+		assert.Equal(t, e.unit, r.Unit, fmt.Sprintf("%s: expected unit %s got %s\n", desc, e.unit, r.Unit))
+		if e.bool != nil {
+			require.NotNil(t, r.BoolValue, fmt.Sprintf("%s: expected bool value of %s\n", desc, e.name))
+			assert.Equal(t, *e.bool, *r.BoolValue, fmt.Sprintf("%s: expected %s to be %t got %t\n", desc, e.name, *e.bool, *r.BoolValue))
+			continue
+		}
+		require.NotNil(t, r.Value, fmt.Sprintf("%s: expected value of %s\n", desc, e.name))
+		assert.InDelta(t, e.val, *r.Value, delta, fmt.Sprintf("%s: expected %s to be %f got %f\n", desc, e.name, e.val, *r.Value))
+	}
+}
+
+func TestCayenneLPP(t *testing.T) {
+	c, err := lora.NewCodec(lora.CodecConfig{Type: lora.CayenneLPP})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	g := 9.80665
+	cases := []struct {
+		desc    string
+		data    []byte
+		records []record
+		err     error
+	}{
+		{
+			desc:    "digital input",
+			data:    []byte{0x01, 0x00, 0xff},
+			records: []record{{name: "digital_input_1", val: 255}},
+		},
+		{
+			desc:    "digital output",
+			data:    []byte{0x02, 0x01, 0x01},
+			records: []record{{name: "digital_output_2", val: 1}},
+		},
+		{
+			desc:    "analog input",
+			data:    []byte{0x03, 0x02, 0x01, 0x2c},
+			records: []record{{name: "analog_input_3", val: 3}},
+		},
+		{
+			desc:    "negative analog input",
+			data:    []byte{0x03, 0x02, 0xfe, 0xd4},
+			records: []record{{name: "analog_input_3", val: -3}},
+		},
+		{
+			desc:    "negative analog output",
+			data:    []byte{0x04, 0x03, 0xff, 0xff},
+			records: []record{{name: "analog_output_4", val: -0.01}},
+		},
+		{
+			desc:    "illuminance above signed range",
+			data:    []byte{0x05, 0x65, 0xff, 0xff},
+			records: []record{{name: "illuminance_5", unit: "lx", val: 65535}},
+		},
+		{
+			desc:    "presence",
+			data:    []byte{0x06, 0x66, 0x01},
+			records: []record{{name: "presence_6", val: 1}},
+		},
+		{
+			desc:    "temperature",
+			data:    []byte{0x07, 0x67, 0x01, 0x10},
+			records: []record{{name: "temperature_7", unit: "Cel", val: 27.2}},
+		},
+		{
+			desc:    "negative temperature",
+			data:    []byte{0x07, 0x67, 0xff, 0x38},
+			records: []record{{name: "temperature_7", unit: "Cel", val: -20}},
+		},
+		{
+			desc:    "humidity",
+			data:    []byte{0x08, 0x68, 0xa1},
+			records: []record{{name: "humidity_8", unit: "%RH", val: 80.5}},
+		},
+		{
+			desc: "accelerometer",
+			data: []byte{0x09, 0x71, 0x03, 0xe8, 0xfc, 0x18, 0x00, 0x00},
+			records: []record{
+				{name: "accelerometer_9_x", unit: "m/s2", val: g},
+				{name: "accelerometer_9_y", unit: "m/s2", val: -g},
+				{name: "accelerometer_9_z", unit: "m/s2", val: 0},
+			},
+		},
+		{
+			desc:    "barometer",
+			data:    []byte{0x0a, 0x73, 0x27, 0x7f},
+			records: []record{{name: "barometer_10", unit: "Pa", val: 101110}},
+		},
+		{
+			desc: "gyrometer",
+			data: []byte{0x0b, 0x86, 0x00, 0x64, 0xff, 0x9c, 0x80, 0x00},
+			records: []record{
+				{name: "gyrometer_11_x", val: 1},
+				{name: "gyrometer_11_y", val: -1},
+				{name: "gyrometer_11_z", val: -327.68},
+			},
+		},
+		{
+			desc: "gps",
+			data: []byte{0x0c, 0x88, 0x06, 0x76, 0x5f, 0xf2, 0x96, 0x0a, 0x00, 0x03, 0xe8},
+			records: []record{
+				{name: "gps_12_lat", unit: "lat", val: 42.3519},
+				{name: "gps_12_lon", unit: "lon", val: -87.9094},
+				{name: "gps_12_alt", unit: "m", val: 10},
+			},
+		},
+		{
+			desc: "negative gps altitude",
+			data: []byte{0x0c, 0x88, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9c},
+			records: []record{
+				{name: "gps_12_lat", unit: "lat", val: 0},
+				{name: "gps_12_lon", unit: "lon", val: 0},
+				{name: "gps_12_alt", unit: "m", val: -1},
+			},
+		},
+		{
+			desc: "multiple values",
+			data: []byte{0x03, 0x67, 0x01, 0x10, 0x05, 0x67, 0x00, 0xff},
+			records: []record{
+				{name: "temperature_3", unit: "Cel", val: 27.2},
+				{name: "temperature_5", unit: "Cel", val: 25.5},
+			},
+		},
+		{
+			desc:    "empty frame",
+			data:    []byte{},
+			records: []record{},
+		},
+		{
+			desc: "unknown type",
+			data: []byte{0x01, 0x50, 0x00},
+			err:  lora.ErrMalformedMessage,
+		},
+		{
+			desc: "missing type",
+			data: []byte{0x01},
+			err:  lora.ErrMalformedMessage,
+		},
+		{
+			desc: "truncated value",
+			data: []byte{0x07, 0x67, 0x01},
+			err:  lora.ErrMalformedMessage,
+		},
+		{
+			desc: "truncated axis",
+			data: []byte{0x09, 0x71, 0x03, 0xe8, 0xfc, 0x18, 0x00},
+			err:  lora.ErrMalformedMessage,
+		},
+		{
+			desc: "truncated gps",
+			data: []byte{0x0c, 0x88, 0x06, 0x76, 0x5f, 0xf2, 0x96, 0x0a, 0x00, 0x03},
+			err:  lora.ErrMalformedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		records, err := c.Decode(tc.data, 1)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assertRecords(t, tc.desc, tc.records, records)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	on := true
+	fields := []lora.Field{
+		{Name: "on", FPort: 1, Offset: 0, Type: "bool"},
+		{Name: "level", FPort: 1, Offset: 1, Type: "uint8"},
+		{Name: "delta", FPort: 1, Offset: 2, Type: "int8"},
+		{Name: "temp", FPort: 1, Offset: 3, Type: "int16", Scale: 0.1, Unit: "Cel"},
+		{Name: "count", FPort: 1, Offset: 5, Type: "uint16", LittleEndian: true},
+		{Name: "energy", FPort: 1, Offset: 7, Type: "uint32"},
+		{Name: "offset", FPort: 1, Offset: 11, Type: "int32", LittleEndian: true},
+		{Name: "ratio", FPort: 1, Offset: 15, Type: "float32"},
+		{Name: "status", FPort: 2, Offset: 0, Type: "uint8"},
+	}
+	c, err := lora.NewCodec(lora.CodecConfig{Type: lora.Layout, Layout: fields})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	ratio := math.Float32bits(0.5)
+	frame := []byte{
+		0x01,
+		0xc8,
+		0xff,
+		0xff, 0x38,
+		0x34, 0x12,
+		0xff, 0xff, 0xff, 0xff,
+		0xfe, 0xff, 0xff, 0xff,
+		byte(ratio >> 24), byte(ratio >> 16), byte(ratio >> 8), byte(ratio),
+	}
+
+	cases := []struct {
+		desc    string
+		data    []byte
+		fPort   int
+		records []record
+		err     error
+	}{
+		{
+			desc:  "decode all fields",
+			data:  frame,
+			fPort: 1,
+			records: []record{
+				{name: "on", bool: &on},
+				{name: "level", val: 200},
+				{name: "delta", val: -1},
+				{name: "temp", unit: "Cel", val: -20},
+				{name: "count", val: 0x1234},
+				{name: "energy", val: 4294967295},
+				{name: "offset", val: -2},
+				{name: "ratio", val: 0.5},
+			},
+		},
+		{
+			desc:    "decode fields of other fPort",
+			data:    []byte{0x07},
+			fPort:   2,
+			records: []record{{name: "status", val: 7}},
+		},
+		{
+			desc:  "decode truncated frame",
+			data:  frame[:len(frame)-1],
+			fPort: 1,
+			err:   lora.ErrMalformedMessage,
+		},
+		{
+			desc:  "decode empty frame",
+			data:  []byte{},
+			fPort: 2,
+			err:   lora.ErrMalformedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		records, err := c.Decode(tc.data, tc.fPort)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assertRecords(t, tc.desc, tc.records, records)
+	}
+}
+
+func TestLayoutBounds(t *testing.T) {
+	cases := []struct {
+		desc  string
+		field lora.Field
+		data  []byte
+		err   error
+	}{
+		{
+			desc:  "field at the end of frame",
+			field: lora.Field{Name: "v", Offset: 2, Type: "uint16"},
+			data:  []byte{0x00, 0x00, 0x00, 0x01},
+			err:   nil,
+		},
+		{
+			desc:  "field past the end of frame",
+			field: lora.Field{Name: "v", Offset: 3, Type: "uint16"},
+			data:  []byte{0x00, 0x00, 0x00, 0x01},
+			err:   lora.ErrMalformedMessage,
+		},
+		{
+			desc:  "field at offset beyond frame",
+			field: lora.Field{Name: "v", Offset: 10, Type: "uint8"},
+			data:  []byte{0x00},
+			err:   lora.ErrMalformedMessage,
+		},
+		{
+			desc:  "field at max offset",
+			field: lora.Field{Name: "v", Offset: math.MaxInt64, Type: "uint32"},
+			data:  []byte{0x00, 0x00, 0x00, 0x01},
+			err:   lora.ErrMalformedMessage,
+		},
+		{
+			desc:  "field larger than frame",
+			field: lora.Field{Name: "v", Offset: 0, Type: "float32"},
+			data:  []byte{0x00, 0x00},
+			err:   lora.ErrMalformedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := lora.NewCodec(lora.CodecConfig{Type: lora.Layout, Layout: []lora.Field{tc.field}})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		_, err = c.Decode(tc.data, 1)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  lora.CodecConfig
+		err  error
+	}{
+		{
+			desc: "cayenne lpp codec",
+			cfg:  lora.CodecConfig{Type: lora.CayenneLPP},
+			err:  nil,
+		},
+		{
+			desc: "layout codec",
+			cfg:  lora.CodecConfig{Type: lora.Layout, Layout: []lora.Field{{Name: "v", Type: "uint8"}}},
+			err:  nil,
+		},
+		{
+			desc: "layout codec without fields",
+			cfg:  lora.CodecConfig{Type: lora.Layout},
+			err:  lora.ErrUnsupportedCodec,
+		},
+		{
+			desc: "layout codec with unknown field type",
+			cfg:  lora.CodecConfig{Type: lora.Layout, Layout: []lora.Field{{Name: "v", Type: "uint64"}}},
+			err:  lora.ErrUnsupportedCodec,
+		},
+		{
+			desc: "layout codec with unnamed field",
+			cfg:  lora.CodecConfig{Type: lora.Layout, Layout: []lora.Field{{Type: "uint8"}}},
+			err:  lora.ErrUnsupportedCodec,
+		},
+		{
+			desc: "layout codec with negative offset",
+			cfg:  lora.CodecConfig{Type: lora.Layout, Layout: []lora.Field{{Name: "v", Offset: -1, Type: "uint8"}}},
+			err:  lora.ErrUnsupportedCodec,
+		},
+		{
+			desc: "unknown codec",
+			cfg:  lora.CodecConfig{Type: "unknown"},
+			err:  lora.ErrUnsupportedCodec,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := lora.NewCodec(tc.cfg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.err, err))
+	}
+}
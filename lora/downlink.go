@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+// DownlinkPublisher publishes downlink messages to LoRa Server.
+type DownlinkPublisher interface {
+	// Publish publishes downlink message to the device of the application.
+	Publish(appID, devEUI string, msg DownlinkMessage) error
+}
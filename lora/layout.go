@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/mainflux/senml"
+)
+
+// Layout codec field types and their sizes in bytes.
+var fieldSizes = map[string]int{
+	"bool":    1,
+	"uint8":   1,
+	"int8":    1,
+	"uint16":  2,
+	"int16":   2,
+	"uint32":  4,
+	"int32":   4,
+	"float32": 4,
+}
+
+var _ Codec = (*layoutCodec)(nil)
+
+type layoutCodec struct {
+	fields []Field
+}
+
+func newLayoutCodec(fields []Field) (Codec, error) {
+	if len(fields) == 0 {
+		return nil, ErrUnsupportedCodec
+	}
+
+	for _, f := range fields {
+		if _, ok := fieldSizes[f.Type]; !ok || f.Name == "" || f.Offset < 0 {
+			return nil, ErrUnsupportedCodec
+		}
+	}
+
+	return layoutCodec{fields: fields}, nil
+}
+
+// Decode decodes the fields of the layout received on the given fPort.
+func (lc layoutCodec) Decode(data []byte, fPort int) ([]senml.Record, error) {
+	records := []senml.Record{}
+	for _, f := range lc.fields {
+		if f.FPort != 0 && f.FPort != fPort {
+			continue
+		}
+
+		// Offset is compared to the remaining size, so the huge offset
+		// can't overflow the end of the field.
+		size := fieldSizes[f.Type]
+		if f.Offset > len(data)-size {
+			return nil, ErrMalformedMessage
+		}
+		b := data[f.Offset : f.Offset+size]
+
+		var order binary.ByteOrder = binary.BigEndian
+		if f.LittleEndian {
+			order = binary.LittleEndian
+		}
+
+		if f.Type == "bool" {
+			val := b[0] != 0
+			records = append(records, senml.Record{Name: f.Name, Unit: f.Unit, BoolValue: &val})
+			continue
+		}
+
+		var val float64
+		switch f.Type {
+		case "uint8":
+			val = float64(b[0])
+		case "int8":
+			val = float64(int8(b[0]))
+		case "uint16":
+			val = float64(order.Uint16(b))
+		case "int16":
+			val = float64(int16(order.Uint16(b)))
+		case "uint32":
+			val = float64(order.Uint32(b))
+		case "int32":
+			val = float64(int32(order.Uint32(b)))
+		case "float32":
+			val = float64(math.Float32frombits(order.Uint32(b)))
+		}
+
+		if f.Scale != 0 {
+			val *= f.Scale
+		}
+
+		records = append(records, senml.Record{Name: f.Name, Unit: f.Unit, Value: &val})
+	}
+
+	return records, nil
+}
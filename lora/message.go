@@ -3,6 +3,7 @@ package lora
 // RxInfo receiver parameters
 type RxInfo []struct {
 	Mac       string  `json:"mac"`
+	GatewayID string  `json:"gatewayID"`
 	Name      string  `json:"name"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
@@ -42,3 +43,55 @@ type Message struct {
 	Data                string      `json:"data"`
 	Object              interface{} `json:"object"`
 }
+
+// DownlinkMessage lora downlink msg (www.loraserver.io/lora-app-server/integrate/sending-receiving/mqtt/)
+type DownlinkMessage struct {
+	Confirmed bool        `json:"confirmed"`
+	FPort     int         `json:"fPort"`
+	Data      string      `json:"data,omitempty"`
+	Object    interface{} `json:"object,omitempty"`
+}
+
+// AckMessage lora downlink acknowledgement msg
+type AckMessage struct {
+	ApplicationID   string `json:"applicationID"`
+	ApplicationName string `json:"applicationName"`
+	DeviceName      string `json:"deviceName"`
+	DevEUI          string `json:"devEUI"`
+	Acknowledged    bool   `json:"acknowledged"`
+	FCnt            int    `json:"fCnt"`
+}
+
+// ErrorMessage lora error msg
+type ErrorMessage struct {
+	ApplicationID   string `json:"applicationID"`
+	ApplicationName string `json:"applicationName"`
+	DeviceName      string `json:"deviceName"`
+	DevEUI          string `json:"devEUI"`
+	Type            string `json:"type"`
+	Error           string `json:"error"`
+	FCnt            int    `json:"fCnt"`
+}
+
+// JoinMessage lora device join msg
+type JoinMessage struct {
+	ApplicationID   string `json:"applicationID"`
+	ApplicationName string `json:"applicationName"`
+	DeviceName      string `json:"deviceName"`
+	DevEUI          string `json:"devEUI"`
+	DevAddr         string `json:"devAddr"`
+}
+
+// StatusMessage lora device status msg. Battery is reported by LoRa Server,
+// while ChirpStack v3 reports BatteryLevel in percents.
+type StatusMessage struct {
+	ApplicationID           string   `json:"applicationID"`
+	ApplicationName         string   `json:"applicationName"`
+	DeviceName              string   `json:"deviceName"`
+	DevEUI                  string   `json:"devEUI"`
+	Battery                 *float64 `json:"battery"`
+	BatteryLevel            *float64 `json:"batteryLevel"`
+	BatteryLevelUnavailable bool     `json:"batteryLevelUnavailable"`
+	ExternalPowerSource     bool     `json:"externalPowerSource"`
+	Margin                  float64  `json:"margin"`
+}
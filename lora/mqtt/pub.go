@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/mainflux/mainflux/lora"
+)
+
+const (
+	downlinkTopic           = "application/%s/device/%s/tx"
+	chirpStackDownlinkTopic = "application/%s/device/%s/command/down"
+)
+
+var _ lora.DownlinkPublisher = (*publisher)(nil)
+
+type publisher struct {
+	client mqtt.Client
+	topic  string
+}
+
+// NewPublisher returns new LoRa Server downlink publisher instance. If
+// chirpStack is set, downlinks are published on ChirpStack v3 topics.
+func NewPublisher(client mqtt.Client, chirpStack bool) lora.DownlinkPublisher {
+	topic := downlinkTopic
+	if chirpStack {
+		topic = chirpStackDownlinkTopic
+	}
+
+	return publisher{
+		client: client,
+		topic:  topic,
+	}
+}
+
+// Publish publishes downlink message to the LoRa Server MQTT broker
+func (p publisher) Publish(appID, devEUI string, msg lora.DownlinkMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	topic := fmt.Sprintf(p.topic, appID, devEUI)
+	t := p.client.Publish(topic, 0, false, payload)
+	if t.Wait() && t.Error() != nil {
+		return t.Error()
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/lora"
@@ -12,6 +13,17 @@ import (
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 )
 
+// LoRa Server and ChirpStack v3 events, given by the last topic level.
+const (
+	rxEvent     = "rx"
+	upEvent     = "up"
+	ackEvent    = "ack"
+	errorEvent  = "error"
+	joinEvent   = "join"
+	statusEvent = "status"
+	txAckEvent  = "txack"
+)
+
 // Subscriber represents the MQTT broker.
 type Subscriber interface {
 	// Subscribes to given subject and receives events.
@@ -45,12 +57,48 @@ func (b broker) Subscribe(subject string) error {
 
 // handleMsg triggered when new message is received on Lora MQTT broker
 func (b broker) handleMsg(c mqtt.Client, msg mqtt.Message) {
-	m := lora.Message{}
-	if err := json.Unmarshal(msg.Payload(), &m); err != nil {
-		b.logger.Warn(fmt.Sprintf("Failed to Unmarshal message: %s", err.Error()))
-		return
-	}
+	topic := msg.Topic()
+	event := topic[strings.LastIndex(topic, "/")+1:]
 
-	b.svc.Publish(context.Background(), "", m)
-	return
+	switch event {
+	case rxEvent, upEvent:
+		m := lora.Message{}
+		if err := json.Unmarshal(msg.Payload(), &m); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to Unmarshal message: %s", err.Error()))
+			return
+		}
+		b.svc.Publish(context.Background(), "", m)
+	case ackEvent:
+		m := lora.AckMessage{}
+		if err := json.Unmarshal(msg.Payload(), &m); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to Unmarshal ack message: %s", err.Error()))
+			return
+		}
+		b.svc.PublishAck(context.Background(), "", m)
+	case errorEvent:
+		m := lora.ErrorMessage{}
+		if err := json.Unmarshal(msg.Payload(), &m); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to Unmarshal error message: %s", err.Error()))
+			return
+		}
+		b.svc.PublishError(context.Background(), "", m)
+	case joinEvent:
+		m := lora.JoinMessage{}
+		if err := json.Unmarshal(msg.Payload(), &m); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to Unmarshal join message: %s", err.Error()))
+			return
+		}
+		b.svc.PublishJoin(context.Background(), "", m)
+	case statusEvent:
+		m := lora.StatusMessage{}
+		if err := json.Unmarshal(msg.Payload(), &m); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to Unmarshal status message: %s", err.Error()))
+			return
+		}
+		b.svc.PublishStatus(context.Background(), "", m)
+	case txAckEvent:
+		// Downlink acknowledgement by the gateway isn't forwarded.
+	default:
+		b.logger.Warn(fmt.Sprintf("Unsupported LoRa Server event on topic %s", topic))
+	}
 }
@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/lora"
+)
+
+var _ lora.CodecRepository = (*codecRepository)(nil)
+
+type codecRepository struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewCodecRepository returns redis Thing codec repository.
+func NewCodecRepository(client *redis.Client, prefix string) lora.CodecRepository {
+	return &codecRepository{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (cr *codecRepository) Save(thingID string, codec lora.CodecConfig) error {
+	key := fmt.Sprintf("%s:%s", cr.prefix, thingID)
+	if codec.Type == "" {
+		return cr.client.Del(key).Err()
+	}
+
+	data, err := json.Marshal(codec)
+	if err != nil {
+		return err
+	}
+
+	return cr.client.Set(key, data, 0).Err()
+}
+
+func (cr *codecRepository) Get(thingID string) (lora.CodecConfig, error) {
+	key := fmt.Sprintf("%s:%s", cr.prefix, thingID)
+	data, err := cr.client.Get(key).Bytes()
+	if err == redis.Nil {
+		return lora.CodecConfig{}, nil
+	}
+	if err != nil {
+		return lora.CodecConfig{}, err
+	}
+
+	var codec lora.CodecConfig
+	if err := json.Unmarshal(data, &codec); err != nil {
+		return lora.CodecConfig{}, err
+	}
+
+	return codec, nil
+}
+
+func (cr *codecRepository) Remove(thingID string) error {
+	key := fmt.Sprintf("%s:%s", cr.prefix, thingID)
+	return cr.client.Del(key).Err()
+}
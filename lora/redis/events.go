@@ -1,8 +1,11 @@
 package redis
 
+import "github.com/mainflux/mainflux/lora"
+
 type createThingEvent struct {
 	id         string
 	loraDevEUI string
+	codec      lora.CodecConfig
 }
 
 type removeThingEvent struct {
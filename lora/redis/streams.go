@@ -39,6 +39,8 @@ var (
 	errMetadataAppID = errors.New("application ID not found in channel metadatada")
 
 	errMetadataDevEUI = errors.New("device EUI not found in thing metadatada")
+
+	errMetadataCodec = errors.New("malformed codec in thing metadatada")
 )
 
 // Subscriber represents event source for things and channels provisioning.
@@ -157,6 +159,16 @@ func decodeCreateThing(event map[string]interface{}) (createThingEvent, error) {
 	}
 
 	cte.loraDevEUI = val
+
+	// Codec fields are decoded from the lora metadata as they are.
+	data, err := json.Marshal(lm)
+	if err != nil {
+		return createThingEvent{}, err
+	}
+	if err := json.Unmarshal(data, &cte.codec); err != nil {
+		return createThingEvent{}, errMetadataCodec
+	}
+
 	return cte, nil
 }
 
@@ -203,7 +215,7 @@ func decodeRemoveChannel(event map[string]interface{}) removeChannelEvent {
 }
 
 func (es eventStore) handleCreateThing(cte createThingEvent) error {
-	return es.svc.CreateThing(cte.id, cte.loraDevEUI)
+	return es.svc.CreateThing(cte.id, cte.loraDevEUI, cte.codec)
 }
 
 func (es eventStore) handleRemoveThing(rte removeThingEvent) error {
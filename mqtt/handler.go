@@ -4,25 +4,47 @@
 package mqtt
 
 import (
-	"errors"
+	"encoding/base64"
+	"encoding/json"
 	"net/url"
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/mqtt/redis"
 	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mproxy/pkg/session"
+	"github.com/mainflux/senml"
 )
 
-var _ session.Handler = (*handler)(nil)
+var _ Handler = (*handler)(nil)
 
-const protocol = "mqtt"
+const (
+	protocol = "mqtt"
+
+	// EventsSubtopic is the system Channel subtopic of MQTT session events.
+	// Clients aren't allowed to publish to it or any of its subtopics.
+	EventsSubtopic = messaging.EventsSubtopic
+
+	// DisconnectSubtopic is the Channel subtopic of client disconnects.
+	DisconnectSubtopic = EventsSubtopic + ".disconnect"
+
+	// WillSubtopic is the Channel subtopic of client Last Will messages.
+	WillSubtopic = EventsSubtopic + ".will"
+
+	// RejectedSubtopic is the Channel subtopic of the rejections of messages
+	// which don't conform to the Channel payload schema.
+	RejectedSubtopic = EventsSubtopic + ".rejected"
+)
 
 var (
-	channelRegExp         = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
+	channelRegExp         = regexp.MustCompile(`^\/?channels\/(@?[\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
 	errMalformedTopic     = errors.New("malformed topic")
 	errMalformedData      = errors.New("malformed request data")
 	errMalformedSubtopic  = errors.New("malformed subtopic")
@@ -31,24 +53,39 @@ var (
 	errInvalidConnect     = errors.New("CONNECT request with invalid username or client ID")
 	errNilTopicPub        = errors.New("PUBLISH to nil topic")
 	errNilTopicSub        = errors.New("SUB to nil topic")
+	errReservedSubtopic   = errors.New("PUBLISH to reserved subtopic")
 )
 
+// Handler extends mProxy session handler with the hook called at the end
+// of the session.
+type Handler interface {
+	session.Handler
+
+	// End is called once the session with the client is over, with the
+	// session details inspected by the proxy.
+	End(c *session.Client, s Session)
+}
+
 // Event implements events.Event interface
 type handler struct {
 	publishers []messaging.Publisher
 	auth       auth.Client
+	limiter    auth.Limiter
+	schemas    auth.Schemas
 	logger     logger.Logger
 	es         redis.EventStore
 }
 
 // NewHandler creates new Handler entity
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client) session.Handler {
+	logger logger.Logger, auth auth.Client, limiter auth.Limiter, schemas auth.Schemas) Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
+		limiter:    limiter,
+		schemas:    schemas,
 	}
 }
 
@@ -84,8 +121,29 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 	if topic == nil {
 		return errNilTopicPub
 	}
+	if reservedTopic(*topic) {
+		return errReservedSubtopic
+	}
+
+	if err := h.authAccess(c.Username, *topic, things.PublishAccess); err != nil {
+		return err
+	}
 
-	return h.authAccess(c.Username, *topic)
+	// MQTT 3.1.1 has no way to reject the PUBLISH packet, so the client
+	// which exceeds its limits is disconnected.
+	if err := h.limiter.Allow(c.Username); err != nil {
+		h.logger.Warn("Disconnecting client " + c.ID + ": " + err.Error())
+		return err
+	}
+
+	// The same applies to the payload which doesn't conform to the channel
+	// schema, with the reason published to the rejections subtopic.
+	if err := h.validate(c, *topic, payload); err != nil {
+		h.logger.Warn("Disconnecting client " + c.ID + ": " + err.Error())
+		return err
+	}
+
+	return nil
 }
 
 // AuthSubscribe is called on device publish,
@@ -99,7 +157,7 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
-		if err := h.authAccess(c.Username, v); err != nil {
+		if err := h.authAccess(c.Username, v, things.SubscribeAccess); err != nil {
 			return err
 		}
 
@@ -183,12 +241,134 @@ func (h *handler) Disconnect(c *session.Client) {
 		return
 	}
 	h.logger.Info("Disconnect - Client with ID: " + c.ID + " and username " + c.Username + " disconnected")
-	if err := h.es.Disconnect(c.Username); err != nil {
+}
+
+// End - session with the client is over
+func (h *handler) End(c *session.Client, s Session) {
+	if c == nil {
+		h.logger.Error("Nil client session end")
+		return
+	}
+	if err := h.es.Disconnect(c.Username, s.CleanDisconnect); err != nil {
 		h.logger.Warn("Failed to publish disconnect event: " + err.Error())
 	}
+
+	// Session events are published only on behalf of the authenticated thing.
+	thid, err := h.auth.Identify(string(c.Password))
+	if err != nil || thid != c.Username {
+		return
+	}
+
+	now := time.Now()
+	channels := map[string]bool{}
+	for _, t := range s.Topics {
+		if chanID, _, err := parseTopic(t); err == nil {
+			channels[chanID] = true
+		}
+	}
+	for chanID := range channels {
+		records := []senml.Record{
+			{Name: "clientID", StringValue: &c.ID},
+			{Name: "clean", BoolValue: &s.CleanDisconnect},
+			{Name: "cleanSession", BoolValue: &s.CleanSession},
+		}
+		h.publishEvent(thid, chanID, DisconnectSubtopic, records, now)
+	}
+
+	// The broker discards the Last Will on clean disconnect.
+	if s.Will == nil || s.CleanDisconnect {
+		return
+	}
+
+	chanID, subtopic, err := parseTopic(s.Will.Topic)
+	if err != nil {
+		h.logger.Info("Invalid Last Will topic " + s.Will.Topic + ": " + err.Error())
+		return
+	}
+	qos := float64(s.Will.QoS)
+	records := []senml.Record{
+		{Name: "clientID", StringValue: &c.ID},
+		{Name: "subtopic", StringValue: &subtopic},
+		{Name: "qos", Value: &qos},
+		{Name: "retain", BoolValue: &s.Will.Retain},
+		payloadRecord(s.Will.Payload),
+	}
+	h.publishEvent(thid, chanID, WillSubtopic, records, now)
 }
 
-func (h *handler) authAccess(username string, topic string) error {
+// publishEvent publishes session event of the thing as SenML on the given
+// system subtopic of the Channel. The event is published only if the thing
+// is allowed to publish to the Channel.
+func (h *handler) publishEvent(thingID, chanID, subtopic string, records []senml.Record, now time.Time) {
+	if err := h.auth.Authorize(chanID, thingID, things.PublishAccess); err != nil {
+		return
+	}
+
+	records[0].BaseTime = float64(now.Unix())
+	payload, err := json.Marshal(records)
+	if err != nil {
+		h.logger.Warn("Failed to encode session event: " + err.Error())
+		return
+	}
+
+	msg := messaging.Message{
+		Protocol:  protocol,
+		Channel:   chanID,
+		Subtopic:  subtopic,
+		Publisher: thingID,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+
+	for _, pub := range h.publishers {
+		if err := pub.Publish(msg.Channel, msg); err != nil {
+			h.logger.Info("Error publishing session event to Mainflux " + err.Error())
+		}
+	}
+}
+
+// validate checks whether the payload conforms to the channel schema. The
+// rejection is published on behalf of the client thing.
+func (h *handler) validate(c *session.Client, topic string, payload *[]byte) error {
+	chanID, subtopic, err := parseTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	var pld []byte
+	if payload != nil {
+		pld = *payload
+	}
+
+	err = h.schemas.Validate(chanID, pld)
+	if err == nil || !errors.Contains(err, schema.ErrInvalidPayload) {
+		return err
+	}
+
+	reason := err.Error()
+	records := []senml.Record{
+		{Name: "clientID", StringValue: &c.ID},
+		{Name: "subtopic", StringValue: &subtopic},
+		{Name: "error", StringValue: &reason},
+	}
+	h.publishEvent(c.Username, chanID, RejectedSubtopic, records, time.Now())
+
+	return err
+}
+
+// payloadRecord returns the record of the Last Will payload. Payloads
+// which aren't valid UTF-8 are stored base64 encoded as data value.
+func payloadRecord(payload []byte) senml.Record {
+	if utf8.Valid(payload) {
+		v := string(payload)
+		return senml.Record{Name: "payload", StringValue: &v}
+	}
+
+	v := base64.StdEncoding.EncodeToString(payload)
+	return senml.Record{Name: "payload", DataValue: &v}
+}
+
+func (h *handler) authAccess(username, topic, access string) error {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.Match([]byte(topic)) {
@@ -202,7 +382,32 @@ func (h *handler) authAccess(username string, topic string) error {
 	}
 
 	chanID := channelParts[1]
-	return h.auth.Authorize(chanID, username)
+	return h.auth.Authorize(chanID, username, access)
+}
+
+// parseTopic returns the Channel ID and the subtopic of the topic.
+func parseTopic(topic string) (string, string, error) {
+	channelParts := channelRegExp.FindStringSubmatch(topic)
+	if len(channelParts) < 1 {
+		return "", "", errMalformedTopic
+	}
+
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return "", "", err
+	}
+
+	return channelParts[1], subtopic, nil
+}
+
+// reservedTopic checks if the topic points to the system events subtopic.
+func reservedTopic(topic string) bool {
+	_, subtopic, err := parseTopic(topic)
+	if err != nil {
+		return false
+	}
+
+	return messaging.Reserved(subtopic)
 }
 
 func parseSubtopic(subtopic string) (string, error) {
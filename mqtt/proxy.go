@@ -0,0 +1,224 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+)
+
+// Proxy is MQTT proxy which, unlike the mProxy one, inspects the session
+// with the client and reports it to the handler once the session is over.
+// Proxy also resolves channel aliases used in the session topics.
+type Proxy struct {
+	address string
+	target  string
+	handler Handler
+	aliases auth.Aliases
+	logger  logger.Logger
+	dialer  net.Dialer
+}
+
+// NewProxy returns a new MQTT proxy instance.
+func NewProxy(address, target string, handler Handler, aliases auth.Aliases, logger logger.Logger) *Proxy {
+	return &Proxy{
+		address: address,
+		target:  target,
+		handler: handler,
+		aliases: aliases,
+		logger:  logger,
+	}
+}
+
+// Proxy starts accepting client connections. This will block.
+func (p Proxy) Proxy() error {
+	l, err := net.Listen("tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			p.logger.Warn("Accept error " + err.Error())
+			continue
+		}
+
+		p.logger.Info("Accepted new client")
+		go p.handle(conn)
+	}
+}
+
+func (p Proxy) handle(inbound net.Conn) {
+	outbound, err := p.dialer.Dial("tcp", p.target)
+	if err != nil {
+		p.logger.Error("Cannot connect to remote broker " + p.target + " due to: " + err.Error())
+		closeConn(inbound, p.logger)
+		return
+	}
+
+	stream(inbound, outbound, p.handler, p.aliases, p.logger)
+}
+
+// WSProxy is MQTT over WebSocket proxy which inspects the session with the
+// client the same way Proxy does.
+type WSProxy struct {
+	target  string
+	path    string
+	scheme  string
+	handler Handler
+	aliases auth.Aliases
+	logger  logger.Logger
+}
+
+// NewWSProxy returns a new MQTT over WebSocket proxy instance.
+func NewWSProxy(target, path, scheme string, handler Handler, aliases auth.Aliases, logger logger.Logger) *WSProxy {
+	return &WSProxy{
+		target:  target,
+		path:    path,
+		scheme:  scheme,
+		handler: handler,
+		aliases: aliases,
+		logger:  logger,
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	// Timeout for WS upgrade request handshake
+	HandshakeTimeout: 10 * time.Second,
+	// Paho JS client expecting header Sec-WebSocket-Protocol:mqtt in Upgrade response during handshake.
+	Subprotocols: []string{"mqtt"},
+	// Allow CORS
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// Handler returns HTTP handler which proxies MQTT over WebSocket traffic.
+func (p WSProxy) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		in, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			p.logger.Error("Error upgrading connection " + err.Error())
+			return
+		}
+
+		go p.pass(in)
+	})
+}
+
+func (p WSProxy) pass(in *websocket.Conn) {
+	u := url.URL{
+		Scheme: p.scheme,
+		Host:   p.target,
+		Path:   p.path,
+	}
+
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"mqtt"},
+	}
+	srv, _, err := dialer.Dial(u.String(), nil)
+	if err != nil {
+		p.logger.Error("Unable to connect to broker, reason: " + err.Error())
+		in.Close()
+		return
+	}
+
+	stream(newWSConn(in), newWSConn(srv), p.handler, p.aliases, p.logger)
+}
+
+// stream proxies the session between the client and the broker and, once
+// the session is over, passes the inspected session details to the handler.
+func stream(client, broker net.Conn, h Handler, aliases auth.Aliases, logger logger.Logger) {
+	ta := newTopicAliases(aliases)
+	inbound := newSessionConn(client, ta)
+	outbound := newBrokerConn(broker, ta)
+
+	s := session.New(inbound, outbound, h, logger)
+	if err := s.Stream(); !errors.Contains(err, io.EOF) {
+		logger.Warn("Broken connection for client: " + s.Client.ID + " with error: " + err.Error())
+	}
+
+	closeConn(inbound, logger)
+	closeConn(outbound, logger)
+
+	h.End(&s.Client, inbound.Session())
+}
+
+func closeConn(conn net.Conn, logger logger.Logger) {
+	if err := conn.Close(); err != nil {
+		logger.Warn(fmt.Sprintf("Error closing connection %s", err.Error()))
+	}
+}
+
+// wsConn wraps WebSocket connection so it satisfies the net.Conn interface.
+type wsConn struct {
+	*websocket.Conn
+	r   io.Reader
+	rio sync.Mutex
+	wio sync.Mutex
+}
+
+func newWSConn(ws *websocket.Conn) net.Conn {
+	return &wsConn{
+		Conn: ws,
+	}
+}
+
+// SetDeadline sets both the read and write deadlines.
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+// Write writes data to the WebSocket as a single binary message.
+func (c *wsConn) Write(p []byte) (int, error) {
+	c.wio.Lock()
+	defer c.wio.Unlock()
+
+	if err := c.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Read reads the current WebSocket message, advancing to the next one
+// once the current one is read.
+func (c *wsConn) Read(p []byte) (int, error) {
+	c.rio.Lock()
+	defer c.rio.Unlock()
+	for {
+		if c.r == nil {
+			var err error
+			_, c.r, err = c.NextReader()
+			if err != nil {
+				return 0, err
+			}
+		}
+		n, err := c.r.Read(p)
+		if err == io.EOF {
+			// At the end of the message, continue to the next one.
+			c.r = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
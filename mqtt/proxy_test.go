@@ -0,0 +1,160 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mproxy/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timeout = time.Second
+
+var _ Handler = (*handlerMock)(nil)
+
+// handlerMock allows all the session operations and reports the end of the
+// session.
+type handlerMock struct {
+	ends chan Session
+}
+
+func (h handlerMock) AuthConnect(*session.Client) error                   { return nil }
+func (h handlerMock) AuthPublish(*session.Client, *string, *[]byte) error { return nil }
+func (h handlerMock) AuthSubscribe(*session.Client, *[]string) error      { return nil }
+func (h handlerMock) Connect(*session.Client)                             {}
+func (h handlerMock) Publish(*session.Client, *string, *[]byte)           {}
+func (h handlerMock) Subscribe(*session.Client, *[]string)                {}
+func (h handlerMock) Unsubscribe(*session.Client, *[]string)              {}
+func (h handlerMock) Disconnect(*session.Client)                          {}
+func (h handlerMock) End(c *session.Client, s Session)                    { h.ends <- s }
+
+// proxy starts streaming the session, and returns the client and the broker
+// ends of the session.
+func proxy(t *testing.T, h Handler) (net.Conn, net.Conn) {
+	log, err := logger.New(ioutil.Discard, logger.Error.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	client, inbound := net.Pipe()
+	outbound, broker := net.Pipe()
+	go stream(inbound, outbound, h, newAliases(), log)
+
+	return client, broker
+}
+
+func TestStream(t *testing.T) {
+	will := &Will{
+		Topic:   willTopic,
+		Payload: []byte("offline"),
+		QoS:     1,
+		Retain:  true,
+	}
+
+	cases := []struct {
+		desc    string
+		packets []packets.ControlPacket
+		session Session
+	}{
+		{
+			desc:    "session with will ended by lost connection",
+			packets: []packets.ControlPacket{connectPacket(true, false), publishPacket(willTopic)},
+			session: Session{Will: will, Topics: []string{willTopic}},
+		},
+		{
+			desc:    "session with will ended by disconnect",
+			packets: []packets.ControlPacket{connectPacket(true, true), publishPacket(willTopic), disconnectPacket()},
+			session: Session{Will: will, CleanSession: true, CleanDisconnect: true, Topics: []string{willTopic}},
+		},
+	}
+
+	for _, tc := range cases {
+		h := handlerMock{ends: make(chan Session, 1)}
+		client, broker := proxy(t, h)
+
+		received := make(chan int)
+		go func() {
+			n := 0
+			for {
+				if _, err := packets.ReadPacket(broker); err != nil {
+					received <- n
+					return
+				}
+				n++
+			}
+		}()
+
+		for _, p := range tc.packets {
+			err := p.Write(client)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		}
+		client.Close()
+
+		select {
+		case s := <-h.ends:
+			assert.Equal(t, tc.session, s, fmt.Sprintf("%s: expected session %+v got %+v\n", tc.desc, tc.session, s))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("%s: expected session to end", tc.desc))
+		}
+		n := <-received
+		assert.Equal(t, len(tc.packets), n, fmt.Sprintf("%s: expected broker to receive %d packets got %d\n", tc.desc, len(tc.packets), n))
+	}
+}
+
+func TestStreamRestoreAlias(t *testing.T) {
+	h := handlerMock{ends: make(chan Session, 1)}
+	client, broker := proxy(t, h)
+	defer client.Close()
+
+	received := make(chan packets.ControlPacket)
+	go func() {
+		for {
+			p, err := packets.ReadPacket(broker)
+			if err != nil {
+				return
+			}
+			received <- p
+		}
+	}()
+
+	aliasTopic := "channels/" + chanAlias + "/messages"
+	for _, p := range []packets.ControlPacket{connectPacket(false, false), subscribePacket(aliasTopic)} {
+		err := p.Write(client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		// Packets are passed on to the broker once they're inspected.
+		<-received
+	}
+
+	cases := []struct {
+		desc  string
+		topic string
+		recv  string
+	}{
+		{
+			desc:  "deliver message of channel subscribed to by alias",
+			topic: "channels/" + chanID + "/messages",
+			recv:  aliasTopic,
+		},
+		{
+			desc:  "deliver message of other channel",
+			topic: "channels/other/messages",
+			recv:  "channels/other/messages",
+		},
+	}
+
+	for _, tc := range cases {
+		go publishPacket(tc.topic).Write(broker)
+		p, err := packets.ReadPacket(client)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		pub, ok := p.(*packets.PublishPacket)
+		require.True(t, ok, fmt.Sprintf("%s: expected PUBLISH packet", tc.desc))
+		assert.Equal(t, tc.recv, pub.TopicName, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, tc.recv, pub.TopicName))
+	}
+}
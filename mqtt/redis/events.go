@@ -16,13 +16,19 @@ type mqttEvent struct {
 	timestamp string
 	eventType string
 	instance  string
+	clean     bool
 }
 
 func (me mqttEvent) Encode() map[string]interface{} {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"thing_id":   me.clientID,
 		"timestamp":  me.timestamp,
 		"event_type": me.eventType,
 		"instance":   me.instance,
 	}
+	if me.eventType == "disconnect" {
+		val["clean"] = me.clean
+	}
+
+	return val
 }
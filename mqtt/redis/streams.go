@@ -30,7 +30,7 @@ func NewEventStore(client *redis.Client, instance string) EventStore {
 	}
 }
 
-func (es EventStore) storeEvent(clientID, eventType string) error {
+func (es EventStore) storeEvent(clientID, eventType string, clean bool) error {
 	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
 
 	event := mqttEvent{
@@ -38,6 +38,7 @@ func (es EventStore) storeEvent(clientID, eventType string) error {
 		timestamp: timestamp,
 		eventType: eventType,
 		instance:  es.instance,
+		clean:     clean,
 	}
 
 	record := &redis.XAddArgs{
@@ -55,10 +56,11 @@ func (es EventStore) storeEvent(clientID, eventType string) error {
 
 // Connect issues event on MQTT CONNECT
 func (es EventStore) Connect(clientID string) error {
-	return es.storeEvent(clientID, "connect")
+	return es.storeEvent(clientID, "connect", false)
 }
 
-// Disconnect issues event on MQTT CONNECT
-func (es EventStore) Disconnect(clientID string) error {
-	return es.storeEvent(clientID, "disconnect")
+// Disconnect issues event on the end of MQTT session. Clean disconnect
+// means the client sent DISCONNECT before closing the connection.
+func (es EventStore) Disconnect(clientID string, clean bool) error {
+	return es.storeEvent(clientID, "disconnect", clean)
 }
@@ -0,0 +1,226 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"bytes"
+	"net"
+	"sync"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/mainflux/mainflux/pkg/auth"
+)
+
+// Will represents the Last Will and Testament of the MQTT client.
+type Will struct {
+	Topic   string
+	Payload []byte
+	QoS     byte
+	Retain  bool
+}
+
+// Session contains the details of the MQTT session which aren't exposed
+// through mProxy session hooks.
+type Session struct {
+	// Will is the Last Will of the client, or nil if the client didn't set it.
+	Will *Will
+
+	// CleanSession is the clean session flag of the CONNECT packet.
+	CleanSession bool
+
+	// CleanDisconnect is true if the client sent DISCONNECT before closing
+	// the connection. In that case, the broker discards the Last Will.
+	CleanDisconnect bool
+
+	// Topics contains all the topics the client published or subscribed to.
+	Topics []string
+}
+
+// sessionConn wraps the client connection and inspects the MQTT control
+// packets the client sends. Channel aliases in the packet topics are
+// replaced with channel IDs before the packets are passed on.
+type sessionConn struct {
+	net.Conn
+	buf     bytes.Buffer
+	aliases *topicAliases
+	mu      sync.Mutex
+	topics  map[string]bool
+	session Session
+}
+
+func newSessionConn(conn net.Conn, aliases *topicAliases) *sessionConn {
+	return &sessionConn{
+		Conn:    conn,
+		aliases: aliases,
+		topics:  make(map[string]bool),
+	}
+}
+
+// Read reads the next packet from the client connection, once the
+// previously read one is consumed.
+func (c *sessionConn) Read(b []byte) (int, error) {
+	if c.buf.Len() == 0 {
+		pkt, err := packets.ReadPacket(c.Conn)
+		if err != nil {
+			return 0, err
+		}
+		c.inspect(pkt)
+		if err := pkt.Write(&c.buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.buf.Read(b)
+}
+
+// Session returns the inspected session. It must be called once the
+// connection is closed.
+func (c *sessionConn) Session() Session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+func (c *sessionConn) inspect(pkt packets.ControlPacket) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch p := pkt.(type) {
+	case *packets.ConnectPacket:
+		c.aliases.setThing(p.Username)
+		c.session.CleanSession = p.CleanSession
+		if p.WillFlag {
+			p.WillTopic = c.aliases.resolve(p.WillTopic, false)
+			c.session.Will = &Will{
+				Topic:   p.WillTopic,
+				Payload: p.WillMessage,
+				QoS:     p.WillQos,
+				Retain:  p.WillRetain,
+			}
+		}
+	case *packets.PublishPacket:
+		p.TopicName = c.aliases.resolve(p.TopicName, false)
+		c.addTopic(p.TopicName)
+	case *packets.SubscribePacket:
+		for i, t := range p.Topics {
+			p.Topics[i] = c.aliases.resolve(t, true)
+			c.addTopic(p.Topics[i])
+		}
+	case *packets.UnsubscribePacket:
+		for i, t := range p.Topics {
+			p.Topics[i] = c.aliases.resolve(t, false)
+		}
+	case *packets.DisconnectPacket:
+		c.session.CleanDisconnect = true
+	}
+}
+
+func (c *sessionConn) addTopic(topic string) {
+	if c.topics[topic] {
+		return
+	}
+	c.topics[topic] = true
+	c.session.Topics = append(c.session.Topics, topic)
+}
+
+// brokerConn wraps the broker connection and restores the channel aliases
+// in the topics of the messages delivered to the client.
+type brokerConn struct {
+	net.Conn
+	buf     bytes.Buffer
+	aliases *topicAliases
+}
+
+func newBrokerConn(conn net.Conn, aliases *topicAliases) *brokerConn {
+	return &brokerConn{
+		Conn:    conn,
+		aliases: aliases,
+	}
+}
+
+// Read reads the next packet from the broker connection, once the
+// previously read one is consumed.
+func (c *brokerConn) Read(b []byte) (int, error) {
+	if c.buf.Len() == 0 {
+		pkt, err := packets.ReadPacket(c.Conn)
+		if err != nil {
+			return 0, err
+		}
+		if p, ok := pkt.(*packets.PublishPacket); ok {
+			p.TopicName = c.aliases.restore(p.TopicName)
+		}
+		if err := pkt.Write(&c.buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.buf.Read(b)
+}
+
+// topicAliases resolves channel aliases used in the topics of the session.
+// Aliases used in subscriptions are tracked, so the topics of the messages
+// delivered to the client contain the aliases the client subscribed to.
+type topicAliases struct {
+	aliases auth.Aliases
+	mu      sync.Mutex
+	thingID string
+	names   map[string]string
+}
+
+func newTopicAliases(aliases auth.Aliases) *topicAliases {
+	return &topicAliases{
+		aliases: aliases,
+		names:   make(map[string]string),
+	}
+}
+
+func (ta *topicAliases) setThing(thingID string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.thingID = thingID
+}
+
+// resolve replaces the channel alias in the topic with the channel ID.
+// Topics which can't be resolved are returned as they are, and rejected
+// by the handler afterwards.
+func (ta *topicAliases) resolve(topic string, track bool) string {
+	loc := channelRegExp.FindStringSubmatchIndex(topic)
+	if loc == nil {
+		return topic
+	}
+	name := topic[loc[2]:loc[3]]
+	if !auth.IsAlias(name) {
+		return topic
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	chanID, err := ta.aliases.ChannelID(ta.thingID, name)
+	if err != nil {
+		return topic
+	}
+	if track {
+		ta.names[chanID] = name
+	}
+
+	return topic[:loc[2]] + chanID + topic[loc[3]:]
+}
+
+// restore replaces the channel ID in the topic with the alias the client
+// subscribed to.
+func (ta *topicAliases) restore(topic string) string {
+	loc := channelRegExp.FindStringSubmatchIndex(topic)
+	if loc == nil {
+		return topic
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	name, ok := ta.names[topic[loc[2]:loc[3]]]
+	if !ok {
+		return topic
+	}
+
+	return topic[:loc[2]] + name + topic[loc[3]:]
+}
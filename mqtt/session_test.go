@@ -0,0 +1,188 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	thingID   = "thing-id"
+	thingKey  = "thing-key"
+	clientID  = "client-id"
+	chanID    = "123e4567-e89b-12d3-a456-000000000001"
+	chanAlias = "@sensors"
+	willTopic = "channels/" + chanID + "/messages/status"
+)
+
+var _ auth.Aliases = (*aliasesMock)(nil)
+
+// aliasesMock contains channel IDs by thing ID and channel alias.
+type aliasesMock map[string]string
+
+func (a aliasesMock) ChannelID(thingID, channel string) (string, error) {
+	if !auth.IsAlias(channel) {
+		return channel, nil
+	}
+	id, ok := a[thingID+":"+channel]
+	if !ok {
+		return "", errors.New("alias not found")
+	}
+	return id, nil
+}
+
+func newAliases() auth.Aliases {
+	return aliasesMock{thingID + ":" + chanAlias: chanID}
+}
+
+func connectPacket(will bool, cleanSession bool) *packets.ConnectPacket {
+	p := packets.NewControlPacket(packets.Connect).(*packets.ConnectPacket)
+	p.ProtocolName = "MQTT"
+	p.ProtocolVersion = 4
+	p.ClientIdentifier = clientID
+	p.UsernameFlag = true
+	p.Username = thingID
+	p.PasswordFlag = true
+	p.Password = []byte(thingKey)
+	p.CleanSession = cleanSession
+	if will {
+		p.WillFlag = true
+		p.WillTopic = willTopic
+		p.WillMessage = []byte("offline")
+		p.WillQos = 1
+		p.WillRetain = true
+	}
+	return p
+}
+
+func publishPacket(topic string) *packets.PublishPacket {
+	p := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+	p.TopicName = topic
+	p.Payload = []byte("payload")
+	return p
+}
+
+func subscribePacket(topics ...string) *packets.SubscribePacket {
+	p := packets.NewControlPacket(packets.Subscribe).(*packets.SubscribePacket)
+	p.MessageID = 1
+	p.Topics = topics
+	p.Qoss = make([]byte, len(topics))
+	return p
+}
+
+func disconnectPacket() packets.ControlPacket {
+	return packets.NewControlPacket(packets.Disconnect)
+}
+
+// inspect sends the packets through the session connection, and returns the
+// inspected session along with the packets passed on.
+func inspect(pkts ...packets.ControlPacket) (Session, []packets.ControlPacket) {
+	client, server := net.Pipe()
+	sc := newSessionConn(server, newTopicAliases(newAliases()))
+
+	go func() {
+		for _, p := range pkts {
+			p.Write(client)
+		}
+		client.Close()
+	}()
+
+	passed := []packets.ControlPacket{}
+	for {
+		p, err := packets.ReadPacket(sc)
+		if err != nil {
+			break
+		}
+		passed = append(passed, p)
+	}
+
+	return sc.Session(), passed
+}
+
+func TestSessionConn(t *testing.T) {
+	will := &Will{
+		Topic:   willTopic,
+		Payload: []byte("offline"),
+		QoS:     1,
+		Retain:  true,
+	}
+	aliasWill := connectPacket(true, false)
+	aliasWill.WillTopic = "channels/" + chanAlias + "/messages/status"
+	unknownAliasWill := connectPacket(true, false)
+	unknownAliasWill.WillTopic = "channels/@unknown/messages/status"
+	unknownWill := *will
+	unknownWill.Topic = unknownAliasWill.WillTopic
+
+	cases := []struct {
+		desc    string
+		packets []packets.ControlPacket
+		session Session
+	}{
+		{
+			desc:    "connect with will and lose connection",
+			packets: []packets.ControlPacket{connectPacket(true, false)},
+			session: Session{Will: will},
+		},
+		{
+			desc:    "connect with will and disconnect",
+			packets: []packets.ControlPacket{connectPacket(true, false), disconnectPacket()},
+			session: Session{Will: will, CleanDisconnect: true},
+		},
+		{
+			desc:    "connect with clean session without will and disconnect",
+			packets: []packets.ControlPacket{connectPacket(false, true), disconnectPacket()},
+			session: Session{CleanSession: true, CleanDisconnect: true},
+		},
+		{
+			desc:    "connect with will to channel alias",
+			packets: []packets.ControlPacket{aliasWill},
+			session: Session{Will: will},
+		},
+		{
+			desc:    "connect with will to unknown channel alias",
+			packets: []packets.ControlPacket{unknownAliasWill},
+			session: Session{Will: &unknownWill},
+		},
+		{
+			desc: "publish and subscribe to channel topics",
+			packets: []packets.ControlPacket{
+				connectPacket(false, false),
+				publishPacket(willTopic),
+				publishPacket("channels/" + chanAlias + "/messages/status"),
+				subscribePacket("channels/"+chanID+"/messages", "channels/"+chanAlias+"/messages/status"),
+			},
+			session: Session{Topics: []string{willTopic, "channels/" + chanID + "/messages"}},
+		},
+	}
+
+	for _, tc := range cases {
+		s, passed := inspect(tc.packets...)
+		assert.Equal(t, tc.session, s, fmt.Sprintf("%s: expected session %+v got %+v\n", tc.desc, tc.session, s))
+		assert.Equal(t, len(tc.packets), len(passed), fmt.Sprintf("%s: expected %d packets passed on got %d\n", tc.desc, len(tc.packets), len(passed)))
+	}
+}
+
+func TestSessionConnResolve(t *testing.T) {
+	_, passed := inspect(
+		connectPacket(false, false),
+		publishPacket("channels/"+chanAlias+"/messages/status"),
+		subscribePacket("channels/"+chanAlias+"/messages", "channels/@unknown/messages"),
+	)
+
+	pub, ok := passed[1].(*packets.PublishPacket)
+	assert.True(t, ok, "expected PUBLISH packet to be passed on")
+	assert.Equal(t, willTopic, pub.TopicName, fmt.Sprintf("expected topic %s got %s\n", willTopic, pub.TopicName))
+
+	sub, ok := passed[2].(*packets.SubscribePacket)
+	assert.True(t, ok, "expected SUBSCRIBE packet to be passed on")
+	topics := []string{"channels/" + chanID + "/messages", "channels/@unknown/messages"}
+	assert.Equal(t, topics, sub.Topics, fmt.Sprintf("expected topics %v got %v\n", topics, sub.Topics))
+}
@@ -4,18 +4,50 @@
 package opcua
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/opcua/db"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/senml"
 )
 
-const protocol = "opcua"
+const (
+	protocol = "opcua"
+
+	// ResponseSubtopic is the Channel subtopic on which the results of
+	// writing to the OPC-UA Nodes are published.
+	ResponseSubtopic = "responses"
+
+	statusOK = "ok"
+
+	// historyBatchSize is the max number of SenML records published in a single message.
+	historyBatchSize = 100
+)
 
 var (
 	// ErrMalformedEntity indicates malformed entity specification.
 	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrNotFoundServerURI indicates missing route-map for the Channel.
+	ErrNotFoundServerURI = errors.New("route map not found for Server URI")
+
+	// ErrNotFoundNodeID indicates missing route-map for the Node.
+	ErrNotFoundNodeID = errors.New("route map not found for Node ID")
+
+	// ErrNotFoundConn indicates that the Node Thing isn't connected to the Channel.
+	ErrNotFoundConn = errors.New("connection not found")
+
+	// ErrWrite indicates failure to write one or more values to the OPC-UA Nodes.
+	ErrWrite = errors.New("failed to write to OPC-UA nodes")
+
+	// ErrHistoryTooLarge indicates that the Node history in the time range
+	// exceeds the max number of values read at once.
+	ErrHistoryTooLarge = errors.New("history exceeds max number of values")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -30,13 +62,16 @@ type Service interface {
 	// RemoveThing removes thingID:OPC-UA-nodeID route-map
 	RemoveThing(thingID string) error
 
-	// CreateChannel creates channelID:OPC-UA-serverURI route-map
-	CreateChannel(chanID, serverURI string) error
+	// CreateChannel creates channelID:OPC-UA-serverURI route-map and stores
+	// the Channel user identity
+	CreateChannel(chanID, serverURI string, auth UserAuth) error
 
-	// UpdateChannel updates channelID:OPC-UA-serverURI route-map
-	UpdateChannel(chanID, serverURI string) error
+	// UpdateChannel updates channelID:OPC-UA-serverURI route-map and the
+	// Channel user identity
+	UpdateChannel(chanID, serverURI string, auth UserAuth) error
 
-	// RemoveChannel removes channelID:OPC-UA-serverURI route-map
+	// RemoveChannel removes channelID:OPC-UA-serverURI route-map and the
+	// Channel user identity
 	RemoveChannel(chanID string) error
 
 	// ConnectThing creates thingID:channelID route-map
@@ -47,6 +82,16 @@ type Service interface {
 
 	// Browse browses available nodes for a given OPC-UA Server URI and NodeID
 	Browse(serverURI, namespace, identifier string) ([]BrowsedNode, error)
+
+	// Write writes SenML values of the message published on the Channel to the
+	// OPC-UA Nodes and publishes the results on the ResponseSubtopic. The Node
+	// is identified by the message subtopic or by the SenML record name.
+	Write(msg messaging.Message) error
+
+	// ReadHistory reads historical values of the Node of the connected Thing
+	// in the given time range and publishes them to the Channel as timestamped
+	// SenML. It returns the number of published values.
+	ReadHistory(chanID, thingID string, from, to time.Time) (int, error)
 }
 
 // Config OPC-UA Server
@@ -58,6 +103,7 @@ type Config struct {
 	Mode      string
 	CertFile  string
 	KeyFile   string
+	Auth      UserAuth
 }
 
 var _ Service = (*adapterService)(nil)
@@ -65,21 +111,29 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	subscriber Subscriber
 	browser    Browser
+	writer     Writer
+	history    HistoryReader
+	publisher  messaging.Publisher
 	thingsRM   RouteMapRepository
 	channelsRM RouteMapRepository
 	connectRM  RouteMapRepository
+	authRepo   UserAuthRepository
 	cfg        Config
 	logger     logger.Logger
 }
 
 // New instantiates the OPC-UA adapter implementation.
-func New(sub Subscriber, brow Browser, thingsRM, channelsRM, connectRM RouteMapRepository, cfg Config, log logger.Logger) Service {
+func New(sub Subscriber, brow Browser, wr Writer, hr HistoryReader, pub messaging.Publisher, thingsRM, channelsRM, connectRM RouteMapRepository, authRepo UserAuthRepository, cfg Config, log logger.Logger) Service {
 	return &adapterService{
 		subscriber: sub,
 		browser:    brow,
+		writer:     wr,
+		history:    hr,
+		publisher:  pub,
 		thingsRM:   thingsRM,
 		channelsRM: channelsRM,
 		connectRM:  connectRM,
+		authRepo:   authRepo,
 		cfg:        cfg,
 		logger:     log,
 	}
@@ -97,16 +151,25 @@ func (as *adapterService) RemoveThing(thingID string) error {
 	return as.thingsRM.Remove(thingID)
 }
 
-func (as *adapterService) CreateChannel(chanID, serverURI string) error {
-	return as.channelsRM.Save(chanID, serverURI)
+func (as *adapterService) CreateChannel(chanID, serverURI string, auth UserAuth) error {
+	if err := as.channelsRM.Save(chanID, serverURI); err != nil {
+		return err
+	}
+	return as.authRepo.Save(chanID, auth)
 }
 
-func (as *adapterService) UpdateChannel(chanID, serverURI string) error {
-	return as.channelsRM.Save(chanID, serverURI)
+func (as *adapterService) UpdateChannel(chanID, serverURI string, auth UserAuth) error {
+	if err := as.channelsRM.Save(chanID, serverURI); err != nil {
+		return err
+	}
+	return as.authRepo.Save(chanID, auth)
 }
 
 func (as *adapterService) RemoveChannel(chanID string) error {
-	return as.channelsRM.Remove(chanID)
+	if err := as.channelsRM.Remove(chanID); err != nil {
+		return err
+	}
+	return as.authRepo.Remove(chanID)
 }
 
 func (as *adapterService) ConnectThing(chanID, thingID string) error {
@@ -120,8 +183,15 @@ func (as *adapterService) ConnectThing(chanID, thingID string) error {
 		return err
 	}
 
-	as.cfg.NodeID = nodeID
-	as.cfg.ServerURI = serverURI
+	auth, err := as.authRepo.Get(chanID)
+	if err != nil {
+		return err
+	}
+
+	cfg := as.cfg
+	cfg.NodeID = nodeID
+	cfg.ServerURI = serverURI
+	cfg.Auth = auth
 
 	c := fmt.Sprintf("%s:%s", chanID, thingID)
 	if err := as.connectRM.Save(c, c); err != nil {
@@ -129,7 +199,7 @@ func (as *adapterService) ConnectThing(chanID, thingID string) error {
 	}
 
 	go func() {
-		if err := as.subscriber.Subscribe(as.cfg); err != nil {
+		if err := as.subscriber.Subscribe(cfg); err != nil {
 			as.logger.Warn(fmt.Sprintf("subscription failed: %s", err))
 		}
 	}()
@@ -139,9 +209,20 @@ func (as *adapterService) ConnectThing(chanID, thingID string) error {
 }
 
 func (as *adapterService) Browse(serverURI, namespace, identifier string) ([]BrowsedNode, error) {
-	nodeID := fmt.Sprintf("%s;%s", namespace, identifier)
+	cfg := as.cfg
+	cfg.ServerURI = serverURI
+	cfg.NodeID = fmt.Sprintf("%s;%s", namespace, identifier)
+
+	// Browse with the user identity of the Channel mapped to the Server, if any.
+	if chanID, err := as.channelsRM.Get(serverURI); err == nil {
+		auth, err := as.authRepo.Get(chanID)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Auth = auth
+	}
 
-	nodes, err := as.browser.Browse(serverURI, nodeID)
+	nodes, err := as.browser.Browse(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +230,264 @@ func (as *adapterService) Browse(serverURI, namespace, identifier string) ([]Bro
 }
 
 func (as *adapterService) DisconnectThing(chanID, thingID string) error {
+	serverURI, err := as.channelsRM.Get(chanID)
+	if err != nil {
+		return err
+	}
+
+	nodeID, err := as.thingsRM.Get(thingID)
+	if err != nil {
+		return err
+	}
+
+	auth, err := as.authRepo.Get(chanID)
+	if err != nil {
+		return err
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if err := as.connectRM.Remove(c); err != nil {
+		return err
+	}
+
+	cfg := as.cfg
+	cfg.ServerURI = serverURI
+	cfg.NodeID = nodeID
+	cfg.Auth = auth
+	if err := as.subscriber.Unsubscribe(cfg); err != nil {
+		return err
+	}
+
+	// Remove subscription details
+	return db.Remove(serverURI, nodeID)
+}
+
+func (as *adapterService) Write(msg messaging.Message) error {
+	serverURI, err := as.channelsRM.Get(msg.Channel)
+	if err != nil {
+		return ErrNotFoundServerURI
+	}
+
+	records, err := normalize(msg.Payload, msg.Subtopic)
+	if err != nil {
+		return ErrMalformedEntity
+	}
+
+	var errWrite error
+	for _, r := range records {
+		nodeID := r.Name
+		thingID, err := as.write(msg.Channel, serverURI, nodeID, r)
+		if err != nil {
+			errWrite = ErrWrite
+		}
+
+		if err := as.respond(msg.Channel, thingID, nodeID, err); err != nil {
+			as.logger.Warn(fmt.Sprintf("Failed to publish write response for node %s: %s", nodeID, err))
+		}
+	}
+
+	return errWrite
+}
+
+func (as *adapterService) write(chanID, serverURI, nodeID string, r senml.Record) (string, error) {
+	if nodeID == "" {
+		return "", ErrMalformedEntity
+	}
+
+	thingID, err := as.thingsRM.Get(nodeID)
+	if err != nil {
+		return "", ErrNotFoundNodeID
+	}
+
 	c := fmt.Sprintf("%s:%s", chanID, thingID)
-	return as.connectRM.Remove(c)
+	if _, err := as.connectRM.Get(c); err != nil {
+		return thingID, ErrNotFoundConn
+	}
+
+	var value interface{}
+	switch {
+	case r.Value != nil:
+		value = *r.Value
+	case r.BoolValue != nil:
+		value = *r.BoolValue
+	case r.StringValue != nil:
+		value = *r.StringValue
+	case r.DataValue != nil:
+		value = *r.DataValue
+	default:
+		return thingID, ErrMalformedEntity
+	}
+
+	auth, err := as.authRepo.Get(chanID)
+	if err != nil {
+		return thingID, err
+	}
+
+	cfg := as.cfg
+	cfg.ServerURI = serverURI
+	cfg.NodeID = nodeID
+	cfg.Auth = auth
+
+	return thingID, as.writer.Write(cfg, value)
+}
+
+// normalize decodes the SenML records and resolves their base fields, so the
+// record names are the NodeIDs to write to, or the given subtopic if set.
+// NodeIDs don't pass SenML name validation, so the names are hex encoded
+// while the records are normalized. Hex encoding preserves concatenation, so
+// the resolved names are the encoded NodeIDs.
+func normalize(payload []byte, subtopic string) ([]senml.Record, error) {
+	var records []senml.Record
+	if err := json.Unmarshal(payload, &records); err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		r := &records[i]
+		if subtopic != "" {
+			r.BaseName = ""
+			r.Name = subtopic
+		}
+		r.BaseName = hex.EncodeToString([]byte(r.BaseName))
+		r.Name = hex.EncodeToString([]byte(r.Name))
+	}
+
+	p, err := senml.Normalize(senml.Pack{Records: records})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range p.Records {
+		name, err := hex.DecodeString(p.Records[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		p.Records[i].Name = string(name)
+	}
+
+	return p.Records, nil
+}
+
+// respond publishes the result of writing to the Node as SenML record
+// with the Node ID as name and the write status as string value.
+func (as *adapterService) respond(chanID, thingID, nodeID string, err error) error {
+	status := statusOK
+	if err != nil {
+		status = err.Error()
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal([]senml.Record{
+		{
+			Name:        nodeID,
+			Time:        float64(now.Unix()),
+			StringValue: &status,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Publisher: thingID,
+		Protocol:  protocol,
+		Channel:   chanID,
+		Subtopic:  ResponseSubtopic,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+func (as *adapterService) ReadHistory(chanID, thingID string, from, to time.Time) (int, error) {
+	serverURI, err := as.channelsRM.Get(chanID)
+	if err != nil {
+		return 0, ErrNotFoundServerURI
+	}
+
+	nodeID, err := as.thingsRM.Get(thingID)
+	if err != nil {
+		return 0, ErrNotFoundNodeID
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if _, err := as.connectRM.Get(c); err != nil {
+		return 0, ErrNotFoundConn
+	}
+
+	auth, err := as.authRepo.Get(chanID)
+	if err != nil {
+		return 0, err
+	}
+
+	cfg := as.cfg
+	cfg.ServerURI = serverURI
+	cfg.NodeID = nodeID
+	cfg.Auth = auth
+
+	values, err := as.history.ReadHistory(cfg, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(values); i += historyBatchSize {
+		end := i + historyBatchSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		if err := as.publishHistory(chanID, thingID, nodeID, values[i:end]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(values), nil
+}
+
+func (as *adapterService) publishHistory(chanID, thingID, nodeID string, values []HistoryValue) error {
+	records := make([]senml.Record, len(values))
+	for i, v := range values {
+		r := senml.Record{
+			Name: v.Type,
+			Time: float64(v.Time.UnixNano()) / 1e9,
+		}
+
+		switch val := v.Data.(type) {
+		case bool:
+			r.BoolValue = &val
+		case string:
+			if v.DataKey == "vd" {
+				r.DataValue = &val
+				break
+			}
+			r.StringValue = &val
+		case float64:
+			r.Value = &val
+		case int64:
+			f := float64(val)
+			r.Value = &f
+		case int:
+			f := float64(val)
+			r.Value = &f
+		}
+
+		records[i] = r
+	}
+
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Publisher: thingID,
+		Protocol:  protocol,
+		Channel:   chanID,
+		Subtopic:  nodeID,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
+	return as.publisher.Publish(msg.Channel, msg)
 }
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		desc     string
+		payload  string
+		subtopic string
+		nodes    []string
+		values   []float64
+		err      bool
+	}{
+		{
+			desc:    "record with node name",
+			payload: `[{"n":"ns=2;i=1001","v":21.5}]`,
+			nodes:   []string{"ns=2;i=1001"},
+			values:  []float64{21.5},
+		},
+		{
+			desc:    "records with base name",
+			payload: `[{"bn":"ns=2;s=","n":"Boiler.Setpoint","v":21.5},{"n":"Boiler.Limit","v":80}]`,
+			nodes:   []string{"ns=2;s=Boiler.Setpoint", "ns=2;s=Boiler.Limit"},
+			values:  []float64{21.5, 80},
+		},
+		{
+			desc:    "records with base value",
+			payload: `[{"n":"ns=2;i=1001","bv":20,"v":1.5}]`,
+			nodes:   []string{"ns=2;i=1001"},
+			values:  []float64{21.5},
+		},
+		{
+			desc:    "records with changed base name",
+			payload: `[{"bn":"ns=2;s=A.","n":"X","v":1},{"bn":"ns=3;s=B.","n":"Y","v":2}]`,
+			nodes:   []string{"ns=2;s=A.X", "ns=3;s=B.Y"},
+			values:  []float64{1, 2},
+		},
+		{
+			desc:     "records with subtopic",
+			payload:  `[{"bn":"ignored","n":"name","v":21.5}]`,
+			subtopic: "ns=2;i=1001",
+			nodes:    []string{"ns=2;i=1001"},
+			values:   []float64{21.5},
+		},
+		{
+			desc:     "record without name with subtopic",
+			payload:  `[{"v":21.5}]`,
+			subtopic: "ns=2;i=1001",
+			nodes:    []string{"ns=2;i=1001"},
+			values:   []float64{21.5},
+		},
+		{
+			desc:    "record without name",
+			payload: `[{"v":21.5}]`,
+			err:     true,
+		},
+		{
+			desc:    "record with too many values",
+			payload: `[{"n":"ns=2;i=1001","v":21.5,"vb":true}]`,
+			err:     true,
+		},
+		{
+			desc:    "malformed payload",
+			payload: `{"n":"ns=2;i=1001"}`,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		records, err := normalize([]byte(tc.payload), tc.subtopic)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error: %v", tc.desc, err))
+		if tc.err {
+			continue
+		}
+		nodes := []string{}
+		values := []float64{}
+		for _, r := range records {
+			nodes = append(nodes, r.Name)
+			values = append(values, *r.Value)
+		}
+		assert.Equal(t, tc.nodes, nodes, fmt.Sprintf("%s: expected nodes %v got %v", tc.desc, tc.nodes, nodes))
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected values %v got %v", tc.desc, tc.values, values))
+	}
+}
@@ -30,3 +30,24 @@ func browseEndpoint(svc opcua.Service) endpoint.Endpoint {
 		return res, nil
 	}
 }
+
+func historyEndpoint(svc opcua.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(historyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		n, err := svc.ReadHistory(req.ChanID, req.ThingID, req.From, req.To)
+		if err != nil {
+			return nil, err
+		}
+
+		res := historyRes{
+			Published: n,
+		}
+
+		return res, nil
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ opcua.Service = (*loggingMiddleware)(nil)
@@ -65,7 +66,7 @@ func (lm loggingMiddleware) RemoveThing(mfxThing string) (err error) {
 	return lm.svc.RemoveThing(mfxThing)
 }
 
-func (lm loggingMiddleware) CreateChannel(mfxChan, opcuaServerURI string) (err error) {
+func (lm loggingMiddleware) CreateChannel(mfxChan, opcuaServerURI string, auth opcua.UserAuth) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("create_channel %s with ServerURI %s, took %s to complete", mfxChan, opcuaServerURI, time.Since(begin))
 		if err != nil {
@@ -75,10 +76,10 @@ func (lm loggingMiddleware) CreateChannel(mfxChan, opcuaServerURI string) (err e
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CreateChannel(mfxChan, opcuaServerURI)
+	return lm.svc.CreateChannel(mfxChan, opcuaServerURI, auth)
 }
 
-func (lm loggingMiddleware) UpdateChannel(mfxChanID, opcuaServerURI string) (err error) {
+func (lm loggingMiddleware) UpdateChannel(mfxChanID, opcuaServerURI string, auth opcua.UserAuth) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("update_channel %s with ServerURI %s, took %s to complete", mfxChanID, opcuaServerURI, time.Since(begin))
 		if err != nil {
@@ -88,7 +89,7 @@ func (lm loggingMiddleware) UpdateChannel(mfxChanID, opcuaServerURI string) (err
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.UpdateChannel(mfxChanID, opcuaServerURI)
+	return lm.svc.UpdateChannel(mfxChanID, opcuaServerURI, auth)
 }
 
 func (lm loggingMiddleware) RemoveChannel(mfxChanID string) (err error) {
@@ -142,3 +143,29 @@ func (lm loggingMiddleware) Browse(serverURI, namespace, identifier string) (nod
 
 	return lm.svc.Browse(serverURI, namespace, identifier)
 }
+
+func (lm loggingMiddleware) Write(msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("write message from channel %s to OPC-UA nodes, took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Write(msg)
+}
+
+func (lm loggingMiddleware) ReadHistory(chanID, thingID string, from, to time.Time) (n int, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("read_history of thing %s from channel %s between %s and %s, took %s to complete", thingID, chanID, from, to, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReadHistory(chanID, thingID, from, to)
+}
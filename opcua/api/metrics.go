@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ opcua.Service = (*metricsMiddleware)(nil)
@@ -54,22 +55,22 @@ func (mm *metricsMiddleware) RemoveThing(mfxDevID string) error {
 	return mm.svc.RemoveThing(mfxDevID)
 }
 
-func (mm *metricsMiddleware) CreateChannel(mfxChanID, opcuaServerURI string) error {
+func (mm *metricsMiddleware) CreateChannel(mfxChanID, opcuaServerURI string, auth opcua.UserAuth) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "create_channel").Add(1)
 		mm.latency.With("method", "create_channel").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.CreateChannel(mfxChanID, opcuaServerURI)
+	return mm.svc.CreateChannel(mfxChanID, opcuaServerURI, auth)
 }
 
-func (mm *metricsMiddleware) UpdateChannel(mfxChanID, opcuaServerURI string) error {
+func (mm *metricsMiddleware) UpdateChannel(mfxChanID, opcuaServerURI string, auth opcua.UserAuth) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "update_channel").Add(1)
 		mm.latency.With("method", "update_channel").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.UpdateChannel(mfxChanID, opcuaServerURI)
+	return mm.svc.UpdateChannel(mfxChanID, opcuaServerURI, auth)
 }
 
 func (mm *metricsMiddleware) RemoveChannel(mfxChanID string) error {
@@ -107,3 +108,21 @@ func (mm *metricsMiddleware) Browse(serverURI, namespace, identifier string) ([]
 
 	return mm.svc.Browse(serverURI, namespace, identifier)
 }
+
+func (mm *metricsMiddleware) Write(msg messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "write").Add(1)
+		mm.latency.With("method", "write").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Write(msg)
+}
+
+func (mm *metricsMiddleware) ReadHistory(chanID, thingID string, from, to time.Time) (int, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_history").Add(1)
+		mm.latency.With("method", "read_history").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadHistory(chanID, thingID, from, to)
+}
@@ -3,7 +3,11 @@
 
 package api
 
-import "github.com/mainflux/mainflux/opcua"
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/opcua"
+)
 
 type browseReq struct {
 	ServerURI  string
@@ -18,3 +22,22 @@ func (req *browseReq) validate() error {
 
 	return nil
 }
+
+type historyReq struct {
+	ChanID  string    `json:"chan_id"`
+	ThingID string    `json:"thing_id"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
+
+func (req *historyReq) validate() error {
+	if req.ChanID == "" || req.ThingID == "" {
+		return opcua.ErrMalformedEntity
+	}
+
+	if req.From.IsZero() || req.To.IsZero() || !req.From.Before(req.To) {
+		return opcua.ErrMalformedEntity
+	}
+
+	return nil
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/mainflux/mainflux/opcua"
 )
 
-var _ mainflux.Response = (*browseRes)(nil)
+var (
+	_ mainflux.Response = (*browseRes)(nil)
+	_ mainflux.Response = (*historyRes)(nil)
+)
 
 type browseRes struct {
 	Nodes []opcua.BrowsedNode `json:"nodes"`
@@ -27,3 +30,19 @@ func (res browseRes) Headers() map[string]string {
 func (res browseRes) Empty() bool {
 	return false
 }
+
+type historyRes struct {
+	Published int `json:"published"`
+}
+
+func (res historyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res historyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res historyRes) Empty() bool {
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -49,6 +50,13 @@ func MakeHandler(svc opcua.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/history", kithttp.NewServer(
+		historyEndpoint(svc),
+		decodeHistory,
+		encodeResponse,
+		opts...,
+	))
+
 	r.GetFunc("/version", mainflux.Version("opcua-adapter"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -85,6 +93,19 @@ func decodeBrowse(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeHistory(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	var req historyReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, opcua.ErrMalformedEntity
+	}
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -111,6 +132,12 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errInvalidQueryParams:
 		w.WriteHeader(http.StatusBadRequest)
+	case opcua.ErrHistoryTooLarge:
+		w.WriteHeader(http.StatusBadRequest)
+	case errUnsupportedContentType:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case opcua.ErrNotFoundServerURI, opcua.ErrNotFoundNodeID, opcua.ErrNotFoundConn:
+		w.WriteHeader(http.StatusNotFound)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -0,0 +1,27 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+// UserAuth represents the OPC-UA user identity token used to open the
+// session to the Channel Server. Anonymous identity is used if neither
+// username nor certificate is set. Certificate and key are PEM encoded.
+type UserAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Cert     string `json:"user_cert,omitempty"`
+	Key      string `json:"user_key,omitempty"`
+}
+
+// UserAuthRepository specifies Channel user identity persistence API.
+type UserAuthRepository interface {
+	// Save stores the user identity of the Channel.
+	Save(string, UserAuth) error
+
+	// Get returns the user identity of the Channel. Empty user identity
+	// is returned if the Channel has none stored.
+	Get(string) (UserAuth, error)
+
+	// Remove removes the user identity of the Channel.
+	Remove(string) error
+}
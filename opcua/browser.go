@@ -15,6 +15,6 @@ type BrowsedNode struct {
 
 // Browser represents the OPC-UA Server Nodes browser.
 type Browser interface {
-	// Browse availlable Nodes for a given URI.
-	Browse(string, string) ([]BrowsedNode, error)
+	// Browse availlable Nodes of the Server starting from the given NodeID.
+	Browse(Config) ([]BrowsedNode, error)
 }
@@ -41,6 +41,36 @@ func Save(serverURI, nodeID string) error {
 	return nil
 }
 
+// Remove removes the stored subscription
+func Remove(serverURI, nodeID string) error {
+	nodes, err := ReadAll()
+	if err != nil {
+		if errors.Contains(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return errors.Wrap(errWriteFile, err)
+	}
+	defer file.Close()
+
+	csvWriter := csv.NewWriter(file)
+	for _, n := range nodes {
+		if n.ServerURI == serverURI && n.NodeID == nodeID {
+			continue
+		}
+		if err := csvWriter.Write([]string{n.ServerURI, n.NodeID}); err != nil {
+			return errors.Wrap(errWriteFile, err)
+		}
+	}
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
 // ReadAll returns all stored subscriptions
 func ReadAll() ([]Node, error) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
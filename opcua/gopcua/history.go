@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"time"
+
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// historyPageSize is the max number of values the Server returns in a
+// single history read response.
+const historyPageSize = 1000
+
+var errFailedReadHistory = errors.New("failed to read history")
+
+var _ opcua.HistoryReader = (*historyReader)(nil)
+
+type historyReader struct {
+	ctx      context.Context
+	sessions *Sessions
+	logger   logger.Logger
+}
+
+// NewHistoryReader returns new OPC-UA history reader instance. History is
+// read using the given shared sessions.
+func NewHistoryReader(ctx context.Context, sessions *Sessions, log logger.Logger) opcua.HistoryReader {
+	return historyReader{
+		ctx:      ctx,
+		sessions: sessions,
+		logger:   log,
+	}
+}
+
+// ReadHistory reads raw historical values of the OPC-UA Server Node,
+// following continuation points until the whole time range is read. Values
+// are read in pages of historyPageSize values, up to opcua.MaxHistoryValues.
+func (hr historyReader) ReadHistory(cfg opcua.Config, from, to time.Time) ([]opcua.HistoryValue, error) {
+	nodeID, err := uaGopcua.ParseNodeID(cfg.NodeID)
+	if err != nil {
+		return nil, errors.Wrap(errFailedParseNodeID, err)
+	}
+
+	oc, release, err := hr.sessions.acquire(hr.ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	details := &uaGopcua.ReadRawModifiedDetails{
+		StartTime:        from,
+		EndTime:          to,
+		NumValuesPerNode: historyPageSize,
+	}
+	node := &uaGopcua.HistoryReadValueID{
+		NodeID: nodeID,
+	}
+
+	values := []opcua.HistoryValue{}
+	for {
+		res, err := oc.HistoryReadRawModified([]*uaGopcua.HistoryReadValueID{node}, details)
+		if err != nil {
+			return nil, errors.Wrap(errFailedReadHistory, err)
+		}
+		if len(res.Results) == 0 {
+			return nil, errResponseStatus
+		}
+
+		r := res.Results[0]
+		if r.StatusCode != uaGopcua.StatusOK {
+			return nil, errors.Wrap(errFailedReadHistory, r.StatusCode)
+		}
+
+		if r.HistoryData != nil {
+			if data, ok := r.HistoryData.Value.(*uaGopcua.HistoryData); ok {
+				for _, dv := range data.DataValues {
+					if dv.Value == nil {
+						continue
+					}
+					if len(values) == opcua.MaxHistoryValues {
+						// Continuation point is released by the Server
+						// once the session is closed or it times out.
+						return nil, opcua.ErrHistoryTooLarge
+					}
+					key, val := senmlValue(dv.Value)
+					values = append(values, opcua.HistoryValue{
+						Type:    dv.Value.Type().String(),
+						Time:    dv.SourceTimestamp,
+						DataKey: key,
+						Data:    val,
+					})
+				}
+			}
+		}
+
+		if len(r.ContinuationPoint) == 0 {
+			return values, nil
+		}
+		node.ContinuationPoint = r.ContinuationPoint
+	}
+}
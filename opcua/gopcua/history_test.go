@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHistory(t *testing.T) {
+	servers := newFakeServers()
+	sessions := newSessions(servers.dial)
+
+	log, err := logger.New(os.Stdout, logger.Error.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	sub := newSubscriber(context.Background(), sessions, nil, routeMap{}, routeMap{}, routeMap{}, log)
+	hr := NewHistoryReader(context.Background(), sessions, log)
+
+	servers.server(serverURI).history = 10
+	err = sub.Subscribe(config(serverURI, nodeID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	pagedURI := "opc.tcp://paged:4840"
+	servers.server(pagedURI).history = 2*historyPageSize + 1
+	largeURI := "opc.tcp://large:4840"
+	servers.server(largeURI).history = opcua.MaxHistoryValues + 1
+	servers.server("opc.tcp://down:4840").down = true
+
+	from := time.Now().Add(-time.Hour)
+	cases := []struct {
+		desc   string
+		uri    string
+		node   string
+		values int
+		reads  int
+		closed bool
+		err    error
+	}{
+		{
+			desc:   "read history of node of subscribed server",
+			uri:    serverURI,
+			node:   nodeID,
+			values: 10,
+			reads:  1,
+			closed: false,
+			err:    nil,
+		},
+		{
+			desc:   "read history in pages",
+			uri:    pagedURI,
+			node:   nodeID,
+			values: 2*historyPageSize + 1,
+			reads:  3,
+			closed: true,
+			err:    nil,
+		},
+		{
+			desc:   "read history exceeding max values",
+			uri:    largeURI,
+			node:   nodeID,
+			reads:  opcua.MaxHistoryValues/historyPageSize + 1,
+			closed: true,
+			err:    opcua.ErrHistoryTooLarge,
+		},
+		{
+			desc: "read history of invalid node",
+			uri:  serverURI,
+			node: "ns=invalid;i=1001",
+			err:  errFailedParseNodeID,
+		},
+		{
+			desc: "read history of unavailable server",
+			uri:  "opc.tcp://down:4840",
+			node: nodeID,
+			err:  errServerDown,
+		},
+	}
+
+	for _, tc := range cases {
+		values, err := hr.ReadHistory(config(tc.uri, tc.node), from, time.Now())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.values, len(values), fmt.Sprintf("%s: expected %d values got %d\n", tc.desc, tc.values, len(values)))
+		if tc.reads == 0 {
+			continue
+		}
+		conn, dials := servers.last(tc.uri)
+		assert.Equal(t, 1, dials, fmt.Sprintf("%s: expected 1 dial got %d\n", tc.desc, dials))
+		assert.Equal(t, tc.reads, conn.reads, fmt.Sprintf("%s: expected %d reads got %d\n", tc.desc, tc.reads, conn.reads))
+		assert.Equal(t, tc.closed, conn.isClosed(), fmt.Sprintf("%s: expected closed session %t\n", tc.desc, tc.closed))
+	}
+}
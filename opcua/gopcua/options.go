@@ -0,0 +1,198 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const securityModeNone = "None"
+
+var (
+	errFailedParseUserCert = errors.New("failed to parse user certificate")
+	errFailedParseUserKey  = errors.New("failed to parse user private key")
+	errFailedLoadKey       = errors.New("failed to load application private key")
+	errUserKeyMismatch     = errors.New("user private key doesn't match application private key")
+)
+
+// clientOptions returns OPC-UA client options built from the security
+// settings and the user identity of the given config.
+func clientOptions(cfg opcua.Config) ([]opcuaGopcua.Option, error) {
+	authType, authOpts, err := authOptions(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Mode == "" && authType == uaGopcua.UserTokenTypeAnonymous {
+		return []opcuaGopcua.Option{
+			opcuaGopcua.SecurityMode(uaGopcua.MessageSecurityModeNone),
+		}, nil
+	}
+
+	// User identity token policy is provided by the Server endpoint,
+	// so endpoints are fetched even if security mode is not set.
+	mode := cfg.Mode
+	if mode == "" {
+		mode = securityModeNone
+	}
+
+	endpoints, err := opcuaGopcua.GetEndpoints(cfg.ServerURI)
+	if err != nil {
+		return nil, errors.Wrap(errFailedFetchEndpoint, err)
+	}
+
+	ep := opcuaGopcua.SelectEndpoint(endpoints, cfg.Policy, uaGopcua.MessageSecurityModeFromString(mode))
+	if ep == nil {
+		return nil, errFailedFindEndpoint
+	}
+
+	keyOpts, err := keyOptions(cfg, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []opcuaGopcua.Option{
+		opcuaGopcua.SecurityPolicy(cfg.Policy),
+		opcuaGopcua.SecurityModeString(mode),
+		opcuaGopcua.CertificateFile(cfg.CertFile),
+	}
+	opts = append(opts, keyOpts...)
+	opts = append(opts, authOpts...)
+	opts = append(opts, opcuaGopcua.SecurityFromEndpoint(ep, authType))
+
+	return opts, nil
+}
+
+// keyOptions returns the client options of the private key. The gopcua
+// client signs the X.509 user identity token with the secure channel key, and
+// has no option to sign it with the separate user key. So the user key is
+// used as the channel key only if the channel isn't secured, and the channel
+// doesn't use the key otherwise. In the secured channel, the user key must be
+// the application key, i.e. the user certificate must be issued for the
+// application key pair.
+func keyOptions(cfg opcua.Config, mode string) ([]opcuaGopcua.Option, error) {
+	if cfg.Auth.Key == "" {
+		return []opcuaGopcua.Option{
+			opcuaGopcua.PrivateKeyFile(cfg.KeyFile),
+		}, nil
+	}
+
+	key, err := parseKey(cfg.Auth.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == securityModeNone {
+		return []opcuaGopcua.Option{
+			opcuaGopcua.PrivateKey(key),
+		}, nil
+	}
+
+	appKey, err := loadKey(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if appKey.N.Cmp(key.N) != 0 || appKey.E != key.E {
+		return nil, errUserKeyMismatch
+	}
+
+	return []opcuaGopcua.Option{
+		opcuaGopcua.PrivateKey(appKey),
+	}, nil
+}
+
+// authOptions returns the user identity token type and the client options
+// of the given user identity. The private key of the X.509 user identity is
+// set by keyOptions.
+func authOptions(auth opcua.UserAuth) (uaGopcua.UserTokenType, []opcuaGopcua.Option, error) {
+	switch {
+	case auth.Cert != "" || auth.Key != "":
+		cert, err := parseCert(auth.Cert)
+		if err != nil {
+			return 0, nil, err
+		}
+		opts := []opcuaGopcua.Option{
+			opcuaGopcua.AuthCertificate(cert),
+		}
+		return uaGopcua.UserTokenTypeCertificate, opts, nil
+	case auth.Username != "":
+		opts := []opcuaGopcua.Option{
+			opcuaGopcua.AuthUsername(auth.Username, auth.Password),
+		}
+		return uaGopcua.UserTokenTypeUserName, opts, nil
+	default:
+		opts := []opcuaGopcua.Option{
+			opcuaGopcua.AuthAnonymous(),
+		}
+		return uaGopcua.UserTokenTypeAnonymous, opts, nil
+	}
+}
+
+// parseCert returns DER encoded certificate from the PEM encoded one.
+func parseCert(data string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errFailedParseUserCert
+	}
+
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, errors.Wrap(errFailedParseUserCert, err)
+	}
+
+	return block.Bytes, nil
+}
+
+// loadKey returns RSA private key from the PEM or DER encoded file.
+func loadKey(file string) (*rsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(errFailedLoadKey, err)
+	}
+
+	if block, _ := pem.Decode(data); block == nil {
+		key, err := x509.ParsePKCS1PrivateKey(data)
+		if err != nil {
+			return nil, errors.Wrap(errFailedLoadKey, err)
+		}
+		return key, nil
+	}
+
+	key, err := parseKey(string(data))
+	if err != nil {
+		return nil, errors.Wrap(errFailedLoadKey, err)
+	}
+	return key, nil
+}
+
+// parseKey returns RSA private key from the PKCS #1 or PKCS #8 PEM encoded one.
+func parseKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errFailedParseUserKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(errFailedParseUserKey, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errFailedParseUserKey
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return key, string(pem.EncodeToMemory(block))
+}
+
+func TestKeyOptions(t *testing.T) {
+	_, appPEM := newKey(t)
+	_, otherPEM := newKey(t)
+
+	f, err := ioutil.TempFile("", "opcua-key")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(appPEM)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	f.Close()
+
+	cases := []struct {
+		desc string
+		key  string
+		file string
+		mode string
+		err  error
+	}{
+		{
+			desc: "application key only",
+			file: f.Name(),
+			mode: "SignAndEncrypt",
+			err:  nil,
+		},
+		{
+			desc: "user key without secured channel",
+			key:  otherPEM,
+			file: f.Name(),
+			mode: securityModeNone,
+			err:  nil,
+		},
+		{
+			desc: "user key matching application key",
+			key:  appPEM,
+			file: f.Name(),
+			mode: "SignAndEncrypt",
+			err:  nil,
+		},
+		{
+			desc: "user key not matching application key",
+			key:  otherPEM,
+			file: f.Name(),
+			mode: "SignAndEncrypt",
+			err:  errUserKeyMismatch,
+		},
+		{
+			desc: "user key with missing application key",
+			key:  appPEM,
+			file: "missing.pem",
+			mode: "Sign",
+			err:  errFailedLoadKey,
+		},
+		{
+			desc: "invalid user key",
+			key:  "invalid",
+			file: f.Name(),
+			mode: securityModeNone,
+			err:  errFailedParseUserKey,
+		},
+	}
+
+	for _, tc := range cases {
+		cfg := opcua.Config{
+			KeyFile: tc.file,
+			Auth:    opcua.UserAuth{Key: tc.key},
+		}
+		_, err := keyOptions(cfg, tc.mode)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSessionKey(t *testing.T) {
+	operator := config(serverURI, nodeID)
+	operator.Auth = opcua.UserAuth{Username: "operator", Password: "secret"}
+	other := operator
+	other.NodeID = otherNode
+	admin := operator
+	admin.Auth.Username = "admin"
+	shifted := operator
+	shifted.Auth = opcua.UserAuth{Username: "operators", Password: "ecret"}
+
+	cases := []struct {
+		desc  string
+		cfg   opcua.Config
+		other opcua.Config
+		same  bool
+	}{
+		{
+			desc:  "same identity and other node",
+			cfg:   operator,
+			other: other,
+			same:  true,
+		},
+		{
+			desc:  "other identity",
+			cfg:   operator,
+			other: admin,
+			same:  false,
+		},
+		{
+			desc:  "anonymous identity",
+			cfg:   operator,
+			other: config(serverURI, nodeID),
+			same:  false,
+		},
+		{
+			desc:  "identity fields shifted",
+			cfg:   operator,
+			other: shifted,
+			same:  false,
+		},
+		{
+			desc:  "other server",
+			cfg:   config(serverURI, nodeID),
+			other: config(otherURI, nodeID),
+			same:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		same := sessionKey(tc.cfg) == sessionKey(tc.other)
+		assert.Equal(t, tc.same, same, fmt.Sprintf("%s: expected same session %t got %t\n", tc.desc, tc.same, same))
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// conn represents the OPC-UA session to the Server.
+type conn interface {
+	// Subscribe creates the subscription with the given publishing interval.
+	Subscribe(interval time.Duration) (subscription, error)
+
+	// Read reads the attributes of the Nodes.
+	Read(req *uaGopcua.ReadRequest) (*uaGopcua.ReadResponse, error)
+
+	// Write writes the attributes of the Nodes.
+	Write(req *uaGopcua.WriteRequest) (*uaGopcua.WriteResponse, error)
+
+	// HistoryReadRawModified reads the raw historical values of the Nodes.
+	HistoryReadRawModified(nodes []*uaGopcua.HistoryReadValueID, details *uaGopcua.ReadRawModifiedDetails) (*uaGopcua.HistoryReadResponse, error)
+
+	// Close closes the session.
+	Close() error
+}
+
+// subscription represents the OPC-UA subscription of the session.
+type subscription interface {
+	// Monitor adds the Node to the subscription under the given client
+	// handle, and returns the monitored item ID.
+	Monitor(nodeID *uaGopcua.NodeID, handle uint32) (uint32, error)
+
+	// Unmonitor removes the monitored item from the subscription.
+	Unmonitor(id uint32) error
+
+	// Notifications returns the channel publish results are delivered to.
+	Notifications() <-chan *opcuaGopcua.PublishNotificationData
+
+	// Run publishes the notifications until the context is canceled or
+	// the session fails.
+	Run(ctx context.Context)
+
+	// Cancel deletes the subscription.
+	Cancel() error
+}
+
+// dialer opens the session to the Server of the given config.
+type dialer func(ctx context.Context, cfg opcua.Config) (conn, error)
+
+// sessionKey returns the key of the session to the Server of the given
+// config. Sessions are shared only by the configs with the same Server URI
+// and the same user identity, so the Nodes are never accessed with the
+// identity of the other Channel.
+func sessionKey(cfg opcua.Config) string {
+	h := sha256.New()
+	for _, f := range []string{cfg.Auth.Username, cfg.Auth.Password, cfg.Auth.Cert, cfg.Auth.Key} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+
+	return cfg.ServerURI + "#" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Sessions represents the OPC-UA sessions shared by the subscriber, the
+// writer and the history reader, so only one session per Server and user
+// identity is opened.
+type Sessions struct {
+	dial dialer
+	// mu guards the sessions map only, so the I/O to one Server doesn't
+	// block the others. Sessions are mapped by the session key.
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewSessions returns new shared OPC-UA sessions instance.
+func NewSessions() *Sessions {
+	return newSessions(dial)
+}
+
+func newSessions(d dialer) *Sessions {
+	return &Sessions{
+		dial:     d,
+		sessions: make(map[string]*session),
+	}
+}
+
+// session returns the session entry of the config, creating it if needed.
+func (ss *Sessions) session(cfg opcua.Config) *session {
+	key := sessionKey(cfg)
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	s, ok := ss.sessions[key]
+	if !ok {
+		s = &session{
+			uri:     cfg.ServerURI,
+			items:   make(map[string]monitoredItem),
+			handles: make(map[uint32]string),
+		}
+		ss.sessions[key] = s
+	}
+
+	return s
+}
+
+// subscribed returns the locked session the Node of the config is monitored
+// by. If the user identity changed since the Node is subscribed to, the Node
+// is looked up in the other sessions to the same Server.
+func (ss *Sessions) subscribed(cfg opcua.Config) (*session, bool) {
+	key := sessionKey(cfg)
+
+	ss.mu.Lock()
+	sessions := []*session{}
+	if s, ok := ss.sessions[key]; ok {
+		sessions = append(sessions, s)
+	}
+	for k, s := range ss.sessions {
+		if k != key && s.uri == cfg.ServerURI {
+			sessions = append(sessions, s)
+		}
+	}
+	ss.mu.Unlock()
+
+	for _, s := range sessions {
+		s.mu.Lock()
+		if _, ok := s.items[cfg.NodeID]; ok {
+			return s, true
+		}
+		s.mu.Unlock()
+	}
+
+	return nil, false
+}
+
+// acquire returns the open session of the config. If there is none, i.e. no
+// Node of the Server is subscribed to, the session is opened and it's closed
+// on release.
+func (ss *Sessions) acquire(ctx context.Context, cfg opcua.Config) (conn, func(), error) {
+	ss.mu.Lock()
+	s, ok := ss.sessions[sessionKey(cfg)]
+	ss.mu.Unlock()
+
+	if ok {
+		s.mu.Lock()
+		cn := s.conn
+		s.mu.Unlock()
+		if cn != nil {
+			return cn, func() {}, nil
+		}
+	}
+
+	cn, err := ss.dial(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cn, func() { cn.Close() }, nil
+}
+
+var _ dialer = dial
+
+// dial opens the session to the OPC-UA Server using the gopcua client.
+func dial(ctx context.Context, cfg opcua.Config) (conn, error) {
+	opts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	oc := opcuaGopcua.NewClient(cfg.ServerURI, opts...)
+	if err := oc.Connect(ctx); err != nil {
+		return nil, errors.Wrap(errFailedConn, err)
+	}
+
+	return uaConn{client: oc}, nil
+}
+
+type uaConn struct {
+	client *opcuaGopcua.Client
+}
+
+func (c uaConn) Subscribe(interval time.Duration) (subscription, error) {
+	sub, err := c.client.Subscribe(&opcuaGopcua.SubscriptionParameters{
+		Interval: interval,
+	})
+	if err != nil {
+		return nil, errors.Wrap(errFailedSub, err)
+	}
+
+	return uaSubscription{sub: sub}, nil
+}
+
+func (c uaConn) Read(req *uaGopcua.ReadRequest) (*uaGopcua.ReadResponse, error) {
+	return c.client.Read(req)
+}
+
+func (c uaConn) Write(req *uaGopcua.WriteRequest) (*uaGopcua.WriteResponse, error) {
+	return c.client.Write(req)
+}
+
+func (c uaConn) HistoryReadRawModified(nodes []*uaGopcua.HistoryReadValueID, details *uaGopcua.ReadRawModifiedDetails) (*uaGopcua.HistoryReadResponse, error) {
+	return c.client.HistoryReadRawModified(nodes, details)
+}
+
+func (c uaConn) Close() error {
+	return c.client.Close()
+}
+
+type uaSubscription struct {
+	sub *opcuaGopcua.Subscription
+}
+
+func (s uaSubscription) Monitor(nodeID *uaGopcua.NodeID, handle uint32) (uint32, error) {
+	req := opcuaGopcua.NewMonitoredItemCreateRequestWithDefaults(nodeID, uaGopcua.AttributeIDValue, handle)
+	res, err := s.sub.Monitor(uaGopcua.TimestampsToReturnBoth, req)
+	if err != nil {
+		return 0, errors.Wrap(errFailedCreateReq, err)
+	}
+	if len(res.Results) == 0 || res.Results[0].StatusCode != uaGopcua.StatusOK {
+		return 0, errResponseStatus
+	}
+
+	return res.Results[0].MonitoredItemID, nil
+}
+
+func (s uaSubscription) Unmonitor(id uint32) error {
+	res, err := s.sub.Unmonitor(id)
+	if err != nil {
+		return errors.Wrap(errFailedUnsub, err)
+	}
+	if len(res.Results) == 0 || res.Results[0] != uaGopcua.StatusOK {
+		return errResponseStatus
+	}
+
+	return nil
+}
+
+func (s uaSubscription) Notifications() <-chan *opcuaGopcua.PublishNotificationData {
+	return s.sub.Notifs
+}
+
+func (s uaSubscription) Run(ctx context.Context) {
+	s.sub.Run(ctx)
+}
+
+func (s uaSubscription) Cancel() error {
+	return s.sub.Cancel()
+}
@@ -0,0 +1,278 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var errServerDown = errors.New("server down")
+
+// fakeServers is the dialer of the in-memory OPC-UA Servers.
+type fakeServers struct {
+	mu      sync.Mutex
+	servers map[string]*fakeServer
+}
+
+type fakeServer struct {
+	// block, if set, blocks the dial until it's closed.
+	block chan struct{}
+	down  bool
+	// history is the number of historical values of each Node.
+	history int
+	dials   int
+	conns []*fakeConn
+}
+
+func newFakeServers() *fakeServers {
+	return &fakeServers{servers: map[string]*fakeServer{}}
+}
+
+func (fs *fakeServers) server(uri string) *fakeServer {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	srv, ok := fs.servers[uri]
+	if !ok {
+		srv = &fakeServer{}
+		fs.servers[uri] = srv
+	}
+	return srv
+}
+
+func (fs *fakeServers) dial(ctx context.Context, cfg opcua.Config) (conn, error) {
+	srv := fs.server(cfg.ServerURI)
+
+	fs.mu.Lock()
+	block := srv.block
+	fs.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	srv.dials++
+	if srv.down {
+		return nil, errServerDown
+	}
+
+	c := &fakeConn{
+		values:  initValues(),
+		history: srv.history,
+		sub: &fakeSubscription{
+			items:  map[uint32]uint32{},
+			notifs: make(chan *opcuaGopcua.PublishNotificationData),
+			fail:   make(chan struct{}),
+		},
+	}
+	srv.conns = append(srv.conns, c)
+	return c, nil
+}
+
+// initValues returns the initial values of the Server Nodes.
+func initValues() map[string]*uaGopcua.Variant {
+	values := map[string]*uaGopcua.Variant{}
+	for node, val := range map[string]interface{}{nodeID: float64(20), otherNode: int32(1)} {
+		v, err := uaGopcua.NewVariant(val)
+		if err != nil {
+			panic(err)
+		}
+		values[node] = v
+	}
+	return values
+}
+
+// last returns the last connection to the Server, and the number of dials.
+func (fs *fakeServers) last(uri string) (*fakeConn, int) {
+	srv := fs.server(uri)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if len(srv.conns) == 0 {
+		return nil, srv.dials
+	}
+	return srv.conns[len(srv.conns)-1], srv.dials
+}
+
+type fakeConn struct {
+	mu     sync.Mutex
+	sub    *fakeSubscription
+	closed bool
+	// values contains the Node values by NodeID.
+	values  map[string]*uaGopcua.Variant
+	history int
+	// reads is the number of history reads.
+	reads int
+}
+
+func (c *fakeConn) Subscribe(time.Duration) (subscription, error) {
+	return c.sub, nil
+}
+
+func (c *fakeConn) Read(req *uaGopcua.ReadRequest) (*uaGopcua.ReadResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := &uaGopcua.ReadResponse{}
+	for _, n := range req.NodesToRead {
+		v, ok := c.values[n.NodeID.String()]
+		if !ok {
+			res.Results = append(res.Results, &uaGopcua.DataValue{Status: uaGopcua.StatusBadNodeIDUnknown})
+			continue
+		}
+		res.Results = append(res.Results, &uaGopcua.DataValue{Status: uaGopcua.StatusOK, Value: v})
+	}
+	return res, nil
+}
+
+func (c *fakeConn) Write(req *uaGopcua.WriteRequest) (*uaGopcua.WriteResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := &uaGopcua.WriteResponse{}
+	for _, n := range req.NodesToWrite {
+		c.values[n.NodeID.String()] = n.Value.Value
+		res.Results = append(res.Results, uaGopcua.StatusOK)
+	}
+	return res, nil
+}
+
+// HistoryReadRawModified returns the pages of the Node history, using the
+// offset of the next page as the continuation point.
+func (c *fakeConn) HistoryReadRawModified(nodes []*uaGopcua.HistoryReadValueID, details *uaGopcua.ReadRawModifiedDetails) (*uaGopcua.HistoryReadResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reads++
+	start := 0
+	if cp := nodes[0].ContinuationPoint; len(cp) > 0 {
+		start, _ = strconv.Atoi(string(cp))
+	}
+	end := c.history
+	if n := int(details.NumValuesPerNode); n > 0 && start+n < end {
+		end = start + n
+	}
+
+	data := &uaGopcua.HistoryData{}
+	for i := start; i < end; i++ {
+		v, err := uaGopcua.NewVariant(float64(i))
+		if err != nil {
+			return nil, err
+		}
+		data.DataValues = append(data.DataValues, &uaGopcua.DataValue{
+			Value:           v,
+			SourceTimestamp: details.StartTime.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	r := &uaGopcua.HistoryReadResult{
+		StatusCode:  uaGopcua.StatusOK,
+		HistoryData: uaGopcua.NewExtensionObject(data),
+	}
+	if end < c.history {
+		r.ContinuationPoint = []byte(strconv.Itoa(end))
+	}
+
+	return &uaGopcua.HistoryReadResponse{Results: []*uaGopcua.HistoryReadResult{r}}, nil
+}
+
+// value returns the current value of the Node.
+func (c *fakeConn) value(node string) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.values[node]
+	if !ok {
+		return nil
+	}
+	return v.Value()
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+type fakeSubscription struct {
+	mu sync.Mutex
+	// items contains client handles by monitored item ID.
+	items    map[uint32]uint32
+	nextID   uint32
+	canceled bool
+	notifs   chan *opcuaGopcua.PublishNotificationData
+	// fail stops the subscription as if the session failed.
+	fail chan struct{}
+}
+
+func (s *fakeSubscription) Monitor(nodeID *uaGopcua.NodeID, handle uint32) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	s.items[s.nextID] = handle
+	return s.nextID, nil
+}
+
+func (s *fakeSubscription) Unmonitor(id uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return errResponseStatus
+	}
+	delete(s.items, id)
+	return nil
+}
+
+func (s *fakeSubscription) Notifications() <-chan *opcuaGopcua.PublishNotificationData {
+	return s.notifs
+}
+
+func (s *fakeSubscription) Run(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-s.fail:
+	}
+}
+
+func (s *fakeSubscription) Cancel() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.canceled = true
+	return nil
+}
+
+// handles returns the client handles of the monitored items.
+func (s *fakeSubscription) handles() map[uint32]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := map[uint32]bool{}
+	for _, h := range s.items {
+		ret[h] = true
+	}
+	return ret
+}
@@ -7,9 +7,9 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
-	opcuaGopcua "github.com/gopcua/opcua"
 	uaGopcua "github.com/gopcua/opcua/ua"
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/opcua"
@@ -29,6 +29,7 @@ var (
 	errFailedRead          = errors.New("failed to read")
 	errFailedParseInterval = errors.New("failed to parse subscription interval")
 	errFailedSub           = errors.New("failed to subscribe")
+	errFailedUnsub         = errors.New("failed to unsubscribe")
 	errFailedFindEndpoint  = errors.New("failed to find suitable endpoint")
 	errFailedFetchEndpoint = errors.New("failed to fetch OPC-UA server endpoints")
 	errFailedParseNodeID   = errors.New("failed to parse NodeID")
@@ -38,6 +39,11 @@ var (
 
 var _ opcua.Subscriber = (*client)(nil)
 
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
 type client struct {
 	ctx        context.Context
 	publisher  messaging.Publisher
@@ -45,6 +51,38 @@ type client struct {
 	channelsRM opcua.RouteMapRepository
 	connectRM  opcua.RouteMapRepository
 	logger     logger.Logger
+	sessions   *Sessions
+}
+
+// session represents the OPC-UA client connection and the subscription
+// shared by all the monitored Nodes of the same Server. Session entries are
+// kept once created, and the connection is opened with the first monitored
+// Node and closed when the last one is removed.
+type session struct {
+	// mu guards the connection and the monitored items, and it's held
+	// during the I/O to the Server.
+	mu     sync.Mutex
+	uri    string
+	cfg    opcua.Config
+	conn   conn
+	sub    subscription
+	cancel context.CancelFunc
+	// gen is incremented whenever the connection is opened or closed, so
+	// the stale reconnects can be detected.
+	gen uint64
+	// items contains monitored items by NodeID.
+	items      map[string]monitoredItem
+	nextHandle uint32
+
+	// handles contains NodeIDs by monitored item client handle. It has
+	// its own lock, since it's read on every notification.
+	hmu     sync.RWMutex
+	handles map[uint32]string
+}
+
+type monitoredItem struct {
+	id     uint32
+	handle uint32
 }
 
 type message struct {
@@ -56,97 +94,235 @@ type message struct {
 	Data      interface{}
 }
 
-// NewSubscriber returns new OPC-UA client instance.
-func NewSubscriber(ctx context.Context, publisher messaging.Publisher, thingsRM, channelsRM, connectRM opcua.RouteMapRepository, log logger.Logger) opcua.Subscriber {
-	return client{
+// NewSubscriber returns new OPC-UA client instance. Subscriptions are
+// created in the given sessions.
+func NewSubscriber(ctx context.Context, sessions *Sessions, publisher messaging.Publisher, thingsRM, channelsRM, connectRM opcua.RouteMapRepository, log logger.Logger) opcua.Subscriber {
+	return newSubscriber(ctx, sessions, publisher, thingsRM, channelsRM, connectRM, log)
+}
+
+func newSubscriber(ctx context.Context, sessions *Sessions, publisher messaging.Publisher, thingsRM, channelsRM, connectRM opcua.RouteMapRepository, log logger.Logger) *client {
+	return &client{
 		ctx:        ctx,
 		publisher:  publisher,
 		thingsRM:   thingsRM,
 		channelsRM: channelsRM,
 		connectRM:  connectRM,
 		logger:     log,
+		sessions:   sessions,
 	}
 }
 
-// Subscribe subscribes to the OPC-UA Server.
-func (c client) Subscribe(cfg opcua.Config) error {
-	opts := []opcuaGopcua.Option{
-		opcuaGopcua.SecurityMode(uaGopcua.MessageSecurityModeNone),
+// Subscribe adds the Node to the subscription of the OPC-UA Server. The
+// session and the subscription are created on the first subscribed Node.
+func (c *client) Subscribe(cfg opcua.Config) error {
+	nodeID, err := uaGopcua.ParseNodeID(cfg.NodeID)
+	if err != nil {
+		return errors.Wrap(errFailedParseNodeID, err)
+	}
+
+	s := c.sessions.session(cfg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[cfg.NodeID]; ok {
+		return nil
 	}
 
-	if cfg.Mode != "" {
-		endpoints, err := opcuaGopcua.GetEndpoints(cfg.ServerURI)
-		if err != nil {
-			return errors.Wrap(errFailedFetchEndpoint, err)
+	if s.sub == nil {
+		s.cfg = cfg
+		if err := c.open(s); err != nil {
+			return err
 		}
+	}
 
-		ep := opcuaGopcua.SelectEndpoint(endpoints, cfg.Policy, uaGopcua.MessageSecurityModeFromString(cfg.Mode))
-		if ep == nil {
-			return errFailedFindEndpoint
+	s.nextHandle++
+	handle := s.nextHandle
+	id, err := s.sub.Monitor(nodeID, handle)
+	if err != nil {
+		if len(s.items) == 0 {
+			c.close(s)
 		}
+		return err
+	}
 
-		opts = []opcuaGopcua.Option{
-			opcuaGopcua.SecurityPolicy(cfg.Policy),
-			opcuaGopcua.SecurityModeString(cfg.Mode),
-			opcuaGopcua.CertificateFile(cfg.CertFile),
-			opcuaGopcua.PrivateKeyFile(cfg.KeyFile),
-			opcuaGopcua.AuthAnonymous(),
-			opcuaGopcua.SecurityFromEndpoint(ep, uaGopcua.UserTokenTypeAnonymous),
+	s.items[cfg.NodeID] = monitoredItem{id: id, handle: handle}
+	s.hmu.Lock()
+	s.handles[handle] = cfg.NodeID
+	s.hmu.Unlock()
+
+	c.logger.Info(fmt.Sprintf("subscribed to server %s and node_id %s", cfg.ServerURI, cfg.NodeID))
+	return nil
+}
+
+// Unsubscribe removes the Node from the subscription of the OPC-UA Server.
+// The session is closed when the last Node is removed.
+func (c *client) Unsubscribe(cfg opcua.Config) error {
+	s, ok := c.sessions.subscribed(cfg)
+	if !ok {
+		return nil
+	}
+	defer s.mu.Unlock()
+
+	item := s.items[cfg.NodeID]
+
+	// Items are removed from the server along with the failed session, so
+	// they're only forgotten while the session is reconnecting.
+	if s.sub != nil {
+		if err := s.sub.Unmonitor(item.id); err != nil {
+			return err
 		}
 	}
 
-	oc := opcuaGopcua.NewClient(cfg.ServerURI, opts...)
-	if err := oc.Connect(c.ctx); err != nil {
-		return errors.Wrap(errFailedConn, err)
+	delete(s.items, cfg.NodeID)
+	s.hmu.Lock()
+	delete(s.handles, item.handle)
+	s.hmu.Unlock()
+	c.logger.Info(fmt.Sprintf("unsubscribed from server %s and node_id %s", cfg.ServerURI, cfg.NodeID))
+
+	if len(s.items) == 0 {
+		c.close(s)
 	}
-	defer oc.Close()
 
-	i, err := strconv.Atoi(cfg.Interval)
+	return nil
+}
+
+// open opens the session and creates the subscription to the OPC-UA Server,
+// and monitors the items of the previous connection, if any. The caller must
+// hold the session lock.
+func (c *client) open(s *session) error {
+	i, err := strconv.Atoi(s.cfg.Interval)
 	if err != nil {
 		return errors.Wrap(errFailedParseInterval, err)
 	}
 
-	sub, err := oc.Subscribe(&opcuaGopcua.SubscriptionParameters{
-		Interval: time.Duration(i) * time.Millisecond,
-	})
+	cn, err := c.sessions.dial(c.ctx, s.cfg)
+	if err != nil {
+		return err
+	}
+
+	sub, err := cn.Subscribe(time.Duration(i) * time.Millisecond)
 	if err != nil {
-		return errors.Wrap(errFailedSub, err)
+		cn.Close()
+		return err
 	}
-	defer sub.Cancel()
 
-	if err := c.runHandler(sub, cfg.ServerURI, cfg.NodeID); err != nil {
-		c.logger.Warn(fmt.Sprintf("Unsubscribed from OPC-UA node %s.%s: %s", cfg.ServerURI, cfg.NodeID, err))
+	for node, item := range s.items {
+		id, err := c.remonitor(sub, node, item.handle)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("Failed to subscribe to server %s and node_id %s: %s", s.uri, node, err))
+			delete(s.items, node)
+			s.hmu.Lock()
+			delete(s.handles, item.handle)
+			s.hmu.Unlock()
+			continue
+		}
+		s.items[node] = monitoredItem{id: id, handle: item.handle}
 	}
 
+	ctx, cancel := context.WithCancel(c.ctx)
+	s.conn = cn
+	s.sub = sub
+	s.cancel = cancel
+	s.gen++
+
+	done := make(chan struct{})
+	go func() {
+		sub.Run(ctx)
+		close(done)
+	}()
+	go c.runHandler(ctx, s, s.gen, sub, done)
+
 	return nil
 }
 
-func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) error {
+func (c *client) remonitor(sub subscription, node string, handle uint32) (uint32, error) {
 	nodeID, err := uaGopcua.ParseNodeID(node)
 	if err != nil {
-		return errors.Wrap(errFailedParseNodeID, err)
+		return 0, errors.Wrap(errFailedParseNodeID, err)
 	}
 
-	// arbitrary client handle for the monitoring item
-	handle := uint32(42)
-	miCreateRequest := opcuaGopcua.NewMonitoredItemCreateRequestWithDefaults(nodeID, uaGopcua.AttributeIDValue, handle)
-	res, err := sub.Monitor(uaGopcua.TimestampsToReturnBoth, miCreateRequest)
-	if err != nil {
-		return errors.Wrap(errFailedCreateReq, err)
-	}
-	if res.Results[0].StatusCode != uaGopcua.StatusOK {
-		return errResponseStatus
+	return sub.Monitor(nodeID, handle)
+}
+
+// close cancels the subscription and closes the session. The caller must
+// hold the session lock.
+func (c *client) close(s *session) {
+	s.gen++
+	if s.sub == nil {
+		return
 	}
 
-	go sub.Run(c.ctx)
+	s.cancel()
+	if err := s.sub.Cancel(); err != nil {
+		c.logger.Warn(fmt.Sprintf("Failed to cancel subscription to server %s: %s", s.uri, err))
+	}
+	if err := s.conn.Close(); err != nil {
+		c.logger.Warn(fmt.Sprintf("Failed to close session to server %s: %s", s.uri, err))
+	}
 
-	c.logger.Info(fmt.Sprintf("subscribed to server %s and node_id %s", uri, node))
+	s.conn = nil
+	s.sub = nil
+	s.cancel = nil
+}
 
+// reconnect reopens the failed session of the given generation, retrying
+// with the exponential backoff until it succeeds, or until the session is
+// closed or reopened by the subscription.
+func (c *client) reconnect(s *session, gen uint64) {
+	delay := minReconnectDelay
 	for {
+		s.mu.Lock()
+		if s.gen != gen {
+			s.mu.Unlock()
+			return
+		}
+		if s.sub != nil {
+			s.cancel()
+			s.conn.Close()
+			s.conn = nil
+			s.sub = nil
+			s.cancel = nil
+		}
+		err := c.open(s)
+		s.mu.Unlock()
+		if err == nil {
+			c.logger.Info(fmt.Sprintf("reconnected to server %s", s.uri))
+			return
+		}
+
+		c.logger.Warn(fmt.Sprintf("Failed to reconnect to server %s: %s", s.uri, err))
 		select {
 		case <-c.ctx.Done():
-			return nil
-		case res := <-sub.Notifs:
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// nodeID returns the NodeID monitored under the given client handle.
+func (s *session) nodeID(handle uint32) (string, bool) {
+	s.hmu.RLock()
+	defer s.hmu.RUnlock()
+
+	node, ok := s.handles[handle]
+	return node, ok
+}
+
+func (c *client) runHandler(ctx context.Context, s *session, gen uint64, sub subscription, done <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			// Subscription stops before the context is canceled only if
+			// the session fails.
+			c.logger.Warn(fmt.Sprintf("Session to server %s failed, reconnecting", s.uri))
+			c.reconnect(s, gen)
+			return
+		case res := <-sub.Notifications():
 			if res.Error != nil {
 				c.logger.Error(res.Error.Error())
 				continue
@@ -155,48 +331,35 @@ func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) erro
 			switch x := res.Value.(type) {
 			case *uaGopcua.DataChangeNotification:
 				for _, item := range x.MonitoredItems {
+					node, ok := s.nodeID(item.ClientHandle)
+					if !ok {
+						continue
+					}
+
+					key, data := senmlValue(item.Value.Value)
 					msg := message{
-						ServerURI: uri,
+						ServerURI: s.uri,
 						NodeID:    node,
 						Type:      item.Value.Value.Type().String(),
 						Time:      item.Value.SourceTimestamp.Unix(),
-						DataKey:   "v",
-					}
-
-					switch item.Value.Value.Type() {
-					case uaGopcua.TypeIDBoolean:
-						msg.DataKey = "vb"
-						msg.Data = item.Value.Value.Bool()
-					case uaGopcua.TypeIDString, uaGopcua.TypeIDByteString:
-						msg.DataKey = "vs"
-						msg.Data = item.Value.Value.String()
-					case uaGopcua.TypeIDDataValue:
-						msg.DataKey = "vd"
-						msg.Data = item.Value.Value.String()
-					case uaGopcua.TypeIDInt64, uaGopcua.TypeIDInt32, uaGopcua.TypeIDInt16:
-						msg.Data = float64(item.Value.Value.Int())
-					case uaGopcua.TypeIDUint64, uaGopcua.TypeIDUint32, uaGopcua.TypeIDUint16:
-						msg.Data = float64(item.Value.Value.Uint())
-					case uaGopcua.TypeIDFloat, uaGopcua.TypeIDDouble:
-						msg.Data = item.Value.Value.Float()
-					case uaGopcua.TypeIDByte:
-						msg.Data = float64(item.Value.Value.Uint())
-					case uaGopcua.TypeIDDateTime:
-						msg.Data = item.Value.Value.Time().Unix()
-					default:
-						msg.Data = 0
+						DataKey:   key,
+						Data:      data,
 					}
 
 					if err := c.publish(token, msg); err != nil {
-						switch err {
-						case errNotFoundServerURI, errNotFoundNodeID, errNotFoundConn:
-							return err
-						default:
-							c.logger.Error(fmt.Sprintf("Failed to publish: %s", err))
-						}
+						c.logger.Warn(fmt.Sprintf("Failed to publish from server %s and node_id %s: %s", s.uri, node, err))
 					}
 				}
 
+			case *uaGopcua.StatusChangeNotification:
+				// Server reports the status change if the subscription
+				// is lost, e.g. if its lifetime expired.
+				if x.Status != uaGopcua.StatusOK {
+					c.logger.Warn(fmt.Sprintf("Subscription to server %s lost with status %s, reconnecting", s.uri, x.Status))
+					c.reconnect(s, gen)
+					return
+				}
+
 			default:
 				c.logger.Info(fmt.Sprintf("unknown publish result: %T", res.Value))
 			}
@@ -204,8 +367,40 @@ func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) erro
 	}
 }
 
+// senmlValue returns SenML value field name and the value of the variant.
+func senmlValue(v *uaGopcua.Variant) (string, interface{}) {
+	key := "v"
+	var data interface{}
+
+	switch v.Type() {
+	case uaGopcua.TypeIDBoolean:
+		key = "vb"
+		data = v.Bool()
+	case uaGopcua.TypeIDString, uaGopcua.TypeIDByteString:
+		key = "vs"
+		data = v.String()
+	case uaGopcua.TypeIDDataValue:
+		key = "vd"
+		data = v.String()
+	case uaGopcua.TypeIDInt64, uaGopcua.TypeIDInt32, uaGopcua.TypeIDInt16:
+		data = float64(v.Int())
+	case uaGopcua.TypeIDUint64, uaGopcua.TypeIDUint32, uaGopcua.TypeIDUint16:
+		data = float64(v.Uint())
+	case uaGopcua.TypeIDFloat, uaGopcua.TypeIDDouble:
+		data = v.Float()
+	case uaGopcua.TypeIDByte:
+		data = float64(v.Uint())
+	case uaGopcua.TypeIDDateTime:
+		data = v.Time().Unix()
+	default:
+		data = 0
+	}
+
+	return key, data
+}
+
 // Publish forwards messages from the OPC-UA Server to Mainflux NATS broker
-func (c client) publish(token string, m message) error {
+func (c *client) publish(token string, m message) error {
 	// Get route-map of the OPC-UA ServerURI
 	chanID, err := c.channelsRM.Get(m.ServerURI)
 	if err != nil {
@@ -0,0 +1,322 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverURI = "opc.tcp://plc:4840"
+	otherURI  = "opc.tcp://other:4840"
+	nodeID    = "ns=2;i=1001"
+	otherNode = "ns=2;i=1002"
+	chanID    = "chan-id"
+	thingID   = "thing-id"
+	interval  = "1000"
+	timeout   = time.Second
+)
+
+type routeMap map[string]string
+
+func (rm routeMap) Save(string, string) error { return nil }
+
+func (rm routeMap) Get(key string) (string, error) {
+	v, ok := rm[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+func (rm routeMap) Remove(string) error { return nil }
+
+type publisher struct {
+	msgs chan messaging.Message
+}
+
+func (p publisher) Publish(_ string, msg messaging.Message) error {
+	p.msgs <- msg
+	return nil
+}
+
+func newTestSubscriber(t *testing.T, servers *fakeServers, pub messaging.Publisher) *client {
+	log, err := logger.New(os.Stdout, logger.Error.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	thingsRM := routeMap{nodeID: thingID}
+	channelsRM := routeMap{serverURI: chanID}
+	connectRM := routeMap{chanID + ":" + thingID: "1"}
+	return newSubscriber(context.Background(), newSessions(servers.dial), pub, thingsRM, channelsRM, connectRM, log)
+}
+
+func config(uri, node string) opcua.Config {
+	return opcua.Config{
+		ServerURI: uri,
+		NodeID:    node,
+		Interval:  interval,
+	}
+}
+
+// eventually waits until the condition is met or the timeout passes.
+func eventually(cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return cond()
+}
+
+func TestSubscribe(t *testing.T) {
+	servers := newFakeServers()
+	servers.server(otherURI).down = true
+	c := newTestSubscriber(t, servers, nil)
+
+	invalidInterval := config("opc.tcp://invalid:4840", nodeID)
+	invalidInterval.Interval = "invalid"
+
+	cases := []struct {
+		desc  string
+		cfg   opcua.Config
+		items int
+		dials int
+		err   error
+	}{
+		{
+			desc:  "subscribe to node",
+			cfg:   config(serverURI, nodeID),
+			items: 1,
+			dials: 1,
+			err:   nil,
+		},
+		{
+			desc:  "subscribe to subscribed node",
+			cfg:   config(serverURI, nodeID),
+			items: 1,
+			dials: 1,
+			err:   nil,
+		},
+		{
+			desc:  "subscribe to other node of the same server",
+			cfg:   config(serverURI, otherNode),
+			items: 2,
+			dials: 1,
+			err:   nil,
+		},
+		{
+			desc:  "subscribe to invalid node",
+			cfg:   config(serverURI, "ns=invalid;i=1001"),
+			items: 2,
+			dials: 1,
+			err:   errFailedParseNodeID,
+		},
+		{
+			desc: "subscribe with invalid interval",
+			cfg:  invalidInterval,
+			err:  errFailedParseInterval,
+		},
+		{
+			desc: "subscribe to unavailable server",
+			cfg:  config(otherURI, nodeID),
+			err:  errServerDown,
+		},
+	}
+
+	for _, tc := range cases {
+		err := c.Subscribe(tc.cfg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		conn, dials := servers.last(tc.cfg.ServerURI)
+		assert.Equal(t, tc.dials, dials, fmt.Sprintf("%s: expected %d dials got %d\n", tc.desc, tc.dials, dials))
+		items := len(conn.sub.handles())
+		assert.Equal(t, tc.items, items, fmt.Sprintf("%s: expected %d monitored items got %d\n", tc.desc, tc.items, items))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	servers := newFakeServers()
+	c := newTestSubscriber(t, servers, nil)
+
+	for _, node := range []string{nodeID, otherNode} {
+		err := c.Subscribe(config(serverURI, node))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	conn, _ := servers.last(serverURI)
+
+	cases := []struct {
+		desc   string
+		cfg    opcua.Config
+		items  int
+		closed bool
+	}{
+		{
+			desc:   "unsubscribe from node",
+			cfg:    config(serverURI, nodeID),
+			items:  1,
+			closed: false,
+		},
+		{
+			desc:   "unsubscribe from unsubscribed node",
+			cfg:    config(serverURI, nodeID),
+			items:  1,
+			closed: false,
+		},
+		{
+			desc:   "unsubscribe from node of unknown server",
+			cfg:    config(otherURI, nodeID),
+			items:  1,
+			closed: false,
+		},
+		{
+			desc:   "unsubscribe from last node",
+			cfg:    config(serverURI, otherNode),
+			items:  0,
+			closed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := c.Unsubscribe(tc.cfg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		items := len(conn.sub.handles())
+		assert.Equal(t, tc.items, items, fmt.Sprintf("%s: expected %d monitored items got %d\n", tc.desc, tc.items, items))
+		assert.Equal(t, tc.closed, conn.isClosed(), fmt.Sprintf("%s: expected closed session %t\n", tc.desc, tc.closed))
+	}
+
+	err := c.Subscribe(config(serverURI, nodeID))
+	assert.Nil(t, err, fmt.Sprintf("subscribe after the session is closed: unexpected error: %s", err))
+	_, dials := servers.last(serverURI)
+	assert.Equal(t, 2, dials, fmt.Sprintf("subscribe after the session is closed: expected 2 dials got %d\n", dials))
+}
+
+func TestSubscribeBlockedServer(t *testing.T) {
+	servers := newFakeServers()
+	block := make(chan struct{})
+	servers.server(otherURI).block = block
+	c := newTestSubscriber(t, servers, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := c.Subscribe(config(otherURI, nodeID))
+		assert.Nil(t, err, fmt.Sprintf("subscribe to blocked server: unexpected error: %s", err))
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- c.Subscribe(config(serverURI, nodeID))
+	}()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err, fmt.Sprintf("subscribe while other server blocks: unexpected error: %s", err))
+	case <-time.After(timeout):
+		assert.Fail(t, "subscribe while other server blocks: expected not to be blocked")
+	}
+
+	close(block)
+	wg.Wait()
+}
+
+func TestReconnect(t *testing.T) {
+	servers := newFakeServers()
+	pub := publisher{msgs: make(chan messaging.Message, 1)}
+	c := newTestSubscriber(t, servers, pub)
+
+	err := c.Subscribe(config(serverURI, nodeID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	failed, _ := servers.last(serverURI)
+	handles := failed.sub.handles()
+
+	close(failed.sub.fail)
+	ok := eventually(func() bool {
+		conn, dials := servers.last(serverURI)
+		return dials == 2 && len(conn.sub.handles()) == len(handles)
+	})
+	require.True(t, ok, "expected session to be reconnected")
+
+	conn, _ := servers.last(serverURI)
+	assert.Equal(t, handles, conn.sub.handles(), fmt.Sprintf("expected reconnected session to monitor %v got %v\n", handles, conn.sub.handles()))
+	assert.True(t, failed.isClosed(), "expected failed session to be closed")
+
+	var handle uint32
+	for h := range handles {
+		handle = h
+	}
+	v, err := uaGopcua.NewVariant(21.5)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	conn.sub.notifs <- &opcuaGopcua.PublishNotificationData{
+		Value: &uaGopcua.DataChangeNotification{
+			MonitoredItems: []*uaGopcua.MonitoredItemNotification{
+				{
+					ClientHandle: handle,
+					Value: &uaGopcua.DataValue{
+						Value:           v,
+						SourceTimestamp: time.Now(),
+					},
+				},
+			},
+		},
+	}
+
+	select {
+	case msg := <-pub.msgs:
+		assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("expected channel %s got %s\n", chanID, msg.Channel))
+		assert.Equal(t, thingID, msg.Publisher, fmt.Sprintf("expected publisher %s got %s\n", thingID, msg.Publisher))
+		assert.Equal(t, nodeID, msg.Subtopic, fmt.Sprintf("expected subtopic %s got %s\n", nodeID, msg.Subtopic))
+	case <-time.After(timeout):
+		assert.Fail(t, "expected value of reconnected session to be published")
+	}
+
+	err = c.Unsubscribe(config(serverURI, nodeID))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, conn.isClosed(), "expected reconnected session to be closed")
+}
+
+func TestSubscribeIdentity(t *testing.T) {
+	servers := newFakeServers()
+	c := newTestSubscriber(t, servers, nil)
+
+	operator := config(serverURI, nodeID)
+	operator.Auth = opcua.UserAuth{Username: "operator", Password: "secret"}
+	err := c.Subscribe(operator)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	operatorConn, _ := servers.last(serverURI)
+
+	admin := config(serverURI, otherNode)
+	admin.Auth = opcua.UserAuth{Username: "admin", Password: "secret"}
+	err = c.Subscribe(admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	adminConn, dials := servers.last(serverURI)
+	assert.Equal(t, 2, dials, fmt.Sprintf("subscribe with other identity: expected 2 dials got %d\n", dials))
+	assert.Equal(t, 1, len(operatorConn.sub.handles()), "subscribe with other identity: expected 1 monitored item in the first session")
+
+	// The Channel identity changed after the Node is subscribed to.
+	changed := operator
+	changed.Auth = opcua.UserAuth{Username: "supervisor", Password: "secret"}
+	err = c.Unsubscribe(changed)
+	assert.Nil(t, err, fmt.Sprintf("unsubscribe with changed identity: unexpected error: %s", err))
+	assert.True(t, operatorConn.isClosed(), "unsubscribe with changed identity: expected session to be closed")
+	assert.False(t, adminConn.isClosed(), "unsubscribe with changed identity: expected other identity session not to be closed")
+}
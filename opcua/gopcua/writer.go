@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errFailedWrite      = errors.New("failed to write")
+	errUnsupportedValue = errors.New("unsupported value for the node data type")
+)
+
+var _ opcua.Writer = (*writer)(nil)
+
+type writer struct {
+	ctx      context.Context
+	sessions *Sessions
+	logger   logger.Logger
+}
+
+// NewWriter returns new OPC-UA writer instance. Values are written using
+// the given shared sessions.
+func NewWriter(ctx context.Context, sessions *Sessions, log logger.Logger) opcua.Writer {
+	return writer{
+		ctx:      ctx,
+		sessions: sessions,
+		logger:   log,
+	}
+}
+
+// Write writes the value to the OPC-UA Server Node. The value is converted
+// to the data type of the current Node value before it's written.
+func (w writer) Write(cfg opcua.Config, value interface{}) error {
+	nodeID, err := uaGopcua.ParseNodeID(cfg.NodeID)
+	if err != nil {
+		return errors.Wrap(errFailedParseNodeID, err)
+	}
+
+	oc, release, err := w.sessions.acquire(w.ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	rReq := &uaGopcua.ReadRequest{
+		MaxAge: 2000,
+		NodesToRead: []*uaGopcua.ReadValueID{
+			{NodeID: nodeID, AttributeID: uaGopcua.AttributeIDValue},
+		},
+		TimestampsToReturn: uaGopcua.TimestampsToReturnNeither,
+	}
+	rRes, err := oc.Read(rReq)
+	if err != nil {
+		return errors.Wrap(errFailedRead, err)
+	}
+	if len(rRes.Results) == 0 || rRes.Results[0].Status != uaGopcua.StatusOK || rRes.Results[0].Value == nil {
+		return errResponseStatus
+	}
+
+	v, err := variant(rRes.Results[0].Value.Type(), value)
+	if err != nil {
+		return err
+	}
+
+	wReq := &uaGopcua.WriteRequest{
+		NodesToWrite: []*uaGopcua.WriteValue{
+			{
+				NodeID:      nodeID,
+				AttributeID: uaGopcua.AttributeIDValue,
+				Value: &uaGopcua.DataValue{
+					EncodingMask: uaGopcua.DataValueValue,
+					Value:        v,
+				},
+			},
+		},
+	}
+	wRes, err := oc.Write(wReq)
+	if err != nil {
+		return errors.Wrap(errFailedWrite, err)
+	}
+	if len(wRes.Results) == 0 {
+		return errResponseStatus
+	}
+	if wRes.Results[0] != uaGopcua.StatusOK {
+		return errors.Wrap(errFailedWrite, wRes.Results[0])
+	}
+
+	return nil
+}
+
+// variant converts SenML value to the OPC-UA variant of the given type.
+func variant(t uaGopcua.TypeID, value interface{}) (*uaGopcua.Variant, error) {
+	switch v := value.(type) {
+	case float64:
+		switch t {
+		case uaGopcua.TypeIDBoolean:
+			return uaGopcua.NewVariant(v != 0)
+		case uaGopcua.TypeIDSByte:
+			return uaGopcua.NewVariant(int8(v))
+		case uaGopcua.TypeIDByte:
+			return uaGopcua.NewVariant(uint8(v))
+		case uaGopcua.TypeIDInt16:
+			return uaGopcua.NewVariant(int16(v))
+		case uaGopcua.TypeIDUint16:
+			return uaGopcua.NewVariant(uint16(v))
+		case uaGopcua.TypeIDInt32:
+			return uaGopcua.NewVariant(int32(v))
+		case uaGopcua.TypeIDUint32:
+			return uaGopcua.NewVariant(uint32(v))
+		case uaGopcua.TypeIDInt64:
+			return uaGopcua.NewVariant(int64(v))
+		case uaGopcua.TypeIDUint64:
+			return uaGopcua.NewVariant(uint64(v))
+		case uaGopcua.TypeIDFloat:
+			return uaGopcua.NewVariant(float32(v))
+		case uaGopcua.TypeIDDouble:
+			return uaGopcua.NewVariant(v)
+		}
+	case bool:
+		if t == uaGopcua.TypeIDBoolean {
+			return uaGopcua.NewVariant(v)
+		}
+	case string:
+		switch t {
+		case uaGopcua.TypeIDString:
+			return uaGopcua.NewVariant(v)
+		case uaGopcua.TypeIDByteString:
+			return uaGopcua.NewVariant([]byte(v))
+		}
+	}
+
+	return nil, errUnsupportedValue
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	servers := newFakeServers()
+	servers.server("opc.tcp://down:4840").down = true
+	sessions := newSessions(servers.dial)
+
+	log, err := logger.New(os.Stdout, logger.Error.String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	sub := newSubscriber(context.Background(), sessions, nil, routeMap{}, routeMap{}, routeMap{}, log)
+	w := NewWriter(context.Background(), sessions, log)
+
+	err = sub.Subscribe(config(serverURI, nodeID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		uri    string
+		node   string
+		value  interface{}
+		stored interface{}
+		dials  int
+		err    error
+	}{
+		{
+			desc:   "write to node of subscribed server",
+			uri:    serverURI,
+			node:   nodeID,
+			value:  21.5,
+			stored: 21.5,
+			dials:  1,
+			err:    nil,
+		},
+		{
+			desc:   "write to integer node of subscribed server",
+			uri:    serverURI,
+			node:   otherNode,
+			value:  float64(3),
+			stored: int32(3),
+			dials:  1,
+			err:    nil,
+		},
+		{
+			desc:   "write to node of unsubscribed server",
+			uri:    otherURI,
+			node:   nodeID,
+			value:  21.5,
+			stored: 21.5,
+			dials:  1,
+			err:    nil,
+		},
+		{
+			desc:  "write unsupported value",
+			uri:   serverURI,
+			node:  nodeID,
+			value: "invalid",
+			dials: 1,
+			err:   errUnsupportedValue,
+		},
+		{
+			desc:  "write to unknown node",
+			uri:   serverURI,
+			node:  "ns=2;i=9999",
+			value: 21.5,
+			dials: 1,
+			err:   errResponseStatus,
+		},
+		{
+			desc:  "write to invalid node",
+			uri:   serverURI,
+			node:  "ns=invalid;i=1001",
+			value: 21.5,
+			err:   errFailedParseNodeID,
+		},
+		{
+			desc:  "write to unavailable server",
+			uri:   "opc.tcp://down:4840",
+			node:  nodeID,
+			value: 21.5,
+			err:   errServerDown,
+		},
+	}
+
+	for _, tc := range cases {
+		err := w.Write(config(tc.uri, tc.node), tc.value)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == errFailedParseNodeID || tc.err == errServerDown {
+			continue
+		}
+		conn, dials := servers.last(tc.uri)
+		assert.Equal(t, tc.dials, dials, fmt.Sprintf("%s: expected %d dials got %d\n", tc.desc, tc.dials, dials))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.stored, conn.value(tc.node), fmt.Sprintf("%s: expected value %v got %v\n", tc.desc, tc.stored, conn.value(tc.node)))
+		closed := tc.uri != serverURI
+		assert.Equal(t, closed, conn.isClosed(), fmt.Sprintf("%s: expected closed session %t\n", tc.desc, closed))
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import "time"
+
+// HistoryValue represents the historical value of the OPC-UA Node. DataKey
+// is SenML value field name the Data is published under.
+type HistoryValue struct {
+	Type    string
+	Time    time.Time
+	DataKey string
+	Data    interface{}
+}
+
+// MaxHistoryValues is the max number of the historical values read at once.
+const MaxHistoryValues = 100000
+
+// HistoryReader represents the OPC-UA Server Nodes history reader.
+type HistoryReader interface {
+	// ReadHistory returns historical values of the NodeID of the given
+	// Server in the given time range. If there are more than
+	// MaxHistoryValues values, ErrHistoryTooLarge is returned.
+	ReadHistory(Config, time.Time, time.Time) ([]HistoryValue, error)
+}
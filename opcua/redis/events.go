@@ -3,6 +3,8 @@
 
 package redis
 
+import "github.com/mainflux/mainflux/opcua"
+
 type createThingEvent struct {
 	id          string
 	opcuaNodeID string
@@ -20,6 +22,7 @@ type connectThingEvent struct {
 type createChannelEvent struct {
 	id             string
 	opcuaServerURI string
+	auth           opcua.UserAuth
 }
 
 type removeChannelEvent struct {
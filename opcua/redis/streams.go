@@ -17,6 +17,10 @@ const (
 	keyType      = "opcua"
 	keyNodeID    = "node_id"
 	keyServerURI = "server_uri"
+	keyUsername  = "username"
+	keyPassword  = "password"
+	keyUserCert  = "user_cert"
+	keyUserKey   = "user_key"
 
 	group  = "mainflux.opcua"
 	stream = "mainflux.things"
@@ -200,6 +204,12 @@ func decodeCreateChannel(event map[string]interface{}) (createChannelEvent, erro
 	}
 
 	cce.opcuaServerURI = val
+	cce.auth = opcua.UserAuth{
+		Username: read(metadataVal, keyUsername, ""),
+		Password: read(metadataVal, keyPassword, ""),
+		Cert:     read(metadataVal, keyUserCert, ""),
+		Key:      read(metadataVal, keyUserKey, ""),
+	}
 	return cce, nil
 }
 
@@ -232,7 +242,7 @@ func (es eventStore) handleRemoveThing(rte removeThingEvent) error {
 }
 
 func (es eventStore) handleCreateChannel(cce createChannelEvent) error {
-	return es.svc.CreateChannel(cce.id, cce.opcuaServerURI)
+	return es.svc.CreateChannel(cce.id, cce.opcuaServerURI, cce.auth)
 }
 
 func (es eventStore) handleRemoveChannel(rce removeChannelEvent) error {
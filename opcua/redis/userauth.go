@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/opcua"
+)
+
+var _ opcua.UserAuthRepository = (*userAuthRepository)(nil)
+
+type userAuthRepository struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewUserAuthRepository returns redis Channel user identity repository.
+func NewUserAuthRepository(client *redis.Client, prefix string) opcua.UserAuthRepository {
+	return &userAuthRepository{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (ur *userAuthRepository) Save(chanID string, auth opcua.UserAuth) error {
+	key := fmt.Sprintf("%s:%s", ur.prefix, chanID)
+	if auth == (opcua.UserAuth{}) {
+		return ur.client.Del(key).Err()
+	}
+
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return err
+	}
+
+	return ur.client.Set(key, data, 0).Err()
+}
+
+func (ur *userAuthRepository) Get(chanID string) (opcua.UserAuth, error) {
+	key := fmt.Sprintf("%s:%s", ur.prefix, chanID)
+	data, err := ur.client.Get(key).Bytes()
+	if err == redis.Nil {
+		return opcua.UserAuth{}, nil
+	}
+	if err != nil {
+		return opcua.UserAuth{}, err
+	}
+
+	var auth opcua.UserAuth
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return opcua.UserAuth{}, err
+	}
+
+	return auth, nil
+}
+
+func (ur *userAuthRepository) Remove(chanID string) error {
+	key := fmt.Sprintf("%s:%s", ur.prefix, chanID)
+	return ur.client.Del(key).Err()
+}
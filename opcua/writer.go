@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+// Writer represents the OPC-UA Server Nodes writer.
+type Writer interface {
+	// Write writes the value to the NodeID of the given Server.
+	Write(Config, interface{}) error
+}
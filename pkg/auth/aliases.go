@@ -0,0 +1,158 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux"
+)
+
+const (
+	aliasPrefix      = "channel_alias"
+	aliasesPrefix    = "channel_aliases"
+	aliasNamesPrefix = "channel_alias_names"
+	// aliasGenKey is the generation of the aliases cache, incremented on
+	// every invalidation.
+	aliasGenKey = "channel_aliases_gen"
+
+	// aliasTTL is the lifetime of the cached alias, which bounds staleness
+	// of the aliases whose invalidation is missed.
+	aliasTTL = 10 * time.Minute
+)
+
+// AliasPrefix marks the channel referenced by alias, e.g. @sensors.
+const AliasPrefix = "@"
+
+// Aliases represents channel aliases cache. Channel alias is the name of
+// the channel prefixed with AliasPrefix, which can be used in place of the
+// channel ID by the things owned by the owner of the channel.
+type Aliases interface {
+	// ChannelID returns the ID of the channel with the given alias, owned
+	// by the owner of the thing. Channel IDs are returned as they are.
+	ChannelID(thingID, channel string) (string, error)
+}
+
+type aliases struct {
+	redisClient  *redis.Client
+	thingsClient mainflux.ThingsServiceClient
+}
+
+// NewAliases returns redis channel aliases cache implementation.
+func NewAliases(redisClient *redis.Client, thingsClient mainflux.ThingsServiceClient) Aliases {
+	return aliases{
+		redisClient:  redisClient,
+		thingsClient: thingsClient,
+	}
+}
+
+// IsAlias checks whether the channel is referenced by alias, rather than
+// by ID.
+func IsAlias(channel string) bool {
+	return len(channel) > len(AliasPrefix) && strings.HasPrefix(channel, AliasPrefix)
+}
+
+// AliasName returns the channel name of the alias.
+func AliasName(alias string) string {
+	return strings.TrimPrefix(alias, AliasPrefix)
+}
+
+func (a aliases) ChannelID(thingID, channel string) (string, error) {
+	if !IsAlias(channel) {
+		return channel, nil
+	}
+	name := AliasName(channel)
+
+	akey := aliasKey(thingID, name)
+	if chanID, err := a.redisClient.Get(akey).Result(); err == nil {
+		return chanID, nil
+	}
+
+	// Generation is read before the alias is resolved, so the alias isn't
+	// cached if the cache is invalidated in the meantime.
+	gen, err := a.redisClient.Get(aliasGenKey).Int64()
+	if err != nil && err != redis.Nil {
+		gen = -1
+	}
+
+	req := &mainflux.ChannelByNameReq{
+		ThingID: thingID,
+		Name:    name,
+	}
+	res, err := a.thingsClient.ChannelIDByName(context.TODO(), req)
+	if err != nil {
+		return "", err
+	}
+
+	chanID := res.GetValue()
+	if gen >= 0 {
+		a.save(gen, akey, name, chanID)
+	}
+
+	return chanID, nil
+}
+
+// save caches the alias unless the cache generation changed. Aliases are
+// tracked by the channel and by the name, so they can be invalidated once
+// the channel is updated or removed, or the other channel takes the name.
+func (a aliases) save(gen int64, akey, name, chanID string) {
+	a.redisClient.Watch(func(tx *redis.Tx) error {
+		cur, err := tx.Get(aliasGenKey).Int64()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if cur != gen {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Set(akey, chanID, aliasTTL)
+			pipe.SAdd(aliasesKey(chanID), akey)
+			pipe.Expire(aliasesKey(chanID), aliasTTL)
+			pipe.SAdd(aliasNamesKey(name), akey)
+			pipe.Expire(aliasNamesKey(name), aliasTTL)
+			return nil
+		})
+		return err
+	}, aliasGenKey)
+}
+
+// invalidate removes all the cached aliases of the channel.
+func invalidate(client *redis.Client, chanID string) error {
+	return invalidateSet(client, aliasesKey(chanID))
+}
+
+// invalidateName removes all the cached aliases with the given name, since
+// they may become ambiguous once the other channel takes the name.
+func invalidateName(client *redis.Client, name string) error {
+	return invalidateSet(client, aliasNamesKey(name))
+}
+
+func invalidateSet(client *redis.Client, key string) error {
+	keys, err := client.SMembers(key).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := client.TxPipeline()
+	pipe.Incr(aliasGenKey)
+	pipe.Del(append(keys, key)...)
+	_, err = pipe.Exec()
+	return err
+}
+
+func aliasKey(thingID, name string) string {
+	return aliasPrefix + ":" + thingID + ":" + name
+}
+
+func aliasesKey(chanID string) string {
+	return aliasesPrefix + ":" + chanID
+}
+
+func aliasNamesKey(name string) string {
+	return aliasNamesPrefix + ":" + name
+}
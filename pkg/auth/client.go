@@ -12,7 +12,9 @@ import (
 
 // Client represents Auth cache.
 type Client interface {
-	Authorize(chanID, thingID string) error
+	// Authorize checks whether the thing is connected to the channel with
+	// the given access type, which is either publish or subscribe.
+	Authorize(chanID, thingID, access string) error
 	Identify(thingKey string) (string, error)
 }
 
@@ -51,14 +53,15 @@ func (c client) Identify(thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) Authorize(chanID, thingID string) error {
-	if c.redisClient.SIsMember(chanPrefix+":"+chanID, thingID).Val() {
+func (c client) Authorize(chanID, thingID, access string) error {
+	if c.redisClient.SIsMember(chanPrefix+":"+chanID+":"+access, thingID).Val() {
 		return nil
 	}
 
 	ar := &mainflux.AccessByIDReq{
 		ThingID: thingID,
 		ChanID:  chanID,
+		Access:  access,
 	}
 	_, err := c.thingsClient.CanAccessByID(context.TODO(), ar)
 	return err
@@ -0,0 +1,227 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	limitsPrefix = "thing_limits"
+	bucketPrefix = "thing_bucket"
+	quotaPrefix  = "thing_quota"
+
+	dayFormat = "20060102"
+	quotaTTL  = 48 * time.Hour
+)
+
+var (
+	// ErrRateLimited indicates that the thing exceeded its rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	// ErrQuotaExceeded indicates that the thing exceeded its daily quota.
+	ErrQuotaExceeded = errors.New("daily message quota exceeded")
+)
+
+// allowScript consumes a token of the thing bucket and a message of the thing
+// daily quota. Bucket is refilled with rate tokens per minute, up to the burst.
+// Limits are applied atomically, so they are shared by all adapter replicas.
+var allowScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local quota = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+
+if quota > 0 then
+	local used = tonumber(redis.call("GET", KEYS[2]) or "0")
+	if used >= quota then
+		return 2
+	end
+end
+
+if rate > 0 then
+	local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+	local tokens = tonumber(bucket[1]) or burst
+	local ts = tonumber(bucket[2]) or now
+	tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 60000)
+	if tokens < 1 then
+		return 1
+	end
+	redis.call("HMSET", KEYS[1], "tokens", tokens - 1, "ts", now)
+	redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 60000 / rate))
+end
+
+if quota > 0 then
+	if redis.call("INCR", KEYS[2]) == 1 then
+		redis.call("EXPIRE", KEYS[2], ARGV[5])
+	end
+end
+
+return 0
+`)
+
+// Limits represents the thing publishing limits. Rate is the number of
+// messages per minute, burst is the number of messages which can be
+// published at once, and quota is the number of messages per day. Zero
+// value of the limit means there's no limit.
+type Limits struct {
+	Rate  uint64
+	Burst uint64
+	Quota uint64
+}
+
+// Limiter represents the thing publishing limiter.
+type Limiter interface {
+	// Allow checks whether the thing is allowed to publish a message. If it
+	// is, the message is counted against the thing rate limit and quota.
+	Allow(thingID string) error
+}
+
+type limiter struct {
+	redisClient  *redis.Client
+	thingsClient mainflux.ThingsServiceClient
+	defaults     Limits
+}
+
+// NewLimiter returns redis thing publishing limiter. Limits of the thing are
+// set in the thing metadata, while the default ones are the ceilings of the
+// thing limits, and apply to the limits the thing metadata doesn't set.
+func NewLimiter(redisClient *redis.Client, thingsClient mainflux.ThingsServiceClient, defaults Limits) Limiter {
+	return limiter{
+		redisClient:  redisClient,
+		thingsClient: thingsClient,
+		defaults:     defaults,
+	}
+}
+
+func (l limiter) Allow(thingID string) error {
+	lm, err := l.limits(thingID)
+	if err != nil {
+		return err
+	}
+	if lm.Rate == 0 && lm.Quota == 0 {
+		return nil
+	}
+
+	// Burst defaults to the number of messages per minute.
+	if lm.Burst == 0 {
+		lm.Burst = lm.Rate
+	}
+
+	now := time.Now().UTC()
+	keys := []string{
+		bucketPrefix + ":" + thingID,
+		quotaPrefix + ":" + thingID + ":" + now.Format(dayFormat),
+	}
+	res, err := allowScript.Run(l.redisClient, keys,
+		lm.Rate, lm.Burst, lm.Quota, now.UnixNano()/int64(time.Millisecond), int(quotaTTL.Seconds())).Int()
+	if err != nil {
+		return err
+	}
+
+	switch res {
+	case 1:
+		return ErrRateLimited
+	case 2:
+		return ErrQuotaExceeded
+	default:
+		return nil
+	}
+}
+
+// limits returns the thing limits, merged with the default ones.
+func (l limiter) limits(thingID string) (Limits, error) {
+	lkey := limitsKey(thingID)
+	lm, err := cachedLimits(l.redisClient.HGetAll(lkey).Val())
+	if err != nil {
+		res, err := l.thingsClient.RetrieveLimits(context.TODO(), &mainflux.ThingID{Value: thingID})
+		if err != nil {
+			// Malformed limits in the thing metadata are ignored.
+			if status.Code(err) != codes.InvalidArgument {
+				return Limits{}, err
+			}
+			res = &mainflux.Limits{}
+		}
+
+		lm = Limits{
+			Rate:  res.GetRate(),
+			Burst: res.GetBurst(),
+			Quota: res.GetQuota(),
+		}
+		l.redisClient.HMSet(lkey, map[string]interface{}{
+			"rate":  lm.Rate,
+			"burst": lm.Burst,
+			"quota": lm.Quota,
+		})
+	}
+
+	return merge(lm, l.defaults), nil
+}
+
+// merge returns the thing limits capped by the default ones, so the thing
+// can't be allowed more than the adapter allows. Burst defaults to the rate
+// on both sides before it's capped.
+func merge(lm, defaults Limits) Limits {
+	burst, defBurst := lm.Burst, defaults.Burst
+	if burst == 0 {
+		burst = lm.Rate
+	}
+	if defBurst == 0 {
+		defBurst = defaults.Rate
+	}
+
+	return Limits{
+		Rate:  capped(lm.Rate, defaults.Rate),
+		Burst: capped(burst, defBurst),
+		Quota: capped(lm.Quota, defaults.Quota),
+	}
+}
+
+// capped returns the limit capped by the given ceiling. Zero value of either
+// means there's no limit, so the other one applies.
+func capped(limit, ceiling uint64) uint64 {
+	if limit == 0 || (ceiling != 0 && limit > ceiling) {
+		return ceiling
+	}
+	return limit
+}
+
+func cachedLimits(fields map[string]string) (Limits, error) {
+	var lm Limits
+	values := map[string]*uint64{
+		"rate":  &lm.Rate,
+		"burst": &lm.Burst,
+		"quota": &lm.Quota,
+	}
+	for k, v := range values {
+		s, ok := fields[k]
+		if !ok {
+			return Limits{}, errors.New("limits not cached")
+		}
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return Limits{}, err
+		}
+		*v = n
+	}
+
+	return lm, nil
+}
+
+// invalidateLimits removes the cached limits of the thing.
+func invalidateLimits(client *redis.Client, thingID string) error {
+	return client.Del(limitsKey(thingID)).Err()
+}
+
+func limitsKey(thingID string) string {
+	return limitsPrefix + ":" + thingID
+}
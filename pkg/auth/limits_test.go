@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	defaults := Limits{Rate: 60, Burst: 10, Quota: 1000}
+
+	cases := []struct {
+		desc     string
+		limits   Limits
+		defaults Limits
+		merged   Limits
+	}{
+		{
+			desc:     "limits not set",
+			limits:   Limits{},
+			defaults: defaults,
+			merged:   defaults,
+		},
+		{
+			desc:     "limits below defaults",
+			limits:   Limits{Rate: 30, Burst: 5, Quota: 500},
+			defaults: defaults,
+			merged:   Limits{Rate: 30, Burst: 5, Quota: 500},
+		},
+		{
+			desc:     "limits above defaults",
+			limits:   Limits{Rate: 600, Burst: 100, Quota: 10000},
+			defaults: defaults,
+			merged:   defaults,
+		},
+		{
+			desc:     "rate below defaults without burst",
+			limits:   Limits{Rate: 5},
+			defaults: defaults,
+			merged:   Limits{Rate: 5, Burst: 5, Quota: 1000},
+		},
+		{
+			desc:     "rate above defaults without burst",
+			limits:   Limits{Rate: 600},
+			defaults: defaults,
+			merged:   defaults,
+		},
+		{
+			desc:     "limits without defaults",
+			limits:   Limits{Rate: 600, Quota: 10000},
+			defaults: Limits{},
+			merged:   Limits{Rate: 600, Burst: 600, Quota: 10000},
+		},
+		{
+			desc:     "limits with default rate without burst",
+			limits:   Limits{Rate: 120, Burst: 100},
+			defaults: Limits{Rate: 60},
+			merged:   Limits{Rate: 60, Burst: 60},
+		},
+	}
+
+	for _, tc := range cases {
+		merged := merge(tc.limits, tc.defaults)
+		assert.Equal(t, tc.merged, merged, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.merged, merged))
+	}
+}
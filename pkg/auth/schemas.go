@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const schemaPrefix = "channel_schema"
+
+// Schemas represents channel payload schemas cache.
+type Schemas interface {
+	// Validate checks whether the payload conforms to the payload schema of
+	// the channel. Payloads of the channels which don't declare the schema
+	// are always valid.
+	Validate(chanID string, payload []byte) error
+
+	// Invalidate removes the cached payload schema of the channel.
+	Invalidate(chanID string) error
+}
+
+// compiled represents the compiled payload schema of the channel, along with
+// the JSON encoded one it's compiled from. Malformed schemas are kept with
+// nil schema, so they're reported only once.
+type compiled struct {
+	raw    string
+	schema schema.Schema
+}
+
+type schemas struct {
+	redisClient  *redis.Client
+	thingsClient mainflux.ThingsServiceClient
+	logger       logger.Logger
+	// mu guards the compiled schemas, mapped by channel ID.
+	mu       *sync.RWMutex
+	compiled map[string]compiled
+}
+
+// NewSchemas returns redis channel payload schemas cache implementation.
+// Schemas are cached in redis JSON encoded, while the compiled ones are
+// cached in memory.
+func NewSchemas(redisClient *redis.Client, thingsClient mainflux.ThingsServiceClient, logger logger.Logger) Schemas {
+	return schemas{
+		redisClient:  redisClient,
+		thingsClient: thingsClient,
+		logger:       logger,
+		mu:           &sync.RWMutex{},
+		compiled:     make(map[string]compiled),
+	}
+}
+
+func (s schemas) Validate(chanID string, payload []byte) error {
+	raw, err := s.schema(chanID)
+	if err != nil {
+		return err
+	}
+	if raw == "" {
+		return nil
+	}
+
+	sch := s.compile(chanID, raw)
+	if sch == nil {
+		return nil
+	}
+
+	return sch.Validate(payload)
+}
+
+func (s schemas) Invalidate(chanID string) error {
+	s.mu.Lock()
+	delete(s.compiled, chanID)
+	s.mu.Unlock()
+
+	return s.redisClient.Del(schemaKey(chanID)).Err()
+}
+
+// compile returns the compiled payload schema of the channel. Schema is
+// compiled again only if its JSON encoding changed, e.g. if the schema is
+// updated while the other instance invalidated the cache.
+func (s schemas) compile(chanID, raw string) schema.Schema {
+	s.mu.RLock()
+	c, ok := s.compiled[chanID]
+	s.mu.RUnlock()
+	if ok && c.raw == raw {
+		return c.schema
+	}
+
+	sch, err := schema.Parse([]byte(raw))
+	if err != nil {
+		// Schemas set before the validation was introduced may be
+		// malformed, so they're ignored.
+		s.logger.Warn(fmt.Sprintf("Ignoring malformed payload schema of channel %s: %s", chanID, err))
+	}
+
+	s.mu.Lock()
+	s.compiled[chanID] = compiled{raw: raw, schema: sch}
+	s.mu.Unlock()
+
+	return sch
+}
+
+// schema returns the JSON encoded payload schema of the channel. Channels
+// without the schema are cached as well, with an empty value.
+func (s schemas) schema(chanID string) (string, error) {
+	skey := schemaKey(chanID)
+	if raw, err := s.redisClient.Get(skey).Result(); err == nil {
+		return raw, nil
+	}
+
+	res, err := s.thingsClient.RetrieveSchema(context.TODO(), &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		if status.Code(err) != codes.InvalidArgument {
+			return "", err
+		}
+		res = &mainflux.Schema{}
+	}
+
+	raw := res.GetValue()
+	s.redisClient.Set(skey, raw, 0)
+
+	return raw, nil
+}
+
+func schemaKey(chanID string) string {
+	return schemaPrefix + ":" + chanID
+}
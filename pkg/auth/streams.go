@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/logger"
+)
+
+const (
+	stream = "mainflux.things"
+
+	thingPrefix = "thing."
+	thingUpdate = thingPrefix + "update"
+	thingRemove = thingPrefix + "remove"
+
+	channelPrefix = "channel."
+	channelCreate = channelPrefix + "create"
+	channelUpdate = channelPrefix + "update"
+	channelRemove = channelPrefix + "remove"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+// Subscriber represents things event stream consumer which keeps the
+// channel aliases, channel schemas and thing limits cache up to date.
+type Subscriber interface {
+	// Subscribe subscribes to things event stream as a member of the given
+	// consumer group and invalidates the aliases and schemas of updated and
+	// removed channels, the aliases with the names of the created channels,
+	// as well as the limits of updated and removed things.
+	Subscribe(group string) error
+}
+
+type eventStore struct {
+	cache    *redis.Client
+	client   *redis.Client
+	schemas  Schemas
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance. Cache is the redis client
+// of the aliases and limits cache, while client is the event store one.
+// Schemas are invalidated using the given schemas cache.
+func NewEventStore(cache, client *redis.Client, schemas Schemas, consumer string, logger logger.Logger) Subscriber {
+	return eventStore{
+		cache:    cache,
+		client:   client,
+		schemas:  schemas,
+		consumer: consumer,
+		logger:   logger,
+	}
+}
+
+func (es eventStore) Subscribe(group string) error {
+	err := es.client.XGroupCreateMkStream(stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := es.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			event := msg.Values
+
+			var err error
+			switch event["operation"] {
+			case channelCreate:
+				// Aliases of the other channels with the same name become
+				// ambiguous.
+				if name, ok := event["name"].(string); ok {
+					err = invalidateName(es.cache, name)
+				}
+			case channelUpdate, channelRemove:
+				// Channel rename can't be told apart from the other updates,
+				// so the aliases are invalidated on every channel update.
+				id, _ := event["id"].(string)
+				if err = invalidate(es.cache, id); err == nil {
+					err = es.schemas.Invalidate(id)
+				}
+				if name, ok := event["name"].(string); ok && err == nil {
+					err = invalidateName(es.cache, name)
+				}
+			case thingUpdate, thingRemove:
+				id, _ := event["id"].(string)
+				err = invalidateLimits(es.cache, id)
+			}
+			if err != nil {
+				es.logger.Warn(fmt.Sprintf("Failed to handle event sourcing: %s", err.Error()))
+				break
+			}
+			es.client.XAck(stream, group, msg.ID)
+		}
+	}
+}
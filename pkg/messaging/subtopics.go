@@ -0,0 +1,17 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "strings"
+
+// EventsSubtopic is the system Channel subtopic of the events published by
+// the adapters themselves, such as MQTT session events. Clients aren't
+// allowed to publish to it or any of its subtopics, regardless of protocol.
+const EventsSubtopic = "_mqtt"
+
+// Reserved checks if the subtopic, in its dot-separated form, is the system
+// events subtopic or any of its subtopics.
+func Reserved(subtopic string) bool {
+	return subtopic == EventsSubtopic || strings.HasPrefix(subtopic, EventsSubtopic+".")
+}
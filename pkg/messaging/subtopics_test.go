@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserved(t *testing.T) {
+	cases := []struct {
+		desc     string
+		subtopic string
+		reserved bool
+	}{
+		{desc: "empty subtopic", subtopic: "", reserved: false},
+		{desc: "regular subtopic", subtopic: "temperature.room1", reserved: false},
+		{desc: "subtopic with events prefix", subtopic: "_mqtt_data", reserved: false},
+		{desc: "nested events subtopic", subtopic: "room1._mqtt", reserved: false},
+		{desc: "events subtopic", subtopic: "_mqtt", reserved: true},
+		{desc: "will subtopic", subtopic: "_mqtt.will", reserved: true},
+		{desc: "disconnect subtopic", subtopic: "_mqtt.disconnect", reserved: true},
+	}
+
+	for _, tc := range cases {
+		reserved := messaging.Reserved(tc.subtopic)
+		assert.Equal(t, tc.reserved, reserved, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.reserved, reserved))
+	}
+}
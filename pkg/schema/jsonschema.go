@@ -0,0 +1,474 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Types of the JSON values, as named by JSON Schema.
+var types = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"number":  true,
+	"integer": true,
+	"string":  true,
+}
+
+// Keywords which aren't supported. Ignoring them, as JSON Schema does with
+// the unknown keywords, would silently accept the payloads the schema author
+// meant to reject.
+var unsupported = []string{
+	"$ref", "$defs", "definitions", "dependencies", "dependentRequired",
+	"dependentSchemas", "if", "then", "else", "not", "patternProperties",
+	"propertyNames", "contains", "uniqueItems", "multipleOf",
+	"minProperties", "maxProperties", "additionalItems", "format",
+}
+
+// jsonSchema is the compiled JSON Schema. The subset of the keywords which
+// describe the structure of the JSON document is supported: type, enum,
+// const, properties, required, additionalProperties, items, minItems,
+// maxItems, minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength,
+// maxLength, pattern, allOf, anyOf and oneOf.
+type jsonSchema struct {
+	// never is set for the false schema, which matches no value.
+	never bool
+
+	types      []string
+	enum       []interface{}
+	constant   []interface{}
+	properties map[string]*jsonSchema
+	required   []string
+	additional *jsonSchema
+	items      *jsonSchema
+	minItems   *float64
+	maxItems   *float64
+	minimum    *float64
+	maximum    *float64
+	exclMin    *float64
+	exclMax    *float64
+	minLength  *float64
+	maxLength  *float64
+	pattern    *regexp.Regexp
+	allOf      []*jsonSchema
+	anyOf      []*jsonSchema
+	oneOf      []*jsonSchema
+}
+
+func (s *jsonSchema) Validate(payload []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	if err := s.validate(v, ""); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	return nil
+}
+
+func compile(v interface{}) (*jsonSchema, error) {
+	switch v := v.(type) {
+	case bool:
+		return &jsonSchema{never: !v}, nil
+	case map[string]interface{}:
+		return compileObject(v)
+	default:
+		return nil, errors.New("schema must be an object or a boolean")
+	}
+}
+
+func compileObject(m map[string]interface{}) (*jsonSchema, error) {
+	for _, k := range unsupported {
+		if _, ok := m[k]; ok {
+			return nil, fmt.Errorf("unsupported keyword %s", k)
+		}
+	}
+
+	s := &jsonSchema{}
+	if v, ok := m["type"]; ok {
+		ts, err := compileTypes(v)
+		if err != nil {
+			return nil, err
+		}
+		s.types = ts
+	}
+
+	if v, ok := m["enum"]; ok {
+		enum, ok := v.([]interface{})
+		if !ok || len(enum) == 0 {
+			return nil, errors.New("enum must be a non-empty array")
+		}
+		s.enum = enum
+	}
+
+	if v, ok := m["const"]; ok {
+		s.constant = []interface{}{v}
+	}
+
+	if v, ok := m["properties"]; ok {
+		props, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("properties must be an object")
+		}
+		s.properties = map[string]*jsonSchema{}
+		for name, p := range props {
+			ps, err := compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("property %s: %s", name, err)
+			}
+			s.properties[name] = ps
+		}
+	}
+
+	if v, ok := m["required"]; ok {
+		req, ok := v.([]interface{})
+		if !ok {
+			return nil, errors.New("required must be an array of strings")
+		}
+		for _, r := range req {
+			name, ok := r.(string)
+			if !ok {
+				return nil, errors.New("required must be an array of strings")
+			}
+			s.required = append(s.required, name)
+		}
+	}
+
+	var err error
+	if s.additional, err = compileOptional(m, "additionalProperties"); err != nil {
+		return nil, err
+	}
+	if s.items, err = compileOptional(m, "items"); err != nil {
+		return nil, err
+	}
+
+	limits := map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclMin,
+		"exclusiveMaximum": &s.exclMax,
+	}
+	for k, f := range limits {
+		if v, ok := m[k]; ok {
+			n, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a number", k)
+			}
+			*f = &n
+		}
+	}
+
+	counts := map[string]**float64{
+		"minItems":  &s.minItems,
+		"maxItems":  &s.maxItems,
+		"minLength": &s.minLength,
+		"maxLength": &s.maxLength,
+	}
+	for k, f := range counts {
+		if v, ok := m[k]; ok {
+			n, ok := v.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return nil, fmt.Errorf("%s must be a non-negative integer", k)
+			}
+			*f = &n
+		}
+	}
+
+	if v, ok := m["pattern"]; ok {
+		p, ok := v.(string)
+		if !ok {
+			return nil, errors.New("pattern must be a string")
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("pattern: %s", err)
+		}
+		s.pattern = re
+	}
+
+	combinators := map[string]*[]*jsonSchema{
+		"allOf": &s.allOf,
+		"anyOf": &s.anyOf,
+		"oneOf": &s.oneOf,
+	}
+	for k, f := range combinators {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		subs, ok := v.([]interface{})
+		if !ok || len(subs) == 0 {
+			return nil, fmt.Errorf("%s must be a non-empty array", k)
+		}
+		for _, sub := range subs {
+			ss, err := compile(sub)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", k, err)
+			}
+			*f = append(*f, ss)
+		}
+	}
+
+	return s, nil
+}
+
+func compileOptional(m map[string]interface{}, key string) (*jsonSchema, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+
+	s, err := compile(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", key, err)
+	}
+
+	return s, nil
+}
+
+func compileTypes(v interface{}) ([]string, error) {
+	var ts []interface{}
+	switch v := v.(type) {
+	case string:
+		ts = []interface{}{v}
+	case []interface{}:
+		ts = v
+	default:
+		return nil, errors.New("type must be a string or an array of strings")
+	}
+
+	var ret []string
+	for _, t := range ts {
+		name, ok := t.(string)
+		if !ok || !types[name] {
+			return nil, fmt.Errorf("unknown type %v", t)
+		}
+		ret = append(ret, name)
+	}
+
+	return ret, nil
+}
+
+// validate returns the first violation of the schema, prefixed with the JSON
+// pointer of the value which violates it.
+func (s *jsonSchema) validate(v interface{}, path string) error {
+	if s.never {
+		return violation(path, "value is not allowed")
+	}
+
+	if len(s.types) > 0 && !s.hasType(v) {
+		return violation(path, "must be of type "+strings.Join(s.types, " or "))
+	}
+
+	if s.enum != nil && !contains(s.enum, v) {
+		return violation(path, "must be one of the enumerated values")
+	}
+
+	if s.constant != nil && !reflect.DeepEqual(s.constant[0], v) {
+		return violation(path, "must be equal to the constant value")
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if err := s.validateObject(v, path); err != nil {
+			return err
+		}
+	case []interface{}:
+		if err := s.validateArray(v, path); err != nil {
+			return err
+		}
+	case float64:
+		if err := s.validateNumber(v, path); err != nil {
+			return err
+		}
+	case string:
+		if err := s.validateString(v, path); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range s.allOf {
+		if err := sub.validate(v, path); err != nil {
+			return err
+		}
+	}
+
+	if len(s.anyOf) > 0 && s.matches(s.anyOf, v) == 0 {
+		return violation(path, "must match at least one schema of anyOf")
+	}
+
+	if len(s.oneOf) > 0 && s.matches(s.oneOf, v) != 1 {
+		return violation(path, "must match exactly one schema of oneOf")
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) validateObject(obj map[string]interface{}, path string) error {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			return violation(path, "missing required property "+name)
+		}
+	}
+
+	// Properties are validated in order, so the reported violation is stable.
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := path + "/" + escape(name)
+		if ps, ok := s.properties[name]; ok {
+			if err := ps.validate(obj[name], p); err != nil {
+				return err
+			}
+			continue
+		}
+		if s.additional != nil {
+			if s.additional.never {
+				return violation(path, "unexpected property "+name)
+			}
+			if err := s.additional.validate(obj[name], p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) validateArray(arr []interface{}, path string) error {
+	n := float64(len(arr))
+	if s.minItems != nil && n < *s.minItems {
+		return violation(path, "must have at least "+format(*s.minItems)+" items")
+	}
+	if s.maxItems != nil && n > *s.maxItems {
+		return violation(path, "must have at most "+format(*s.maxItems)+" items")
+	}
+
+	if s.items != nil {
+		for i, item := range arr {
+			if err := s.items.validate(item, path+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) validateNumber(n float64, path string) error {
+	if s.minimum != nil && n < *s.minimum {
+		return violation(path, "must be greater than or equal to "+format(*s.minimum))
+	}
+	if s.maximum != nil && n > *s.maximum {
+		return violation(path, "must be less than or equal to "+format(*s.maximum))
+	}
+	if s.exclMin != nil && n <= *s.exclMin {
+		return violation(path, "must be greater than "+format(*s.exclMin))
+	}
+	if s.exclMax != nil && n >= *s.exclMax {
+		return violation(path, "must be less than "+format(*s.exclMax))
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) validateString(str string, path string) error {
+	n := float64(utf8.RuneCountInString(str))
+	if s.minLength != nil && n < *s.minLength {
+		return violation(path, "must be at least "+format(*s.minLength)+" characters long")
+	}
+	if s.maxLength != nil && n > *s.maxLength {
+		return violation(path, "must be at most "+format(*s.maxLength)+" characters long")
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return violation(path, "must match pattern "+s.pattern.String())
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) hasType(v interface{}) bool {
+	for _, t := range s.types {
+		if typeOf(v, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *jsonSchema) matches(schemas []*jsonSchema, v interface{}) int {
+	n := 0
+	for _, sub := range schemas {
+		if sub.validate(v, "") == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+func typeOf(v interface{}, t string) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case float64:
+		return t == "number" || (t == "integer" && v == math.Trunc(v))
+	case string:
+		return t == "string"
+	default:
+		return false
+	}
+}
+
+func contains(values []interface{}, v interface{}) bool {
+	for _, e := range values {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func violation(path, msg string) error {
+	if path == "" {
+		path = "/"
+	}
+
+	return errors.New(path + ": " + msg)
+}
+
+// escape escapes the property name as the JSON pointer reference token.
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func format(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
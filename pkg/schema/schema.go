@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema contains the payload schemas the channels can declare, so
+// the protocol adapters are able to reject malformed messages at ingest.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/senml"
+)
+
+const (
+	// SenML requires the payload to be a valid SenML JSON pack.
+	SenML = "senml"
+
+	// SenMLCBOR requires the payload to be a valid SenML CBOR pack.
+	SenMLCBOR = "senml+cbor"
+)
+
+var (
+	// ErrMalformedSchema indicates that the schema is malformed or that it
+	// uses the unsupported JSON Schema keyword.
+	ErrMalformedSchema = errors.New("malformed payload schema")
+
+	// ErrInvalidPayload indicates that the payload doesn't conform to the
+	// channel payload schema.
+	ErrInvalidPayload = errors.New("payload doesn't conform to the channel schema")
+)
+
+// Schema represents the channel payload schema.
+type Schema interface {
+	// Validate checks whether the payload conforms to the schema.
+	Validate(payload []byte) error
+}
+
+// Parse returns the schema encoded as the JSON value. The value is either
+// one of the SenML schema names, or the JSON Schema object.
+func Parse(raw []byte) (Schema, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return nil, errors.Wrap(ErrMalformedSchema, err)
+		}
+		switch name {
+		case SenML:
+			return senmlSchema(senml.JSON), nil
+		case SenMLCBOR:
+			return senmlSchema(senml.CBOR), nil
+		default:
+			return nil, errors.Wrap(ErrMalformedSchema, errors.New("unknown schema "+name))
+		}
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, errors.Wrap(ErrMalformedSchema, errors.New("schema must be a name or an object"))
+	}
+
+	js, err := compile(v)
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	return js, nil
+}
+
+type senmlSchema senml.Format
+
+func (s senmlSchema) Validate(payload []byte) error {
+	if _, err := senml.Decode(payload, senml.Format(s)); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const temperature = `{
+	"type": "object",
+	"required": ["temp", "unit"],
+	"additionalProperties": false,
+	"properties": {
+		"temp": {"type": "number", "minimum": -50, "maximum": 150},
+		"unit": {"enum": ["C", "F"]},
+		"serial": {"type": "string", "pattern": "^[A-Z]{2}[0-9]+$", "maxLength": 12},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+		"count": {"type": "integer"}
+	}
+}`
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema string
+		err    error
+	}{
+		{
+			desc:   "parse SenML schema",
+			schema: `"senml"`,
+			err:    nil,
+		},
+		{
+			desc:   "parse SenML CBOR schema",
+			schema: `"senml+cbor"`,
+			err:    nil,
+		},
+		{
+			desc:   "parse JSON schema",
+			schema: temperature,
+			err:    nil,
+		},
+		{
+			desc:   "parse unknown schema name",
+			schema: `"xml"`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema which isn't an object",
+			schema: `[1, 2]`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse invalid JSON",
+			schema: `{"type": `,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema with unknown type",
+			schema: `{"type": "decimal"}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema with unsupported keyword",
+			schema: `{"$ref": "#/definitions/temp"}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema with invalid pattern",
+			schema: `{"pattern": "[a-"}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema with negative length",
+			schema: `{"minLength": -1}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		{
+			desc:   "parse schema with invalid property schema",
+			schema: `{"properties": {"temp": 5}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := schema.Parse([]byte(tc.schema))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidateSenML(t *testing.T) {
+	// Following hex-encoded bytes correspond to the content of:
+	// [{0: "name", 2: 42.0}]
+	cborBytes, err := hex.DecodeString("81A200646E616D6502F95140")
+	require.Nil(t, err, "Decoding CBOR expected to succeed")
+
+	cases := []struct {
+		desc    string
+		schema  string
+		payload []byte
+		err     error
+	}{
+		{
+			desc:    "validate valid SenML JSON",
+			schema:  `"senml"`,
+			payload: []byte(`[{"n":"temp","v":21.5}]`),
+			err:     nil,
+		},
+		{
+			desc:    "validate SenML JSON without value",
+			schema:  `"senml"`,
+			payload: []byte(`[{"n":"temp"}]`),
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate plain JSON as SenML",
+			schema:  `"senml"`,
+			payload: []byte(`{"temp":21.5}`),
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate valid SenML CBOR",
+			schema:  `"senml+cbor"`,
+			payload: cborBytes,
+			err:     nil,
+		},
+		{
+			desc:    "validate SenML JSON as SenML CBOR",
+			schema:  `"senml+cbor"`,
+			payload: []byte(`[{"n":"temp","v":21.5}]`),
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := schema.Parse([]byte(tc.schema))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		err = s.Validate(tc.payload)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	cases := []struct {
+		desc    string
+		schema  string
+		payload string
+		err     string
+	}{
+		{
+			desc:    "validate valid payload",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "serial": "AB123", "tags": ["lab"], "count": 3}`,
+		},
+		{
+			desc:    "validate payload which isn't JSON",
+			schema:  temperature,
+			payload: `temp=21.5`,
+			err:     "invalid character",
+		},
+		{
+			desc:    "validate payload of invalid type",
+			schema:  temperature,
+			payload: `[21.5]`,
+			err:     "/: must be of type object",
+		},
+		{
+			desc:    "validate payload without required property",
+			schema:  temperature,
+			payload: `{"temp": 21.5}`,
+			err:     "/: missing required property unit",
+		},
+		{
+			desc:    "validate payload with unexpected property",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "hum": 40}`,
+			err:     "/: unexpected property hum",
+		},
+		{
+			desc:    "validate payload with property of invalid type",
+			schema:  temperature,
+			payload: `{"temp": "21.5", "unit": "C"}`,
+			err:     "/temp: must be of type number",
+		},
+		{
+			desc:    "validate payload with value out of range",
+			schema:  temperature,
+			payload: `{"temp": 200, "unit": "C"}`,
+			err:     "/temp: must be less than or equal to 150",
+		},
+		{
+			desc:    "validate payload with value not enumerated",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "K"}`,
+			err:     "/unit: must be one of the enumerated values",
+		},
+		{
+			desc:    "validate payload with value not matching pattern",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "serial": "ab123"}`,
+			err:     "/serial: must match pattern ^[A-Z]{2}[0-9]+$",
+		},
+		{
+			desc:    "validate payload with too many items",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "tags": ["a", "b", "c"]}`,
+			err:     "/tags: must have at most 2 items",
+		},
+		{
+			desc:    "validate payload with item of invalid type",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "tags": ["a", 1]}`,
+			err:     "/tags/1: must be of type string",
+		},
+		{
+			desc:    "validate payload with fractional integer",
+			schema:  temperature,
+			payload: `{"temp": 21.5, "unit": "C", "count": 1.5}`,
+			err:     "/count: must be of type integer",
+		},
+		{
+			desc:    "validate payload matching one of the schemas",
+			schema:  `{"oneOf": [{"type": "string"}, {"type": "number"}]}`,
+			payload: `5`,
+		},
+		{
+			desc:    "validate payload matching none of the schemas",
+			schema:  `{"anyOf": [{"type": "string"}, {"type": "number"}]}`,
+			payload: `true`,
+			err:     "/: must match at least one schema of anyOf",
+		},
+		{
+			desc:    "validate payload matching both of the schemas",
+			schema:  `{"oneOf": [{"type": "integer"}, {"type": "number"}]}`,
+			payload: `5`,
+			err:     "/: must match exactly one schema of oneOf",
+		},
+		{
+			desc:    "validate payload not equal to the constant",
+			schema:  `{"properties": {"v": {"const": 1}}}`,
+			payload: `{"v": 2}`,
+			err:     "/v: must be equal to the constant value",
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := schema.Parse([]byte(tc.schema))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		err = s.Validate([]byte(tc.payload))
+		if tc.err == "" {
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			continue
+		}
+		require.NotNil(t, err, fmt.Sprintf("%s: expected error %s\n", tc.desc, tc.err))
+		assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, schema.ErrInvalidPayload, err))
+		assert.Contains(t, err.Error(), tc.err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
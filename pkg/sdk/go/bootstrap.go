@@ -24,19 +24,23 @@ const whitelistEndpoint = "state"
 // MFKey is key of corresponding Mainflux Thing.
 // MFChannels is a list of Mainflux Channels corresponding Mainflux Thing connects to.
 type BootstrapConfig struct {
-	ThingID     string    `json:"thing_id,omitempty"`
-	Channels    []string  `json:"channels,omitempty"`
-	ExternalID  string    `json:"external_id,omitempty"`
-	ExternalKey string    `json:"external_key,omitempty"`
-	MFThing     string    `json:"mainflux_id,omitempty"`
-	MFChannels  []Channel `json:"mainflux_channels,omitempty"`
-	MFKey       string    `json:"mainflux_key,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	ClientCert  string    `json:"client_cert,omitempty"`
-	ClientKey   string    `json:"client_key,omitempty"`
-	CACert      string    `json:"ca_cert,omitempty"`
-	Content     string    `json:"content,omitempty"`
-	State       int       `json:"state,omitempty"`
+	ThingID        string    `json:"thing_id,omitempty"`
+	Channels       []string  `json:"channels,omitempty"`
+	ExternalID     string    `json:"external_id,omitempty"`
+	ExternalKey    string    `json:"external_key,omitempty"`
+	MFThing        string    `json:"mainflux_id,omitempty"`
+	MFChannels     []Channel `json:"mainflux_channels,omitempty"`
+	MFKey          string    `json:"mainflux_key,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	ClientCert     string    `json:"client_cert,omitempty"`
+	ClientKey      string    `json:"client_key,omitempty"`
+	CACert         string    `json:"ca_cert,omitempty"`
+	Content        string    `json:"content,omitempty"`
+	TemplateID     string    `json:"template_id,omitempty"`
+	Format         string    `json:"format,omitempty"`
+	State          int       `json:"state,omitempty"`
+	Version        uint64    `json:"version,omitempty"`
+	FetchedVersion uint64    `json:"fetched_version,omitempty"`
 }
 
 func (sdk mfSDK) AddBootstrap(token string, cfg BootstrapConfig) (string, error) {
@@ -20,7 +20,7 @@ import (
 
 func newMessageService(cc mainflux.ThingsServiceClient) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, cc)
+	return adapter.New(pub, cc, mocks.NewAliases(map[string]string{}), mocks.NewLimiter(map[string]uint64{}), mocks.NewSchemas(map[string]string{}))
 }
 
 func newMessageServer(svc adapter.Service) *httptest.Server {
@@ -9,8 +9,10 @@ type UserPasswordReq struct {
 	Password    string `json:"password,omitempty"`
 }
 
-// ConnectionIDs contains ID lists of things and channels to be connected
+// ConnectionIDs contains ID lists of things and channels to be connected.
+// Access is either "publish", "subscribe" or "pubsub", which is the default.
 type ConnectionIDs struct {
 	ChannelIDs []string `json:"channel_ids"`
 	ThingIDs   []string `json:"thing_ids"`
+	Access     string   `json:"access,omitempty"`
 }
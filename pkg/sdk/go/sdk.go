@@ -132,6 +132,9 @@ type SDK interface {
 	// UpdateThing updates existing thing.
 	UpdateThing(thing Thing, token string) error
 
+	// UpdateThingKey updates key of the existing thing.
+	UpdateThingKey(id, key, token string) error
+
 	// DeleteThing removes existing thing.
 	DeleteThing(id, token string) error
 
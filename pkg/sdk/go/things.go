@@ -203,6 +203,32 @@ func (sdk mfSDK) UpdateThing(t Thing, token string) error {
 	return nil
 }
 
+func (sdk mfSDK) UpdateThingKey(id, key, token string) error {
+	data, err := json.Marshal(map[string]string{"key": key})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/key", thingsEndpoint, id)
+	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
 func (sdk mfSDK) DeleteThing(id, token string) error {
 	endpoint := fmt.Sprintf("%s/%s", thingsEndpoint, id)
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
@@ -538,6 +538,66 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateThingKey(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateThing(thing, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		id    string
+		key   string
+		token string
+		err   error
+	}{
+		{
+			desc:  "update key of existing thing",
+			id:    id,
+			key:   "new-key",
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "update key of non-existing thing",
+			id:    "0",
+			key:   "other-key",
+			token: token,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+		},
+		{
+			desc:  "update thing key with empty key",
+			id:    id,
+			key:   "",
+			token: token,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusBadRequest),
+		},
+		{
+			desc:  "update thing key with invalid token",
+			id:    id,
+			key:   "other-key",
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.UpdateThingKey(tc.id, tc.key, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
 func TestDeleteThing(t *testing.T) {
 	svc := newThingsService(map[string]string{token: email})
 	ts := newThingsServer(svc)
@@ -805,8 +865,8 @@ func TestConnect(t *testing.T) {
 
 	for _, tc := range cases {
 		connIDs := sdk.ConnectionIDs{
-			[]string{tc.thingID},
-			[]string{tc.chanID},
+			ChannelIDs: []string{tc.thingID},
+			ThingIDs:   []string{tc.chanID},
 		}
 
 		err := mainfluxSDK.Connect(connIDs, tc.token)
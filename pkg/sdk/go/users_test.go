@@ -32,7 +32,7 @@ func newUserService() users.Service {
 
 	emailer := mocks.NewEmailer()
 
-	return users.New(repo, hasher, auth, emailer)
+	return users.New(repo, hasher, mocks.NewTOTP(), auth, emailer, nil)
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
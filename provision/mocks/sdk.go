@@ -73,6 +73,11 @@ func (s *mockSDK) UpdateThing(thing mfSDK.Thing, token string) error {
 	panic("UpdateThing not implemented")
 }
 
+// UpdateThingKey updates key of the existing thing.
+func (s *mockSDK) UpdateThingKey(id, key, token string) error {
+	panic("UpdateThingKey not implemented")
+}
+
 // DisconnectThing disconnect thing from specified channel by id.
 func (s *mockSDK) DisconnectThing(thingID, chanID, token string) error {
 	panic("UpdatePassword not implemented")
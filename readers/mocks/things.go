@@ -45,3 +45,19 @@ func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByID
 func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
+
+func (svc thingsServiceMock) RetrievePSK(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) ChannelIDByName(context.Context, *mainflux.ChannelByNameReq, ...grpc.CallOption) (*mainflux.ChannelID, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) RetrieveLimits(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.Limits, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) RetrieveSchema(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.Schema, error) {
+	panic("not implemented")
+}
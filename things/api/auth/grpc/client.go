@@ -11,6 +11,7 @@ import (
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -19,10 +20,14 @@ import (
 var _ mainflux.ThingsServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	timeout        time.Duration
-	canAccessByKey endpoint.Endpoint
-	canAccessByID  endpoint.Endpoint
-	identify       endpoint.Endpoint
+	timeout         time.Duration
+	canAccessByKey  endpoint.Endpoint
+	canAccessByID   endpoint.Endpoint
+	identify        endpoint.Endpoint
+	retrievePSK     endpoint.Endpoint
+	channelIDByName endpoint.Endpoint
+	retrieveLimits  endpoint.Endpoint
+	retrieveSchema  endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -55,6 +60,38 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeIdentityResponse,
 			mainflux.ThingID{},
 		).Endpoint()),
+		retrievePSK: kitot.TraceClient(tracer, "retrieve_psk")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrievePSK",
+			encodeRetrievePSKRequest,
+			decodePSKResponse,
+			mainflux.Token{},
+		).Endpoint()),
+		channelIDByName: kitot.TraceClient(tracer, "channel_id_by_name")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ChannelIDByName",
+			encodeChannelByNameRequest,
+			decodeChannelIDResponse,
+			mainflux.ChannelID{},
+		).Endpoint()),
+		retrieveLimits: kitot.TraceClient(tracer, "retrieve_limits")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveLimits",
+			encodeRetrieveLimitsRequest,
+			decodeLimitsResponse,
+			mainflux.Limits{},
+		).Endpoint()),
+		retrieveSchema: kitot.TraceClient(tracer, "retrieve_schema")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveSchema",
+			encodeRetrieveSchemaRequest,
+			decodeSchemaResponse,
+			mainflux.Schema{},
+		).Endpoint()),
 	}
 }
 
@@ -65,6 +102,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 	ar := AccessByKeyReq{
 		thingKey: req.GetToken(),
 		chanID:   req.GetChanID(),
+		access:   req.GetAccess(),
 	}
 	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
@@ -76,7 +114,11 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 }
 
 func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
-	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}
+	ar := accessByIDReq{
+		thingID: req.GetThingID(),
+		chanID:  req.GetChanID(),
+		access:  req.GetAccess(),
+	}
 	res, err := client.canAccessByID(ctx, ar)
 	if err != nil {
 		return nil, err
@@ -99,14 +141,66 @@ func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ..
 	return &mainflux.ThingID{Value: ir.id}, ir.err
 }
 
+func (client grpcClient) RetrievePSK(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrievePSK(ctx, retrievePSKReq{id: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(pskRes)
+	return &mainflux.Token{Value: pr.psk}, pr.err
+}
+
+func (client grpcClient) ChannelIDByName(ctx context.Context, req *mainflux.ChannelByNameReq, _ ...grpc.CallOption) (*mainflux.ChannelID, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.channelIDByName(ctx, channelByNameReq{thingID: req.GetThingID(), name: req.GetName()})
+	if err != nil {
+		return nil, err
+	}
+
+	cr := res.(channelIDRes)
+	return &mainflux.ChannelID{Value: cr.id}, cr.err
+}
+
+func (client grpcClient) RetrieveLimits(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.Limits, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveLimits(ctx, retrieveLimitsReq{id: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	lr := res.(limitsRes)
+	return &mainflux.Limits{Rate: lr.limits.Rate, Burst: lr.limits.Burst, Quota: lr.limits.Quota}, lr.err
+}
+
+func (client grpcClient) RetrieveSchema(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Schema, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveSchema(ctx, retrieveSchemaReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(schemaRes)
+	return &mainflux.Schema{Value: sr.schema}, sr.err
+}
+
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(AccessByKeyReq)
-	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
+	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID, Access: req.access}, nil
 }
 
 func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByIDReq)
-	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
+	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID, Access: req.access}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -114,11 +208,56 @@ func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 	return &mainflux.Token{Value: req.key}, nil
 }
 
+func encodeRetrievePSKRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrievePSKReq)
+	return &mainflux.ThingID{Value: req.id}, nil
+}
+
+func encodeChannelByNameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelByNameReq)
+	return &mainflux.ChannelByNameReq{ThingID: req.thingID, Name: req.name}, nil
+}
+
+func encodeRetrieveLimitsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveLimitsReq)
+	return &mainflux.ThingID{Value: req.id}, nil
+}
+
+func encodeRetrieveSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveSchemaReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue(), err: nil}, nil
 }
 
+func decodePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Token)
+	return pskRes{psk: res.GetValue(), err: nil}, nil
+}
+
+func decodeChannelIDResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelID)
+	return channelIDRes{id: res.GetValue(), err: nil}, nil
+}
+
+func decodeLimitsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Limits)
+	limits := things.Limits{
+		Rate:  res.GetRate(),
+		Burst: res.GetBurst(),
+		Quota: res.GetQuota(),
+	}
+	return limitsRes{limits: limits, err: nil}, nil
+}
+
+func decodeSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Schema)
+	return schemaRes{schema: res.GetValue(), err: nil}, nil
+}
+
 func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
 	return emptyRes{}, nil
 }
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+// adapterSecretKey is the request metadata key under which the protocol
+// adapter sends its secret.
+const adapterSecretKey = "adapter-secret"
+
+var _ credentials.PerRPCCredentials = (*adapterCredentials)(nil)
+
+type adapterCredentials struct {
+	secret string
+	secure bool
+}
+
+// NewAdapterCredentials returns per-RPC credentials which authenticate the
+// protocol adapter using the given secret. Only the authenticated adapters
+// can retrieve thing pre-shared keys. If secure is set, the credentials are
+// sent only over TLS connections.
+func NewAdapterCredentials(secret string, secure bool) credentials.PerRPCCredentials {
+	return adapterCredentials{secret: secret, secure: secure}
+}
+
+func (ac adapterCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{adapterSecretKey: ac.secret}, nil
+}
+
+func (ac adapterCredentials) RequireTransportSecurity() bool {
+	return ac.secure
+}
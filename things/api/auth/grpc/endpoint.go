@@ -4,6 +4,8 @@
 package grpc
 
 import (
+	"crypto/subtle"
+
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/things"
 	context "golang.org/x/net/context"
@@ -16,7 +18,7 @@ func canAccessEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey, req.access)
 		if err != nil {
 			return identityRes{err: err}, err
 		}
@@ -31,7 +33,7 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.CanAccessByID(ctx, req.chanID, req.thingID)
+		err := svc.CanAccessByID(ctx, req.chanID, req.thingID, req.access)
 		return emptyRes{err: err}, err
 	}
 }
@@ -46,3 +48,69 @@ func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 		return identityRes{id: id, err: nil}, nil
 	}
 }
+
+func retrievePSKEndpoint(svc things.Service, secret string) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrievePSKReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if secret == "" || subtle.ConstantTimeCompare([]byte(req.secret), []byte(secret)) != 1 {
+			return pskRes{err: things.ErrUnauthorizedAccess}, things.ErrUnauthorizedAccess
+		}
+
+		psk, err := svc.RetrievePSK(ctx, req.id)
+		if err != nil {
+			return pskRes{err: err}, err
+		}
+		return pskRes{psk: psk, err: nil}, nil
+	}
+}
+
+func channelIDByNameEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelByNameReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := svc.ChannelIDByName(ctx, req.thingID, req.name)
+		if err != nil {
+			return channelIDRes{err: err}, err
+		}
+		return channelIDRes{id: id, err: nil}, nil
+	}
+}
+
+func retrieveLimitsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveLimitsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		limits, err := svc.RetrieveLimits(ctx, req.id)
+		if err != nil {
+			return limitsRes{err: err}, err
+		}
+
+		return limitsRes{limits: limits, err: nil}, nil
+	}
+}
+
+func retrieveSchemaEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveSchemaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		schema, err := svc.RetrieveSchema(ctx, req.chanID)
+		if err != nil {
+			return schemaRes{err: err}, err
+		}
+
+		return schemaRes{schema: schema, err: nil}, nil
+	}
+}
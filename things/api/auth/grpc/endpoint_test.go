@@ -15,6 +15,7 @@ import (
 	"github.com/mainflux/mainflux/things"
 	grpcapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -34,7 +35,7 @@ func TestCanAccessByKey(t *testing.T) {
 	cth := sths[0]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{cth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{cth.ID}, things.PublishAccess)
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
 	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
@@ -45,37 +46,56 @@ func TestCanAccessByKey(t *testing.T) {
 	cases := map[string]struct {
 		key     string
 		chanID  string
+		access  string
 		thingID string
 		code    codes.Code
 	}{
 		"check if connected thing can access existing channel": {
 			key:     cth.Key,
 			chanID:  sch.ID,
+			access:  things.PublishAccess,
 			thingID: cth.ID,
 			code:    codes.OK,
 		},
 		"check if unconnected thing can access existing channel": {
 			key:     oth.Key,
 			chanID:  sch.ID,
+			access:  things.PublishAccess,
 			thingID: wrongID,
 			code:    codes.PermissionDenied,
 		},
 		"check if thing with wrong access key can access existing channel": {
 			key:     wrong,
 			chanID:  sch.ID,
+			access:  things.PublishAccess,
 			thingID: wrongID,
 			code:    codes.PermissionDenied,
 		},
 		"check if connected thing can access non-existent channel": {
 			key:     cth.Key,
 			chanID:  wrongID,
+			access:  things.PublishAccess,
+			thingID: wrongID,
+			code:    codes.InvalidArgument,
+		},
+		"check if connected thing can subscribe to publish-only channel": {
+			key:     cth.Key,
+			chanID:  sch.ID,
+			access:  things.SubscribeAccess,
+			thingID: wrongID,
+			code:    codes.PermissionDenied,
+		},
+		"check if connected thing can access existing channel with invalid access type": {
+			key:     cth.Key,
+			chanID:  sch.ID,
+			access:  wrong,
 			thingID: wrongID,
 			code:    codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID})
+		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID, Access: tc.access})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.thingID, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.thingID, id.GetValue()))
@@ -90,7 +110,7 @@ func TestCanAccessByID(t *testing.T) {
 	cth := sths[0]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{cth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{cth.ID}, things.PublishAccess)
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
 	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
@@ -101,37 +121,55 @@ func TestCanAccessByID(t *testing.T) {
 	cases := map[string]struct {
 		chanID  string
 		thingID string
+		access  string
 		code    codes.Code
 	}{
 		"check if connected thing can access existing channel": {
 			chanID:  sch.ID,
 			thingID: cth.ID,
+			access:  things.PublishAccess,
 			code:    codes.OK,
 		},
 		"check if unconnected thing can access existing channel": {
 			chanID:  sch.ID,
 			thingID: oth.ID,
+			access:  things.PublishAccess,
 			code:    codes.PermissionDenied,
 		},
 		"check if connected thing can access non-existent channel": {
 			chanID:  wrongID,
 			thingID: cth.ID,
+			access:  things.PublishAccess,
 			code:    codes.InvalidArgument,
 		},
 		"check if thing with empty ID can access existing channel": {
 			chanID:  sch.ID,
 			thingID: "",
+			access:  things.PublishAccess,
 			code:    codes.InvalidArgument,
 		},
 		"check if connected thing can access channel with empty ID": {
 			chanID:  "",
 			thingID: cth.ID,
+			access:  things.PublishAccess,
+			code:    codes.InvalidArgument,
+		},
+		"check if connected thing can subscribe to publish-only channel": {
+			chanID:  sch.ID,
+			thingID: cth.ID,
+			access:  things.SubscribeAccess,
+			code:    codes.PermissionDenied,
+		},
+		"check if connected thing can access existing channel with invalid access type": {
+			chanID:  sch.ID,
+			thingID: cth.ID,
+			access:  wrong,
 			code:    codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID})
+		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID, Access: tc.access})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
@@ -173,3 +211,210 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestRetrievePSK(t *testing.T) {
+	psk := "9d6f0ba1c3e54e0f8a2b7d16e4c3f952"
+	sths, _ := svc.CreateThings(context.Background(), token, thing)
+	sth := sths[0]
+	err := svc.UpdatePSK(context.Background(), token, sth.ID, psk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure(), grpc.WithPerRPCCredentials(grpcapi.NewAdapterCredentials(adapterSecret, false)))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	wrongConn, _ := grpc.Dial(usersAddr, grpc.WithInsecure(), grpc.WithPerRPCCredentials(grpcapi.NewAdapterCredentials(wrong, false)))
+	wrongCli := grpcapi.NewClient(wrongConn, mocktracer.New(), time.Second)
+	anonConn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
+	anonCli := grpcapi.NewClient(anonConn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		cli  mainflux.ThingsServiceClient
+		id   string
+		psk  string
+		code codes.Code
+	}{
+		"retrieve PSK of existing thing": {
+			cli:  cli,
+			id:   sth.ID,
+			psk:  psk,
+			code: codes.OK,
+		},
+		"retrieve PSK of non-existent thing": {
+			cli:  cli,
+			id:   wrong,
+			psk:  "",
+			code: codes.NotFound,
+		},
+		"retrieve PSK with empty thing ID": {
+			cli:  cli,
+			id:   wrongID,
+			psk:  "",
+			code: codes.InvalidArgument,
+		},
+		"retrieve PSK with wrong adapter secret": {
+			cli:  wrongCli,
+			id:   sth.ID,
+			psk:  "",
+			code: codes.PermissionDenied,
+		},
+		"retrieve PSK without adapter secret": {
+			cli:  anonCli,
+			id:   sth.ID,
+			psk:  "",
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for desc, tc := range cases {
+		psk, err := tc.cli.RetrievePSK(ctx, &mainflux.ThingID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.psk, psk.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.psk, psk.GetValue()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestChannelIDByName(t *testing.T) {
+	sths, _ := svc.CreateThings(context.Background(), token, thing)
+	sth := sths[0]
+	schs, _ := svc.CreateChannels(context.Background(), token, things.Channel{Name: "alias"})
+	sch := schs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		thingID string
+		name    string
+		id      string
+		code    codes.Code
+	}{
+		"retrieve channel ID by name": {
+			thingID: sth.ID,
+			name:    sch.Name,
+			id:      sch.ID,
+			code:    codes.OK,
+		},
+		"retrieve channel ID by non-existent name": {
+			thingID: sth.ID,
+			name:    wrong,
+			id:      "",
+			code:    codes.NotFound,
+		},
+		"retrieve channel ID with empty thing ID": {
+			thingID: wrongID,
+			name:    sch.Name,
+			id:      "",
+			code:    codes.InvalidArgument,
+		},
+		"retrieve channel ID with empty name": {
+			thingID: sth.ID,
+			name:    "",
+			id:      "",
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := cli.ChannelIDByName(ctx, &mainflux.ChannelByNameReq{ThingID: tc.thingID, Name: tc.name})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.id, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.id, id.GetValue()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestRetrieveLimits(t *testing.T) {
+	th := thing
+	th.Metadata = map[string]interface{}{
+		things.LimitsKey: map[string]interface{}{"rate": float64(60), "quota": float64(1000)},
+	}
+	sths, _ := svc.CreateThings(context.Background(), token, th)
+	sth := sths[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id     string
+		limits mainflux.Limits
+		code   codes.Code
+	}{
+		"retrieve limits of existing thing": {
+			id:     sth.ID,
+			limits: mainflux.Limits{Rate: 60, Quota: 1000},
+			code:   codes.OK,
+		},
+		"retrieve limits of non-existent thing": {
+			id:     wrong,
+			limits: mainflux.Limits{},
+			code:   codes.NotFound,
+		},
+		"retrieve limits with empty thing ID": {
+			id:     wrongID,
+			limits: mainflux.Limits{},
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		limits, err := cli.RetrieveLimits(ctx, &mainflux.ThingID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.limits.GetRate(), limits.GetRate(), fmt.Sprintf("%s: expected rate %d got %d", desc, tc.limits.GetRate(), limits.GetRate()))
+		assert.Equal(t, tc.limits.GetBurst(), limits.GetBurst(), fmt.Sprintf("%s: expected burst %d got %d", desc, tc.limits.GetBurst(), limits.GetBurst()))
+		assert.Equal(t, tc.limits.GetQuota(), limits.GetQuota(), fmt.Sprintf("%s: expected quota %d got %d", desc, tc.limits.GetQuota(), limits.GetQuota()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestRetrieveSchema(t *testing.T) {
+	ch := channel
+	ch.Metadata = map[string]interface{}{things.SchemaKey: "senml"}
+	schs, _ := svc.CreateChannels(context.Background(), token, ch)
+	sch := schs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(usersAddr, grpc.WithInsecure())
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id     string
+		schema string
+		code   codes.Code
+	}{
+		"retrieve schema of existing channel": {
+			id:     sch.ID,
+			schema: `"senml"`,
+			code:   codes.OK,
+		},
+		"retrieve schema of non-existent channel": {
+			id:     wrong,
+			schema: "",
+			code:   codes.NotFound,
+		},
+		"retrieve schema with empty channel ID": {
+			id:     wrongID,
+			schema: "",
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		schema, err := cli.RetrieveSchema(ctx, &mainflux.ChannelID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.schema, schema.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.schema, schema.GetValue()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
@@ -8,6 +8,7 @@ import "github.com/mainflux/mainflux/things"
 type AccessByKeyReq struct {
 	thingKey string
 	chanID   string
+	access   string
 }
 
 func (req AccessByKeyReq) validate() error {
@@ -15,12 +16,13 @@ func (req AccessByKeyReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
-	return nil
+	return validateAccess(req.access)
 }
 
 type accessByIDReq struct {
 	thingID string
 	chanID  string
+	access  string
 }
 
 func (req accessByIDReq) validate() error {
@@ -28,6 +30,14 @@ func (req accessByIDReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	return validateAccess(req.access)
+}
+
+func validateAccess(access string) error {
+	if access != things.PublishAccess && access != things.SubscribeAccess {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -42,3 +52,53 @@ func (req identifyReq) validate() error {
 
 	return nil
 }
+
+type channelByNameReq struct {
+	thingID string
+	name    string
+}
+
+func (req channelByNameReq) validate() error {
+	if req.thingID == "" || req.name == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type retrieveLimitsReq struct {
+	id string
+}
+
+func (req retrieveLimitsReq) validate() error {
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type retrieveSchemaReq struct {
+	chanID string
+}
+
+func (req retrieveSchemaReq) validate() error {
+	if req.chanID == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type retrievePSKReq struct {
+	id     string
+	secret string
+}
+
+func (req retrievePSKReq) validate() error {
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
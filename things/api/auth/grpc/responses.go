@@ -3,11 +3,33 @@
 
 package grpc
 
+import "github.com/mainflux/mainflux/things"
+
 type identityRes struct {
 	id  string
 	err error
 }
 
+type pskRes struct {
+	psk string
+	err error
+}
+
+type channelIDRes struct {
+	id  string
+	err error
+}
+
+type limitsRes struct {
+	limits things.Limits
+	err    error
+}
+
+type schemaRes struct {
+	schema string
+	err    error
+}
+
 type emptyRes struct {
 	err error
 }
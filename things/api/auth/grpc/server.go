@@ -8,23 +8,31 @@ import (
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 var _ mainflux.ThingsServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	canAccessByKey kitgrpc.Handler
-	canAccessByID  kitgrpc.Handler
-	identify       kitgrpc.Handler
+	canAccessByKey  kitgrpc.Handler
+	canAccessByID   kitgrpc.Handler
+	identify        kitgrpc.Handler
+	retrievePSK     kitgrpc.Handler
+	channelIDByName kitgrpc.Handler
+	retrieveLimits  kitgrpc.Handler
+	retrieveSchema  kitgrpc.Handler
 }
 
-// NewServer returns new ThingsServiceServer instance.
-func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsServiceServer {
+// NewServer returns new ThingsServiceServer instance. Thing pre-shared keys
+// are retrieved only by the adapters authenticated with the given secret, so
+// the retrieval is disabled if the secret is empty.
+func NewServer(tracer opentracing.Tracer, svc things.Service, adapterSecret string) mainflux.ThingsServiceServer {
 	return &grpcServer{
 		canAccessByKey: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "can_access")(canAccessEndpoint(svc)),
@@ -41,6 +49,26 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeIdentifyRequest,
 			encodeIdentityResponse,
 		),
+		retrievePSK: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_psk")(retrievePSKEndpoint(svc, adapterSecret)),
+			decodeRetrievePSKRequest,
+			encodePSKResponse,
+		),
+		channelIDByName: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "channel_id_by_name")(channelIDByNameEndpoint(svc)),
+			decodeChannelByNameRequest,
+			encodeChannelIDResponse,
+		),
+		retrieveLimits: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_limits")(retrieveLimitsEndpoint(svc)),
+			decodeRetrieveLimitsRequest,
+			encodeLimitsResponse,
+		),
+		retrieveSchema: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_schema")(retrieveSchemaEndpoint(svc)),
+			decodeRetrieveSchemaRequest,
+			encodeSchemaResponse,
+		),
 	}
 }
 
@@ -71,14 +99,50 @@ func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainf
 	return res.(*mainflux.ThingID), nil
 }
 
+func (gs *grpcServer) RetrievePSK(ctx context.Context, req *mainflux.ThingID) (*mainflux.Token, error) {
+	_, res, err := gs.retrievePSK.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Token), nil
+}
+
+func (gs *grpcServer) ChannelIDByName(ctx context.Context, req *mainflux.ChannelByNameReq) (*mainflux.ChannelID, error) {
+	_, res, err := gs.channelIDByName.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelID), nil
+}
+
+func (gs *grpcServer) RetrieveLimits(ctx context.Context, req *mainflux.ThingID) (*mainflux.Limits, error) {
+	_, res, err := gs.retrieveLimits.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Limits), nil
+}
+
+func (gs *grpcServer) RetrieveSchema(ctx context.Context, req *mainflux.ChannelID) (*mainflux.Schema, error) {
+	_, res, err := gs.retrieveSchema.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Schema), nil
+}
+
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
-	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
+	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID(), access: req.GetAccess()}, nil
 }
 
 func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByIDReq)
-	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
+	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), access: req.GetAccess()}, nil
 }
 
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -86,24 +150,86 @@ func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 	return identifyReq{key: req.GetValue()}, nil
 }
 
+func decodeRetrievePSKRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return retrievePSKReq{id: req.GetValue(), secret: adapterSecret(ctx)}, nil
+}
+
+func decodeChannelByNameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelByNameReq)
+	return channelByNameReq{thingID: req.GetThingID(), name: req.GetName()}, nil
+}
+
+func decodeRetrieveLimitsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return retrieveLimitsReq{id: req.GetValue()}, nil
+}
+
+func decodeRetrieveSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return retrieveSchemaReq{chanID: req.GetValue()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, encodeError(res.err)
 }
 
+func encodePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(pskRes)
+	return &mainflux.Token{Value: res.psk}, encodeError(res.err)
+}
+
+func encodeChannelIDResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelIDRes)
+	return &mainflux.ChannelID{Value: res.id}, encodeError(res.err)
+}
+
+func encodeLimitsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(limitsRes)
+	limits := &mainflux.Limits{
+		Rate:  res.limits.Rate,
+		Burst: res.limits.Burst,
+		Quota: res.limits.Quota,
+	}
+	return limits, encodeError(res.err)
+}
+
+func encodeSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(schemaRes)
+	return &mainflux.Schema{Value: res.schema}, encodeError(res.err)
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
 }
 
+// adapterSecret returns the adapter secret sent in the request metadata.
+func adapterSecret(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	vals := md.Get(adapterSecretKey)
+	if len(vals) != 1 {
+		return ""
+	}
+
+	return vals[0]
+}
+
 func encodeError(err error) error {
-	switch err {
-	case nil:
+	switch {
+	case err == nil:
 		return nil
-	case things.ErrMalformedEntity:
+	case errors.Contains(err, things.ErrMalformedEntity):
 		return status.Error(codes.InvalidArgument, "received invalid can access request")
-	case things.ErrUnauthorizedAccess:
+	case errors.Contains(err, things.ErrUnauthorizedAccess):
 		return status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
+	case errors.Contains(err, things.ErrNotFound):
+		return status.Error(codes.NotFound, "entity does not exist")
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
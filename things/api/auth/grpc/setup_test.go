@@ -19,10 +19,11 @@ import (
 )
 
 const (
-	port  = 8080
-	token = "token"
-	wrong = "wrong"
-	email = "john.doe@email.com"
+	port          = 8080
+	token         = "token"
+	wrong         = "wrong"
+	email         = "john.doe@email.com"
+	adapterSecret = "adapter-secret"
 )
 
 var svc things.Service
@@ -37,7 +38,7 @@ func startServer() {
 	svc = newService(map[string]string{token: email})
 	listener, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	server := grpc.NewServer()
-	mainflux.RegisterThingsServiceServer(server, grpcapi.NewServer(mocktracer.New(), svc))
+	mainflux.RegisterThingsServiceServer(server, grpcapi.NewServer(mocktracer.New(), svc, adapterSecret))
 	go server.Serve(listener)
 }
 
@@ -37,7 +37,7 @@ func canAccessByKeyEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.Token)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.Token, req.Access)
 		if err != nil {
 			return nil, err
 		}
@@ -57,7 +57,7 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.CanAccessByID(ctx, req.chanID, req.ThingID); err != nil {
+		if err := svc.CanAccessByID(ctx, req.chanID, req.ThingID, req.Access); err != nil {
 			return nil, err
 		}
 
@@ -156,13 +156,21 @@ func TestCanAccessByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	sch := schs[0]
 
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PublishAccess)
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
-	car := canAccessByKeyReq{
-		Token: sth.Key,
-	}
-	data := toJSON(car)
+	data := toJSON(canAccessByKeyReq{
+		Token:  sth.Key,
+		Access: things.PublishAccess,
+	})
+	subData := toJSON(canAccessByKeyReq{
+		Token:  sth.Key,
+		Access: things.SubscribeAccess,
+	})
+	invalidData := toJSON(canAccessByKeyReq{
+		Token:  sth.Key,
+		Access: wrong,
+	})
 
 	cases := map[string]struct {
 		contentType string
@@ -182,6 +190,18 @@ func TestCanAccessByKey(t *testing.T) {
 			req:         data,
 			status:      http.StatusForbidden,
 		},
+		"check access with access type not granted by connection": {
+			contentType: contentType,
+			chanID:      sch.ID,
+			req:         subData,
+			status:      http.StatusForbidden,
+		},
+		"check access with invalid access type": {
+			contentType: contentType,
+			chanID:      sch.ID,
+			req:         invalidData,
+			status:      http.StatusBadRequest,
+		},
 		"check access with invalid content type": {
 			contentType: wrong,
 			chanID:      sch.ID,
@@ -235,13 +255,21 @@ func TestCanAccessByID(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	sch := schs[0]
 
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PublishAccess)
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
-	car := canAccessByIDReq{
+	data := toJSON(canAccessByIDReq{
 		ThingID: sth.ID,
-	}
-	data := toJSON(car)
+		Access:  things.PublishAccess,
+	})
+	subData := toJSON(canAccessByIDReq{
+		ThingID: sth.ID,
+		Access:  things.SubscribeAccess,
+	})
+	invalidData := toJSON(canAccessByIDReq{
+		ThingID: sth.ID,
+		Access:  wrong,
+	})
 
 	cases := map[string]struct {
 		contentType string
@@ -261,6 +289,18 @@ func TestCanAccessByID(t *testing.T) {
 			req:         data,
 			status:      http.StatusForbidden,
 		},
+		"check access with access type not granted by connection": {
+			contentType: contentType,
+			chanID:      sch.ID,
+			req:         subData,
+			status:      http.StatusForbidden,
+		},
+		"check access with invalid access type": {
+			contentType: contentType,
+			chanID:      sch.ID,
+			req:         invalidData,
+			status:      http.StatusBadRequest,
+		},
 		"check access with invalid content type": {
 			contentType: wrong,
 			chanID:      sch.ID,
@@ -306,9 +346,11 @@ type identifyReq struct {
 }
 
 type canAccessByKeyReq struct {
-	Token string `json:"token"`
+	Token  string `json:"token"`
+	Access string `json:"access"`
 }
 
 type canAccessByIDReq struct {
 	ThingID string `json:"thing_id"`
+	Access  string `json:"access"`
 }
@@ -26,6 +26,7 @@ func (req identifyReq) validate() error {
 type canAccessByKeyReq struct {
 	chanID string
 	Token  string `json:"token"`
+	Access string `json:"access"`
 }
 
 func (req canAccessByKeyReq) validate() error {
@@ -33,12 +34,13 @@ func (req canAccessByKeyReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
-	return nil
+	return validateAccess(req.Access)
 }
 
 type canAccessByIDReq struct {
 	chanID  string
 	ThingID string `json:"thing_id"`
+	Access  string `json:"access"`
 }
 
 func (req canAccessByIDReq) validate() error {
@@ -46,5 +48,13 @@ func (req canAccessByIDReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
+	return validateAccess(req.Access)
+}
+
+func validateAccess(access string) error {
+	if access != things.PublishAccess && access != things.SubscribeAccess {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
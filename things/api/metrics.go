@@ -58,6 +58,15 @@ func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return ms.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (ms *metricsMiddleware) UpdatePSK(ctx context.Context, token, id, psk string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_psk").Add(1)
+		ms.latency.With("method", "update_psk").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdatePSK(ctx, token, id, psk)
+}
+
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing").Add(1)
@@ -148,13 +157,13 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return ms.svc.RemoveChannel(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string, access string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Connect(ctx, token, chIDs, thIDs)
+	return ms.svc.Connect(ctx, token, chIDs, thIDs, access)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) error {
@@ -166,22 +175,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thin
 	return ms.svc.Disconnect(ctx, token, chanID, thingID)
 }
 
-func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key, access string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
 		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByKey(ctx, id, key)
+	return ms.svc.CanAccessByKey(ctx, id, key, access)
 }
 
-func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, access string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_id").Add(1)
 		ms.latency.With("method", "can_access_by_id").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByID(ctx, chanID, thingID)
+	return ms.svc.CanAccessByID(ctx, chanID, thingID, access)
 }
 
 func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string, error) {
@@ -192,3 +201,39 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 
 	return ms.svc.Identify(ctx, key)
 }
+
+func (ms *metricsMiddleware) RetrievePSK(ctx context.Context, id string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_psk").Add(1)
+		ms.latency.With("method", "retrieve_psk").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrievePSK(ctx, id)
+}
+
+func (ms *metricsMiddleware) ChannelIDByName(ctx context.Context, thingID, name string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "channel_id_by_name").Add(1)
+		ms.latency.With("method", "channel_id_by_name").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ChannelIDByName(ctx, thingID, name)
+}
+
+func (ms *metricsMiddleware) RetrieveLimits(ctx context.Context, id string) (things.Limits, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_limits").Add(1)
+		ms.latency.With("method", "retrieve_limits").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveLimits(ctx, id)
+}
+
+func (ms *metricsMiddleware) RetrieveSchema(ctx context.Context, chanID string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_schema").Add(1)
+		ms.latency.With("method", "retrieve_schema").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveSchema(ctx, chanID)
+}
@@ -326,6 +326,10 @@ func TestUpdateThing(t *testing.T) {
 	th.Name = invalidName
 	invalidData := toJSON(th)
 
+	th = thing
+	th.Metadata = map[string]interface{}{things.LimitsKey: map[string]interface{}{"rate": -1}}
+	invalidLimits := toJSON(th)
+
 	cases := []struct {
 		desc        string
 		req         string
@@ -413,6 +417,14 @@ func TestUpdateThing(t *testing.T) {
 			auth:        token,
 			status:      http.StatusBadRequest,
 		},
+		{
+			desc:        "update thing with invalid limits",
+			req:         invalidLimits,
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -551,6 +563,110 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestUpdatePSK(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	sths, _ := svc.CreateThings(context.Background(), token, thing)
+	sth := sths[0]
+
+	psk := "9d6f0ba1c3e54e0f8a2b7d16e4c3f952"
+	data := fmt.Sprintf(`{"psk":"%s"}`, psk)
+
+	cases := []struct {
+		desc        string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "update PSK for an existing thing",
+			req:         data,
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update PSK with too short key",
+			req:         `{"psk":"secret"}`,
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update PSK of non-existent thing",
+			req:         data,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update PSK with invalid user token",
+			req:         data,
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update PSK with empty user token",
+			req:         data,
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        "",
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update PSK with invalid data format",
+			req:         "{",
+			id:          sth.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update PSK without content type",
+			req:         data,
+			id:          sth.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/things/%s/psk", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/things/%s", ts.URL, sth.ID),
+		token:  token,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	body, err := ioutil.ReadAll(res.Body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.NotContains(t, string(body), psk, "view thing: expected PSK not to be returned")
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -793,7 +909,7 @@ func TestListThingsByChannel(t *testing.T) {
 		sths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		sth := sths[0]
-		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		thres := thingRes{
@@ -1202,6 +1318,10 @@ func TestUpdateChannel(t *testing.T) {
 	ch.Name = invalidName
 	invalidData := toJSON(ch)
 
+	ch = channel
+	ch.Metadata = map[string]interface{}{things.SchemaKey: "xml"}
+	invalidSchema := toJSON(ch)
+
 	cases := []struct {
 		desc        string
 		req         string
@@ -1289,6 +1409,14 @@ func TestUpdateChannel(t *testing.T) {
 			auth:        token,
 			status:      http.StatusBadRequest,
 		},
+		{
+			desc:        "update channel with invalid schema",
+			req:         invalidSchema,
+			id:          sch.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1316,7 +1444,7 @@ func TestViewChannel(t *testing.T) {
 
 	sths, _ := svc.CreateThings(context.Background(), token, thing)
 	sth := sths[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 
 	chres := channelRes{
 		ID:       sch.ID,
@@ -1399,7 +1527,7 @@ func TestListChannels(t *testing.T) {
 		sths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		sth := sths[0]
-		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 
 		chres := channelRes{
 			ID:       sch.ID,
@@ -1554,7 +1682,7 @@ func TestListChannelsByThing(t *testing.T) {
 		schs, err := svc.CreateChannels(context.Background(), token, channel)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		sch := schs[0]
-		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		chres := channelRes{
@@ -1874,6 +2002,7 @@ func TestCreateConnections(t *testing.T) {
 		desc        string
 		channelIDs  []string
 		thingIDs    []string
+		access      string
 		auth        string
 		contentType string
 		body        string
@@ -1887,6 +2016,24 @@ func TestCreateConnections(t *testing.T) {
 			contentType: contentType,
 			status:      http.StatusOK,
 		},
+		{
+			desc:        "connect existing things to existing channels with publish access",
+			channelIDs:  achs,
+			thingIDs:    ths,
+			access:      things.PublishAccess,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect existing things to existing channels with invalid access type",
+			channelIDs:  achs,
+			thingIDs:    ths,
+			access:      "invalid",
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
 		{
 			desc:        "connect existing things to non-existent channels",
 			channelIDs:  []string{strconv.FormatUint(wrongID, 10)},
@@ -2004,9 +2151,11 @@ func TestCreateConnections(t *testing.T) {
 		data := struct {
 			ChannelIDs []string `json:"channel_ids"`
 			ThingIDs   []string `json:"thing_ids"`
+			Access     string   `json:"access,omitempty"`
 		}{
 			tc.channelIDs,
 			tc.thingIDs,
+			tc.access,
 		}
 		body := toJSON(data)
 
@@ -2043,7 +2192,7 @@ func TestDisconnnect(t *testing.T) {
 	ath := sths[0]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	ach := schs[0]
-	svc.Connect(context.Background(), token, []string{ach.ID}, []string{ath.ID})
+	svc.Connect(context.Background(), token, []string{ach.ID}, []string{ath.ID}, things.PubSubAccess)
 	schs, _ = svc.CreateChannels(context.Background(), otherToken, channel)
 	bch := schs[0]
 
@@ -30,6 +30,10 @@ func (req createThingReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if _, err := things.Metadata(req.Metadata).Limits(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -51,6 +55,10 @@ func (req createThingsReq) validate() error {
 		if len(thing.Name) > maxNameSize {
 			return things.ErrMalformedEntity
 		}
+
+		if _, err := things.Metadata(thing.Metadata).Limits(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -76,6 +84,10 @@ func (req updateThingReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if _, err := things.Metadata(req.Metadata).Limits(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -97,6 +109,24 @@ func (req updateKeyReq) validate() error {
 	return nil
 }
 
+type updatePSKReq struct {
+	token string
+	id    string
+	PSK   string `json:"psk"`
+}
+
+func (req updatePSKReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type createChannelReq struct {
 	token    string
 	Name     string                 `json:"name,omitempty"`
@@ -112,6 +142,10 @@ func (req createChannelReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if _, err := things.Metadata(req.Metadata).Schema(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -133,6 +167,10 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > maxNameSize {
 			return things.ErrMalformedEntity
 		}
+
+		if _, err := things.Metadata(channel.Metadata).Schema(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -158,6 +196,10 @@ func (req updateChannelReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if _, err := things.Metadata(req.Metadata).Schema(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -247,6 +289,7 @@ type createConnectionsReq struct {
 	token      string
 	ChannelIDs []string `json:"channel_ids,omitempty"`
 	ThingIDs   []string `json:"thing_ids,omitempty"`
+	Access     string   `json:"access,omitempty"`
 }
 
 func (req createConnectionsReq) validate() error {
@@ -269,5 +312,10 @@ func (req createConnectionsReq) validate() error {
 		}
 	}
 
-	return nil
+	switch req.Access {
+	case things.PublishAccess, things.SubscribeAccess, things.PubSubAccess:
+		return nil
+	default:
+		return things.ErrMalformedEntity
+	}
 }
@@ -16,6 +16,15 @@ type Channel struct {
 	Metadata map[string]interface{}
 }
 
+// Connection access types. A thing connected with publish access can only
+// send messages to the channel, while the one connected with subscribe access
+// can only receive them.
+const (
+	PublishAccess   = "publish"
+	SubscribeAccess = "subscribe"
+	PubSubAccess    = "pubsub"
+)
+
 // ChannelsPage contains page related metadata as well as list of channels that
 // belong to this page.
 type ChannelsPage struct {
@@ -38,6 +47,13 @@ type ChannelRepository interface {
 	// by the specified user.
 	RetrieveByID(context.Context, string, string) (Channel, error)
 
+	// RetrieveIDByName retrieves the identifier of the channel having the
+	// provided name, that is owned by the owner of the specified thing.
+	RetrieveIDByName(context.Context, string, string) (string, error)
+
+	// RetrieveMetadataByID returns channel metadata for given channel ID.
+	RetrieveMetadataByID(context.Context, string) (Metadata, error)
+
 	// RetrieveAll retrieves the subset of channels owned by the specified user.
 	RetrieveAll(context.Context, string, uint64, uint64, string, Metadata) (ChannelsPage, error)
 
@@ -49,31 +65,33 @@ type ChannelRepository interface {
 	// by the specified user.
 	Remove(context.Context, string, string) error
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(context.Context, string, []string, []string) error
+	// Connect adds things to the channel's list of connected things, using
+	// the provided access type.
+	Connect(context.Context, string, []string, []string, string) error
 
 	// Disconnect removes thing from the channel's list of connected
 	// things.
 	Disconnect(context.Context, string, string, string) error
 
 	// HasThing determines whether the thing with the provided access key, is
-	// "connected" to the specified channel. If that's the case, it returns
-	// thing's ID.
-	HasThing(context.Context, string, string) (string, error)
+	// "connected" to the specified channel with the given access type. If
+	// that's the case, it returns thing's ID.
+	HasThing(context.Context, string, string, string) (string, error)
 
 	// HasThingByID determines whether the thing with the provided ID, is
-	// "connected" to the specified channel. If that's the case, then
-	// returned error will be nil.
-	HasThingByID(context.Context, string, string) error
+	// "connected" to the specified channel with the given access type. If
+	// that's the case, then returned error will be nil.
+	HasThingByID(context.Context, string, string, string) error
 }
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect caches channel thing connection with the given access type.
+	Connect(context.Context, string, string, string) error
 
-	// HasThing checks if thing is connected to channel.
-	HasThing(context.Context, string, string) bool
+	// HasThing checks if thing is connected to channel with the given
+	// access type.
+	HasThing(context.Context, string, string, string) bool
 
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "math"
+
+// LimitsKey is the thing metadata key under which the thing publishing
+// limits are set, e.g. {"limits": {"rate": 60, "burst": 10, "quota": 10000}}.
+const LimitsKey = "limits"
+
+// Limits represents the limits the protocol adapters apply to the messages
+// published by the thing. Zero value of the limit means the limit is not set
+// for the thing, so the adapter default one applies.
+type Limits struct {
+	// Rate is the number of messages per minute the thing can publish.
+	Rate uint64
+
+	// Burst is the number of messages the thing can publish at once, before
+	// the rate limit applies.
+	Burst uint64
+
+	// Quota is the number of messages per day the thing can publish.
+	Quota uint64
+}
+
+// Limits returns the thing publishing limits set in the metadata. Limits
+// must be the object of non-negative integers.
+func (m Metadata) Limits() (Limits, error) {
+	v, ok := m[LimitsKey]
+	if !ok {
+		return Limits{}, nil
+	}
+
+	lm, ok := v.(map[string]interface{})
+	if !ok {
+		return Limits{}, ErrMalformedEntity
+	}
+
+	var l Limits
+	fields := map[string]*uint64{
+		"rate":  &l.Rate,
+		"burst": &l.Burst,
+		"quota": &l.Quota,
+	}
+	for k, v := range lm {
+		f, ok := fields[k]
+		if !ok {
+			return Limits{}, ErrMalformedEntity
+		}
+		n, ok := v.(float64)
+		if !ok || n < 0 || n != math.Trunc(n) {
+			return Limits{}, ErrMalformedEntity
+		}
+		*f = uint64(n)
+	}
+
+	return l, nil
+}
@@ -30,6 +30,7 @@ type channelRepositoryMock struct {
 	channels map[string]things.Channel
 	tconns   chan Connection                      // used for syncronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	access   map[string]string                    // used to track connection access types
 	things   things.ThingRepository
 }
 
@@ -39,6 +40,7 @@ func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) t
 		channels: make(map[string]things.Channel),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		access:   make(map[string]string),
 		things:   repo,
 	}
 }
@@ -78,6 +80,34 @@ func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, owner, id stri
 	return things.Channel{}, things.ErrNotFound
 }
 
+func (crm *channelRepositoryMock) RetrieveIDByName(ctx context.Context, thingID, name string) (string, error) {
+	ids := []string{}
+	for _, c := range crm.channels {
+		if c.Name != name {
+			continue
+		}
+		if _, err := crm.things.RetrieveByID(ctx, c.Owner, thingID); err == nil {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	if len(ids) != 1 {
+		return "", things.ErrNotFound
+	}
+
+	return ids[0], nil
+}
+
+func (crm *channelRepositoryMock) RetrieveMetadataByID(_ context.Context, id string) (things.Metadata, error) {
+	for _, c := range crm.channels {
+		if c.ID == id {
+			return c.Metadata, nil
+		}
+	}
+
+	return nil, things.ErrNotFound
+}
+
 func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	channels := make([]things.Channel, 0)
 
@@ -160,7 +190,7 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner, id string) er
 	return nil
 }
 
-func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string, access string) error {
 	for _, chID := range chIDs {
 		ch, err := crm.RetrieveByID(context.Background(), owner, chID)
 		if err != nil {
@@ -182,6 +212,7 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs
 				crm.cconns[thID] = make(map[string]things.Channel)
 			}
 			crm.cconns[thID][chID] = ch
+			crm.access[key(chID, thID)] = access
 		}
 	}
 
@@ -206,7 +237,7 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chanID, t
 	return nil
 }
 
-func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token string) (string, error) {
+func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token, access string) (string, error) {
 	tid, err := crm.things.RetrieveByKey(context.Background(), token)
 	if err != nil {
 		return "", things.ErrNotFound
@@ -221,10 +252,14 @@ func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token stri
 		return "", things.ErrNotFound
 	}
 
+	if !crm.hasAccess(chanID, tid, access) {
+		return "", things.ErrNotFound
+	}
+
 	return tid, nil
 }
 
-func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
+func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID, access string) error {
 	chans, ok := crm.cconns[thingID]
 	if !ok {
 		return things.ErrNotFound
@@ -234,9 +269,18 @@ func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingI
 		return things.ErrNotFound
 	}
 
+	if !crm.hasAccess(chanID, thingID, access) {
+		return things.ErrNotFound
+	}
+
 	return nil
 }
 
+func (crm *channelRepositoryMock) hasAccess(chanID, thingID, access string) bool {
+	a := crm.access[key(chanID, thingID)]
+	return a == access || a == things.PubSubAccess
+}
+
 type channelCacheMock struct {
 	mu       sync.Mutex
 	channels map[string]string
@@ -249,26 +293,26 @@ func NewChannelCache() things.ChannelCache {
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID, access string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	ccm.channels[chanID] = thingID
+	ccm.channels[key(chanID, access)] = thingID
 	return nil
 }
 
-func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
+func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID, access string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	return ccm.channels[key(chanID, access)] == thingID
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	ccm.remove(chanID)
 	return nil
 }
 
@@ -276,6 +320,12 @@ func (ccm *channelCacheMock) Remove(_ context.Context, chanID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	ccm.remove(chanID)
 	return nil
 }
+
+func (ccm *channelCacheMock) remove(chanID string) {
+	for _, access := range []string{things.PublishAccess, things.SubscribeAccess, things.PubSubAccess} {
+		delete(ccm.channels, key(chanID, access))
+	}
+}
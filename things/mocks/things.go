@@ -22,6 +22,7 @@ type thingRepositoryMock struct {
 	conns   chan Connection
 	tconns  map[string]map[string]things.Thing
 	things  map[string]things.Thing
+	psks    map[string]string
 }
 
 // NewThingRepository creates in-memory thing repository.
@@ -29,6 +30,7 @@ func NewThingRepository(conns chan Connection) things.ThingRepository {
 	repo := &thingRepositoryMock{
 		conns:  conns,
 		things: make(map[string]things.Thing),
+		psks:   make(map[string]string),
 		tconns: make(map[string]map[string]things.Thing),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
@@ -101,6 +103,19 @@ func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val stri
 	return nil
 }
 
+func (trm *thingRepositoryMock) UpdatePSK(_ context.Context, owner, id, psk string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.things[key(owner, id)]; !ok {
+		return things.ErrNotFound
+	}
+
+	trm.psks[id] = psk
+
+	return nil
+}
+
 func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Thing, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -195,6 +210,9 @@ func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chan
 func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
+	if _, ok := trm.things[key(owner, id)]; ok {
+		delete(trm.psks, id)
+	}
 	delete(trm.things, key(owner, id))
 	return nil
 }
@@ -212,6 +230,32 @@ func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (st
 	return "", things.ErrNotFound
 }
 
+func (trm *thingRepositoryMock) RetrieveMetadataByID(_ context.Context, id string) (things.Metadata, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, thing := range trm.things {
+		if thing.ID == id {
+			return thing.Metadata, nil
+		}
+	}
+
+	return nil, things.ErrNotFound
+}
+
+func (trm *thingRepositoryMock) RetrievePSK(_ context.Context, id string) (string, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, thing := range trm.things {
+		if thing.ID == id {
+			return trm.psks[id], nil
+		}
+	}
+
+	return "", things.ErrNotFound
+}
+
 func (trm *thingRepositoryMock) connect(conn Connection) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -46,6 +46,7 @@ type dbConnection struct {
 	Channel string `db:"channel"`
 	Thing   string `db:"thing"`
 	Owner   string `db:"owner"`
+	Access  string `db:"access"`
 }
 
 // NewChannelRepository instantiates a PostgreSQL implementation of channel
@@ -140,6 +141,60 @@ func (cr channelRepository) RetrieveByID(ctx context.Context, owner, id string)
 	return toChannel(dbch), nil
 }
 
+func (cr channelRepository) RetrieveIDByName(ctx context.Context, thingID, name string) (string, error) {
+	// Two rows are enough to tell whether the name is unique among the
+	// channels of the owner.
+	q := `SELECT ch.id FROM channels ch INNER JOIN things th ON ch.owner = th.owner
+	      WHERE th.id = :thing AND ch.name = :name LIMIT 2;`
+
+	params := map[string]interface{}{
+		"thing": thingID,
+		"name":  name,
+	}
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return "", things.ErrNotFound
+		}
+		return "", errors.Wrap(ErrSelectChannel, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", errors.Wrap(ErrSelectChannel, err)
+		}
+		ids = append(ids, id)
+	}
+
+	// The name can be resolved only if it's unique among the channels
+	// of the owner.
+	if len(ids) != 1 {
+		return "", things.ErrNotFound
+	}
+
+	return ids[0], nil
+}
+
+func (cr channelRepository) RetrieveMetadataByID(ctx context.Context, id string) (things.Metadata, error) {
+	q := `SELECT metadata FROM channels WHERE id = $1;`
+
+	dbch := dbChannel{ID: id}
+	if err := cr.db.QueryRowxContext(ctx, q, id).StructScan(&dbch); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return nil, things.ErrNotFound
+		}
+		return nil, errors.Wrap(ErrSelectChannel, err)
+	}
+
+	return toChannel(dbch).Metadata, nil
+}
+
 func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	nq, name := getNameQuery(name)
 	m, mq, err := getMetadataQuery(metadata)
@@ -263,14 +318,14 @@ func (cr channelRepository) Remove(ctx context.Context, owner, id string) error
 	return nil
 }
 
-func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string, access string) error {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(ErrDeleteChannel, err)
 	}
 
-	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner)
-	      VALUES (:channel, :owner, :thing, :owner);`
+	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, access)
+	      VALUES (:channel, :owner, :thing, :owner, :access);`
 
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
@@ -278,6 +333,7 @@ func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, th
 				Channel: chID,
 				Thing:   thID,
 				Owner:   owner,
+				Access:  access,
 			}
 
 			_, err := tx.NamedExecContext(ctx, q, dbco)
@@ -333,7 +389,7 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner, chanID, thing
 	return nil
 }
 
-func (cr channelRepository) HasThing(ctx context.Context, chanID, key string) (string, error) {
+func (cr channelRepository) HasThing(ctx context.Context, chanID, key, access string) (string, error) {
 	var thingID string
 	q := `SELECT id FROM things WHERE key = $1`
 	if err := cr.db.QueryRowxContext(ctx, q, key).Scan(&thingID); err != nil {
@@ -341,21 +397,22 @@ func (cr channelRepository) HasThing(ctx context.Context, chanID, key string) (s
 
 	}
 
-	if err := cr.hasThing(ctx, chanID, thingID); err != nil {
+	if err := cr.hasThing(ctx, chanID, thingID, access); err != nil {
 		return "", errors.Wrap(ErrHasThing, err)
 	}
 
 	return thingID, nil
 }
 
-func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID string) error {
-	return cr.hasThing(ctx, chanID, thingID)
+func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID, access string) error {
+	return cr.hasThing(ctx, chanID, thingID, access)
 }
 
-func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID string) error {
-	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2);`
+func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID, access string) error {
+	q := `SELECT EXISTS (SELECT 1 FROM connections
+	      WHERE channel_id = $1 AND thing_id = $2 AND access IN ($3, $4));`
 	exists := false
-	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).Scan(&exists); err != nil {
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID, access, things.PubSubAccess).Scan(&exists); err != nil {
 		return errors.Wrap(ErrHasThing, err)
 	}
 
@@ -179,7 +179,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 
 	schs, _ := chanRepo.Save(context.Background(), ch)
 	ch.ID = schs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID})
+	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID}, things.PubSubAccess)
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -217,6 +217,131 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	}
 }
 
+func TestChannelRetrieveMetadataByID(t *testing.T) {
+	email := "channel-metadata-retrieved-by-id@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	chid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentChanID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ch := things.Channel{
+		ID:       chid,
+		Owner:    email,
+		Metadata: things.Metadata{things.SchemaKey: "senml"},
+	}
+
+	schs, _ := chanRepo.Save(context.Background(), ch)
+	ch.ID = schs[0].ID
+
+	cases := map[string]struct {
+		ID       string
+		metadata things.Metadata
+		err      error
+	}{
+		"retrieve metadata of existing channel": {
+			ID:       ch.ID,
+			metadata: ch.Metadata,
+			err:      nil,
+		},
+		"retrieve metadata of non-existing channel": {
+			ID:       nonexistentChanID,
+			metadata: nil,
+			err:      things.ErrNotFound,
+		},
+		"retrieve metadata with malformed channel ID": {
+			ID:       wrongValue,
+			metadata: nil,
+			err:      things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		metadata, err := chanRepo.RetrieveMetadataByID(context.Background(), tc.ID)
+		assert.Equal(t, tc.metadata, metadata, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.metadata, metadata))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestChannelIDRetrievalByName(t *testing.T) {
+	email := "channel-name-retrieval@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	thid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	th := things.Thing{
+		ID:    thid,
+		Owner: email,
+		Key:   thkey,
+	}
+	sths, _ := thingRepo.Save(context.Background(), th)
+	th.ID = sths[0].ID
+
+	chs := []things.Channel{
+		{Owner: email, Name: "unique"},
+		{Owner: email, Name: "duplicate"},
+		{Owner: email, Name: "duplicate"},
+		{Owner: wrongValue, Name: "foreign"},
+	}
+	for i := range chs {
+		chs[i].ID, err = uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	_, err = chanRepo.Save(context.Background(), chs...)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		thingID string
+		name    string
+		id      string
+		err     error
+	}{
+		"retrieve channel ID by unique name": {
+			thingID: th.ID,
+			name:    "unique",
+			id:      chs[0].ID,
+			err:     nil,
+		},
+		"retrieve channel ID by duplicate name": {
+			thingID: th.ID,
+			name:    "duplicate",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+		"retrieve channel ID by name of other owner's channel": {
+			thingID: th.ID,
+			name:    "foreign",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+		"retrieve channel ID by non-existing name": {
+			thingID: th.ID,
+			name:    wrongValue,
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+		"retrieve channel ID with malformed thing ID": {
+			thingID: wrongValue,
+			name:    "unique",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := chanRepo.RetrieveIDByName(context.Background(), tc.thingID, tc.name)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
 func TestMultiChannelRetrieval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	chanRepo := postgres.NewChannelRepository(dbMiddleware)
@@ -370,7 +495,7 @@ func TestMultiChannelRetrievalByThing(t *testing.T) {
 		schs, err := chanRepo.Save(context.Background(), ch)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		cid := schs[0].ID
-		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{tid})
+		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{tid}, things.PubSubAccess)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -535,7 +660,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chanID}, []string{tc.thingID})
+		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chanID}, []string{tc.thingID}, things.PubSubAccess)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -567,7 +692,7 @@ func TestDisconnect(t *testing.T) {
 		Owner: email,
 	})
 	chanID := schs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID})
+	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID}, things.PubSubAccess)
 
 	nonexistentThingID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -651,7 +776,7 @@ func TestHasThing(t *testing.T) {
 		Owner: email,
 	})
 	chanID := schs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID})
+	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID}, things.PublishAccess)
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -659,27 +784,37 @@ func TestHasThing(t *testing.T) {
 	cases := map[string]struct {
 		chanID    string
 		key       string
+		access    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			chanID:    chanID,
 			key:       thing.Key,
+			access:    things.PublishAccess,
 			hasAccess: true,
 		},
+		"access check for thing with different access type": {
+			chanID:    chanID,
+			key:       thing.Key,
+			access:    things.SubscribeAccess,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chanID:    chanID,
 			key:       wrongValue,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chanID:    nonexistentChanID,
 			key:       thing.Key,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.HasThing(context.Background(), tc.chanID, tc.key)
+		_, err := chanRepo.HasThing(context.Background(), tc.chanID, tc.key, tc.access)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -724,7 +859,7 @@ func TestHasThingByID(t *testing.T) {
 		Owner: email,
 	})
 	chanID := schs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID})
+	chanRepo.Connect(context.Background(), email, []string{chanID}, []string{thingID}, things.PublishAccess)
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -732,32 +867,43 @@ func TestHasThingByID(t *testing.T) {
 	cases := map[string]struct {
 		chanID    string
 		thingID   string
+		access    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			chanID:    chanID,
 			thingID:   thingID,
+			access:    things.PublishAccess,
 			hasAccess: true,
 		},
+		"access check for thing with different access type": {
+			chanID:    chanID,
+			thingID:   thingID,
+			access:    things.SubscribeAccess,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chanID:    chanID,
 			thingID:   disconnectedThingID,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chanID:    nonexistentChanID,
 			thingID:   thingID,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 		"access check for non-existing thing": {
 			chanID:    chanID,
 			thingID:   wrongValue,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		err := chanRepo.HasThingByID(context.Background(), tc.chanID, tc.thingID)
+		err := chanRepo.HasThingByID(context.Background(), tc.chanID, tc.thingID, tc.access)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -93,6 +93,28 @@ func migrateDB(db *sqlx.DB) error {
 					 metadata TYPE JSONB using metadata::text::jsonb`,
 				},
 			},
+			{
+				Id: "things_4",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS
+					 access VARCHAR(16) NOT NULL DEFAULT 'pubsub'`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS access`,
+				},
+			},
+			{
+				Id: "things_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS
+					 psk VARCHAR(1024) NOT NULL DEFAULT ''`,
+					`UPDATE things SET psk = metadata->>'dtls_psk', metadata = metadata - 'dtls_psk'
+					 WHERE jsonb_typeof(metadata->'dtls_psk') = 'string'`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS psk`,
+				},
+			},
 		},
 	}
 
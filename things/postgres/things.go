@@ -122,6 +122,37 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 	return nil
 }
 
+func (tr thingRepository) UpdatePSK(ctx context.Context, owner, id, psk string) error {
+	q := `UPDATE things SET psk = :psk WHERE owner = :owner AND id = :id;`
+
+	params := map[string]interface{}{
+		"id":    id,
+		"owner": owner,
+		"psk":   psk,
+	}
+
+	res, err := tr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(things.ErrMalformedEntity, err)
+		}
+
+		return errors.Wrap(ErrUpdateDb, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(ErrUpdateDb, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
 func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
 	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
 
@@ -194,6 +225,41 @@ func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string
 	return id, nil
 }
 
+func (tr thingRepository) RetrieveMetadataByID(ctx context.Context, id string) (things.Metadata, error) {
+	q := `SELECT metadata FROM things WHERE id = $1;`
+
+	dbth := dbThing{ID: id}
+	if err := tr.db.QueryRowxContext(ctx, q, id).StructScan(&dbth); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return nil, errors.Wrap(things.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(ErrSelectDb, err)
+	}
+
+	th, err := toThing(dbth)
+	if err != nil {
+		return nil, err
+	}
+
+	return th.Metadata, nil
+}
+
+func (tr thingRepository) RetrievePSK(ctx context.Context, id string) (string, error) {
+	q := `SELECT psk FROM things WHERE id = $1;`
+
+	var psk string
+	if err := tr.db.QueryRowxContext(ctx, q, id).Scan(&psk); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return "", errors.Wrap(things.ErrNotFound, err)
+		}
+		return "", errors.Wrap(ErrSelectDb, err)
+	}
+
+	return psk, nil
+}
+
 func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, tm things.Metadata) (things.Page, error) {
 	nq, name := getNameQuery(name)
 	m, mq, err := getMetadataQuery(tm)
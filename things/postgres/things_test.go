@@ -381,6 +381,125 @@ func TestThingRetrieveByKey(t *testing.T) {
 	}
 }
 
+func TestThingRetrieveMetadataByID(t *testing.T) {
+	email := "thing-metadata-retrieved-by-id@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	thid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	thing := things.Thing{
+		ID:       thid,
+		Owner:    email,
+		Key:      thkey,
+		Metadata: things.Metadata{"field": "value"},
+	}
+
+	sths, _ := thingRepo.Save(context.Background(), thing)
+	thing.ID = sths[0].ID
+
+	cases := map[string]struct {
+		ID       string
+		metadata things.Metadata
+		err      error
+	}{
+		"retrieve metadata of existing thing": {
+			ID:       thing.ID,
+			metadata: thing.Metadata,
+			err:      nil,
+		},
+		"retrieve metadata of non-existent thing": {
+			ID:       nonexistentID,
+			metadata: nil,
+			err:      things.ErrNotFound,
+		},
+		"retrieve metadata with invalid thing ID": {
+			ID:       "invalid",
+			metadata: nil,
+			err:      things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		metadata, err := thingRepo.RetrieveMetadataByID(context.Background(), tc.ID)
+		assert.Equal(t, tc.metadata, metadata, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.metadata, metadata))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestThingPSK(t *testing.T) {
+	email := "thing-psk@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	thid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	thing := things.Thing{
+		ID:    thid,
+		Owner: email,
+		Key:   thkey,
+	}
+
+	sths, _ := thingRepo.Save(context.Background(), thing)
+	thing.ID = sths[0].ID
+
+	psk := "9d6f0ba1c3e54e0f8a2b7d16e4c3f952"
+
+	cases := map[string]struct {
+		owner string
+		ID    string
+		psk   string
+		err   error
+	}{
+		"update PSK of existing thing": {
+			owner: thing.Owner,
+			ID:    thing.ID,
+			psk:   psk,
+			err:   nil,
+		},
+		"update PSK of thing owned by other user": {
+			owner: wrongValue,
+			ID:    thing.ID,
+			psk:   psk,
+			err:   things.ErrNotFound,
+		},
+		"update PSK of non-existent thing": {
+			owner: thing.Owner,
+			ID:    nonexistentID,
+			psk:   psk,
+			err:   things.ErrNotFound,
+		},
+		"update PSK with invalid thing ID": {
+			owner: thing.Owner,
+			ID:    "invalid",
+			psk:   psk,
+			err:   things.ErrMalformedEntity,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := thingRepo.UpdatePSK(context.Background(), tc.owner, tc.ID, tc.psk)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	retrieved, err := thingRepo.RetrievePSK(context.Background(), thing.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve PSK: got unexpected error: %s", err))
+	assert.Equal(t, psk, retrieved, fmt.Sprintf("retrieve PSK: expected %s got %s\n", psk, retrieved))
+
+	_, err = thingRepo.RetrievePSK(context.Background(), nonexistentID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("retrieve PSK of non-existent thing: expected %s got %s\n", things.ErrNotFound, err))
+}
+
 func TestMultiThingRetrieval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	thingRepo := postgres.NewThingRepository(dbMiddleware)
@@ -544,7 +663,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 		sths, err := thingRepo.Save(context.Background(), th)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		tid := sths[0].ID
-		err = channelRepo.Connect(context.Background(), email, []string{cid}, []string{tid})
+		err = channelRepo.Connect(context.Background(), email, []string{cid}, []string{tid}, things.PubSubAccess)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+// minPSKLen is the minimum length of the thing DTLS pre-shared key, in bytes.
+// The pre-shared key is provisioned separately from the thing key and kept
+// apart from the thing metadata, so it's never listed nor sent over the
+// event stream.
+const minPSKLen = 16
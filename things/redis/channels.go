@@ -34,37 +34,48 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(_ context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SAdd(cid, tid).Err(); err != nil {
+func (cc channelCache) Connect(_ context.Context, chanID, thingID, access string) error {
+	if err := cc.client.SAdd(connKey(chanID, access), thingID).Err(); err != nil {
 		return errors.Wrap(ErrRedisConnectChannel, err)
 	}
 	return nil
 }
 
-func (cc channelCache) HasThing(_ context.Context, chanID, thingID string) bool {
-	cid, tid := kv(chanID, thingID)
-	return cc.client.SIsMember(cid, tid).Val()
+func (cc channelCache) HasThing(_ context.Context, chanID, thingID, access string) bool {
+	return cc.client.SIsMember(connKey(chanID, access), thingID).Val()
 }
 
 func (cc channelCache) Disconnect(_ context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(cid, tid).Err(); err != nil {
+	_, err := cc.client.Pipelined(func(pipe redis.Pipeliner) error {
+		for _, k := range connKeys(chanID) {
+			pipe.SRem(k, thingID)
+		}
+		return nil
+	})
+	if err != nil {
 		return errors.Wrap(ErrRedisDisconnectChannel, err)
 	}
 	return nil
 }
 
 func (cc channelCache) Remove(_ context.Context, chanID string) error {
-	cid, _ := kv(chanID, "0")
-	if err := cc.client.Del(cid).Err(); err != nil {
+	if err := cc.client.Del(connKeys(chanID)...).Err(); err != nil {
 		return errors.Wrap(ErrRedisRemoveChannel, err)
 	}
 	return nil
 }
 
-// Generates key-value pair
-func kv(chanID, thingID string) (string, string) {
-	cid := fmt.Sprintf("%s:%s", chanPrefix, chanID)
-	return cid, thingID
+// Generates the key of the set containing things connected to the channel
+// with the given access type.
+func connKey(chanID, access string) string {
+	return fmt.Sprintf("%s:%s:%s", chanPrefix, chanID, access)
+}
+
+// Generates the keys of all the channel connection sets.
+func connKeys(chanID string) []string {
+	return []string{
+		connKey(chanID, things.PublishAccess),
+		connKey(chanID, things.SubscribeAccess),
+		connKey(chanID, things.PubSubAccess),
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,23 +21,32 @@ func TestConnect(t *testing.T) {
 	tid := "321"
 
 	cases := []struct {
-		desc string
-		cid  string
-		tid  string
+		desc   string
+		cid    string
+		tid    string
+		access string
 	}{
 		{
-			desc: "connect thing to channel",
-			cid:  cid,
-			tid:  tid,
+			desc:   "connect thing to channel",
+			cid:    cid,
+			tid:    tid,
+			access: things.PublishAccess,
 		},
 		{
-			desc: "connect already connected thing to channel",
-			cid:  cid,
-			tid:  tid,
+			desc:   "connect already connected thing to channel",
+			cid:    cid,
+			tid:    tid,
+			access: things.PublishAccess,
+		},
+		{
+			desc:   "connect thing to channel with different access type",
+			cid:    cid,
+			tid:    tid,
+			access: things.SubscribeAccess,
 		},
 	}
 	for _, tc := range cases {
-		err := channelCache.Connect(context.Background(), cid, tid)
+		err := channelCache.Connect(context.Background(), tc.cid, tc.tid, tc.access)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail to connect due to: %s\n", tc.desc, err))
 	}
 }
@@ -47,33 +57,43 @@ func TestHasThing(t *testing.T) {
 	cid := "123"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.PublishAccess)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := map[string]struct {
 		cid       string
 		tid       string
+		access    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			cid:       cid,
 			tid:       tid,
+			access:    things.PublishAccess,
 			hasAccess: true,
 		},
+		"access check for thing with different access type": {
+			cid:       cid,
+			tid:       tid,
+			access:    things.SubscribeAccess,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			cid:       cid,
 			tid:       cid,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			cid:       tid,
 			tid:       tid,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid, tc.access)
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
 }
@@ -84,25 +104,28 @@ func TestDisconnect(t *testing.T) {
 	tid := "321"
 	tid2 := "322"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.PublishAccess)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
 		desc      string
 		cid       string
 		tid       string
+		access    string
 		hasAccess bool
 	}{
 		{
 			desc:      "disconnecting connected thing",
 			cid:       cid,
 			tid:       tid,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 		{
 			desc:      "disconnecting non-connected thing",
 			cid:       cid,
 			tid:       tid2,
+			access:    things.PublishAccess,
 			hasAccess: false,
 		},
 	}
@@ -110,7 +133,7 @@ func TestDisconnect(t *testing.T) {
 		err := channelCache.Disconnect(context.Background(), tc.cid, tc.tid)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail due to: %s\n", tc.desc, err))
 
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid, tc.access)
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("access check after %s: expected %t got %t\n", tc.desc, tc.hasAccess, hasAccess))
 	}
 }
@@ -122,13 +145,14 @@ func TestRemove(t *testing.T) {
 	cid2 := "124"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.PublishAccess)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
 		desc      string
 		cid       string
 		tid       string
+		access    string
 		err       error
 		hasAccess bool
 	}{
@@ -136,6 +160,7 @@ func TestRemove(t *testing.T) {
 			desc:      "Remove channel from cache",
 			cid:       cid,
 			tid:       tid,
+			access:    things.PublishAccess,
 			err:       nil,
 			hasAccess: false,
 		},
@@ -143,6 +168,7 @@ func TestRemove(t *testing.T) {
 			desc:      "Remove non-cached channel from cache",
 			cid:       cid2,
 			tid:       tid,
+			access:    things.PublishAccess,
 			err:       nil,
 			hasAccess: false,
 		},
@@ -151,7 +177,7 @@ func TestRemove(t *testing.T) {
 	for _, tc := range cases {
 		err := channelCache.Remove(context.Background(), tc.cid)
 		assert.Nil(t, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		hasAcces := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAcces := channelCache.HasThing(context.Background(), tc.cid, tc.tid, tc.access)
 		assert.Equal(t, tc.hasAccess, hasAcces, "%s - check access after removing channel: expected %t got %t\n", tc.desc, tc.hasAccess, hasAcces)
 	}
 }
@@ -172,12 +172,14 @@ func (rce removeChannelEvent) Encode() map[string]interface{} {
 type connectThingEvent struct {
 	chanID  string
 	thingID string
+	access  string
 }
 
 func (cte connectThingEvent) Encode() map[string]interface{} {
 	return map[string]interface{}{
 		"chan_id":   cte.chanID,
 		"thing_id":  cte.thingID,
+		"access":    cte.access,
 		"operation": thingConnect,
 	}
 }
@@ -82,6 +82,12 @@ func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error
 	return es.svc.UpdateKey(ctx, token, id, key)
 }
 
+// UpdatePSK doesn't send event because pre-shared key shouldn't be sent over
+// stream.
+func (es eventStore) UpdatePSK(ctx context.Context, token, id, psk string) error {
+	return es.svc.UpdatePSK(ctx, token, id, psk)
+}
+
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	return es.svc.ViewThing(ctx, token, id)
 }
@@ -186,8 +192,8 @@ func (es eventStore) RemoveChannel(ctx context.Context, token, id string) error
 	return nil
 }
 
-func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	if err := es.svc.Connect(ctx, token, chIDs, thIDs); err != nil {
+func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string, access string) error {
+	if err := es.svc.Connect(ctx, token, chIDs, thIDs, access); err != nil {
 		return err
 	}
 
@@ -196,6 +202,7 @@ func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []s
 			event := connectThingEvent{
 				chanID:  chID,
 				thingID: thID,
+				access:  access,
 			}
 			record := &redis.XAddArgs{
 				Stream:       streamID,
@@ -228,14 +235,30 @@ func (es eventStore) Disconnect(ctx context.Context, token, chanID, thingID stri
 	return nil
 }
 
-func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
-	return es.svc.CanAccessByKey(ctx, chanID, key)
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, access string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, access)
 }
 
-func (es eventStore) CanAccessByID(ctx context.Context, chanID string, thingID string) error {
-	return es.svc.CanAccessByID(ctx, chanID, thingID)
+func (es eventStore) CanAccessByID(ctx context.Context, chanID, thingID, access string) error {
+	return es.svc.CanAccessByID(ctx, chanID, thingID, access)
 }
 
 func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
+
+func (es eventStore) RetrievePSK(ctx context.Context, id string) (string, error) {
+	return es.svc.RetrievePSK(ctx, id)
+}
+
+func (es eventStore) ChannelIDByName(ctx context.Context, thingID, name string) (string, error) {
+	return es.svc.ChannelIDByName(ctx, thingID, name)
+}
+
+func (es eventStore) RetrieveLimits(ctx context.Context, id string) (things.Limits, error) {
+	return es.svc.RetrieveLimits(ctx, id)
+}
+
+func (es eventStore) RetrieveSchema(ctx context.Context, chanID string) (string, error) {
+	return es.svc.RetrieveSchema(ctx, chanID)
+}
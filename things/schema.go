@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/schema"
+)
+
+// SchemaKey is the channel metadata key under which the channel payload
+// schema is set. The schema is either the name of the SenML format the
+// payloads must be encoded with, e.g. {"schema": "senml"}, or the JSON Schema
+// object the JSON payloads must conform to.
+const SchemaKey = "schema"
+
+// Schema returns the JSON encoded payload schema set in the metadata, or an
+// empty string if the metadata doesn't set it.
+func (m Metadata) Schema() (string, error) {
+	v, ok := m[SchemaKey]
+	if !ok {
+		return "", nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", ErrMalformedEntity
+	}
+
+	if _, err := schema.Parse(raw); err != nil {
+		return "", ErrMalformedEntity
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+// Resources and actions of the API key scopes required by the service
+// operations, e.g. things:read or channels:write:<channel_id>.
+const (
+	thingsScope   = "things"
+	channelsScope = "channels"
+	readScope     = "read"
+	writeScope    = "write"
+)
+
+// scope returns the API key scope required by the operation on the resource
+// with the given ID, or on all the resources of the kind if ID is empty.
+func scope(resource, action, id string) string {
+	s := resource + ":" + action
+	if id != "" {
+		s += ":" + id
+	}
+
+	return s
+}
+
+// connectScope returns the API key scope required to connect things to the
+// channels. Connecting to a single channel requires the write scope of that
+// channel only.
+func connectScope(chIDs []string) string {
+	if len(chIDs) == 1 {
+		return scope(channelsScope, writeScope, chIDs[0])
+	}
+
+	return scope(channelsScope, writeScope, "")
+}
@@ -59,6 +59,11 @@ type Service interface {
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, token, id, key string) error
 
+	// UpdatePSK updates DTLS pre-shared key of the existing thing. The empty
+	// key removes the pre-shared key of the thing. A non-nil error is
+	// returned to indicate operation failure.
+	UpdatePSK(ctx context.Context, token, id, psk string) error
+
 	// ViewThing retrieves data about the thing identified with the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
@@ -100,23 +105,47 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveChannel(ctx context.Context, token, id string) error
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(ctx context.Context, token string, chIDs, thIDs []string) error
+	// Connect adds things to the channel's list of connected things. Access
+	// defines whether connected things can publish, subscribe or both.
+	Connect(ctx context.Context, token string, chIDs, thIDs []string, access string) error
 
 	// Disconnect removes thing from the channel's list of connected
 	// things.
 	Disconnect(ctx context.Context, token, chanID, thingID string) error
 
 	// CanAccessByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
+	// provided key with the given access type and returns thing's id if
+	// access is allowed.
+	CanAccessByKey(ctx context.Context, chanID, key, access string) (string, error)
 
 	// CanAccessByID determines whether the channel can be accessed by
-	// the given thing and returns error if it cannot.
-	CanAccessByID(ctx context.Context, chanID, thingID string) error
+	// the given thing with the given access type and returns error if it
+	// cannot.
+	CanAccessByID(ctx context.Context, chanID, thingID, access string) error
 
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
+
+	// RetrievePSK returns DTLS pre-shared key of the thing with the given ID.
+	// It's used by the adapters that authenticate things using pre-shared
+	// keys, and it's the only way the pre-shared key is ever retrieved.
+	RetrievePSK(ctx context.Context, id string) (string, error)
+
+	// ChannelIDByName returns ID of the channel with the given name, owned
+	// by the owner of the thing with the given ID. It's used by the adapters
+	// to resolve channel aliases used in place of channel IDs.
+	ChannelIDByName(ctx context.Context, thingID, name string) (string, error)
+
+	// RetrieveLimits returns publishing limits of the thing with the given
+	// ID, as set in the thing metadata. It's used by the adapters to limit
+	// the messages the thing publishes.
+	RetrieveLimits(ctx context.Context, id string) (Limits, error)
+
+	// RetrieveSchema returns the JSON encoded payload schema of the channel
+	// with the given ID, as set in the channel metadata, or an empty string
+	// if the channel doesn't declare one. It's used by the adapters to reject
+	// the messages which don't conform to the schema.
+	RetrieveSchema(ctx context.Context, chanID string) (string, error)
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -151,7 +180,7 @@ func New(auth mainflux.AuthNServiceClient, things ThingRepository, channels Chan
 }
 
 func (ts *thingsService) CreateThings(ctx context.Context, token string, things ...Thing) ([]Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, writeScope, "")})
 	if err != nil {
 		return []Thing{}, ErrUnauthorizedAccess
 	}
@@ -176,7 +205,7 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 }
 
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, writeScope, thing.ID)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -187,7 +216,7 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 }
 
 func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, writeScope, id)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -198,8 +227,21 @@ func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) e
 
 }
 
+func (ts *thingsService) UpdatePSK(ctx context.Context, token, id, psk string) error {
+	if psk != "" && len(psk) < minPSKLen {
+		return ErrMalformedEntity
+	}
+
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, writeScope, id)})
+	if err != nil {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.things.UpdatePSK(ctx, res.GetValue(), id, psk)
+}
+
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, readScope, id)})
 	if err != nil {
 		return Thing{}, ErrUnauthorizedAccess
 	}
@@ -208,7 +250,7 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 }
 
 func (ts *thingsService) ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata Metadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, readScope, "")})
 	if err != nil {
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -219,7 +261,7 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, offset, l
 }
 
 func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, channel string, offset, limit uint64) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, readScope, "")})
 	if err != nil {
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -228,7 +270,7 @@ func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, channel
 }
 
 func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(thingsScope, writeScope, id)})
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -240,7 +282,7 @@ func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) erro
 }
 
 func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, writeScope, "")})
 	if err != nil {
 		return []Channel{}, ErrUnauthorizedAccess
 	}
@@ -258,7 +300,7 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 }
 
 func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, writeScope, channel.ID)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -268,7 +310,7 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 }
 
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, readScope, id)})
 	if err != nil {
 		return Channel{}, ErrUnauthorizedAccess
 	}
@@ -277,7 +319,7 @@ func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Cha
 }
 
 func (ts *thingsService) ListChannels(ctx context.Context, token string, offset, limit uint64, name string, m Metadata) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, readScope, "")})
 	if err != nil {
 		return ChannelsPage{}, ErrUnauthorizedAccess
 	}
@@ -286,7 +328,7 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, offset,
 }
 
 func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thing string, offset, limit uint64) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, readScope, "")})
 	if err != nil {
 		return ChannelsPage{}, ErrUnauthorizedAccess
 	}
@@ -295,7 +337,7 @@ func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thing s
 }
 
 func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, writeScope, id)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -306,17 +348,21 @@ func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) er
 	return ts.channels.Remove(ctx, res.GetValue(), id)
 }
 
-func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string, access string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: connectScope(chIDs)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
 
-	return ts.channels.Connect(ctx, res.GetValue(), chIDs, thIDs)
+	if !validAccess(access) {
+		return ErrMalformedEntity
+	}
+
+	return ts.channels.Connect(ctx, res.GetValue(), chIDs, thIDs, access)
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token, Scope: scope(channelsScope, writeScope, chanID)})
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -327,32 +373,40 @@ func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID
 	return ts.channels.Disconnect(ctx, res.GetValue(), chanID, thingID)
 }
 
-func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, key string) (string, error) {
-	thingID, err := ts.hasThing(ctx, chanID, key)
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, key, access string) (string, error) {
+	if !validAccess(access) {
+		return "", ErrUnauthorizedAccess
+	}
+
+	thingID, err := ts.hasThing(ctx, chanID, key, access)
 	if err == nil {
 		return thingID, nil
 	}
 
-	thingID, err = ts.channels.HasThing(ctx, chanID, key)
+	thingID, err = ts.channels.HasThing(ctx, chanID, key, access)
 	if err != nil {
 		return "", ErrUnauthorizedAccess
 	}
 
 	ts.thingCache.Save(ctx, key, thingID)
-	ts.channelCache.Connect(ctx, chanID, thingID)
+	ts.channelCache.Connect(ctx, chanID, thingID, access)
 	return thingID, nil
 }
 
-func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID string) error {
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); connected {
+func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID, access string) error {
+	if !validAccess(access) {
+		return ErrUnauthorizedAccess
+	}
+
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, access); connected {
 		return nil
 	}
 
-	if err := ts.channels.HasThingByID(ctx, chanID, thingID); err != nil {
+	if err := ts.channels.HasThingByID(ctx, chanID, thingID, access); err != nil {
 		return ErrUnauthorizedAccess
 	}
 
-	ts.channelCache.Connect(ctx, chanID, thingID)
+	ts.channelCache.Connect(ctx, chanID, thingID, access)
 	return nil
 }
 
@@ -371,15 +425,58 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
-func (ts *thingsService) hasThing(ctx context.Context, chanID, key string) (string, error) {
+func (ts *thingsService) RetrievePSK(ctx context.Context, id string) (string, error) {
+	psk, err := ts.things.RetrievePSK(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if psk == "" {
+		return "", ErrNotFound
+	}
+
+	return psk, nil
+}
+
+func (ts *thingsService) ChannelIDByName(ctx context.Context, thingID, name string) (string, error) {
+	return ts.channels.RetrieveIDByName(ctx, thingID, name)
+}
+
+func (ts *thingsService) RetrieveLimits(ctx context.Context, id string) (Limits, error) {
+	m, err := ts.things.RetrieveMetadataByID(ctx, id)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	return m.Limits()
+}
+
+func (ts *thingsService) RetrieveSchema(ctx context.Context, chanID string) (string, error) {
+	m, err := ts.channels.RetrieveMetadataByID(ctx, chanID)
+	if err != nil {
+		return "", err
+	}
+
+	return m.Schema()
+}
+
+func (ts *thingsService) hasThing(ctx context.Context, chanID, key, access string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, key)
 	if err != nil {
 		return "", err
 	}
 
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); !connected {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, access); !connected {
 		return "", ErrUnauthorizedAccess
 	}
 
 	return thingID, nil
 }
+
+func validAccess(access string) bool {
+	switch access {
+	case PublishAccess, SubscribeAccess, PubSubAccess:
+		return true
+	default:
+		return false
+	}
+}
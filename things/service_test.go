@@ -158,6 +158,63 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestUpdatePSK(t *testing.T) {
+	psk := "9d6f0ba1c3e54e0f8a2b7d16e4c3f952"
+	svc := newService(map[string]string{token: email})
+	sths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	sth := sths[0]
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		psk   string
+		err   error
+	}{
+		{
+			desc:  "update PSK of an existing thing",
+			token: token,
+			id:    sth.ID,
+			psk:   psk,
+			err:   nil,
+		},
+		{
+			desc:  "update PSK with too short key",
+			token: token,
+			id:    sth.ID,
+			psk:   "secret",
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "update PSK with invalid credentials",
+			token: wrongValue,
+			id:    sth.ID,
+			psk:   psk,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "update PSK of non-existing thing",
+			token: token,
+			id:    wrongID,
+			psk:   psk,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "remove PSK of an existing thing",
+			token: token,
+			id:    sth.ID,
+			psk:   "",
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdatePSK(context.Background(), tc.token, tc.id, tc.psk)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	sths, _ := svc.CreateThings(context.Background(), token, thing)
@@ -283,7 +340,7 @@ func TestListThingsByChannel(t *testing.T) {
 		sths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		sth := sths[0]
-		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 	}
 
 	// Wait for things and channels to connect
@@ -624,7 +681,7 @@ func TestListChannelsByThing(t *testing.T) {
 		schs, err := svc.CreateChannels(context.Background(), token, channel)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		sch := schs[0]
-		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+		svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 	}
 
 	// Wait for things and channels to connect.
@@ -750,8 +807,8 @@ func TestRemoveChannel(t *testing.T) {
 func TestConnect(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	sths, _ := svc.CreateThings(context.Background(), token, thing)
-	sth := sths[0]
+	sths, _ := svc.CreateThings(context.Background(), token, thing, thing)
+	sth, sth2 := sths[0], sths[1]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
 
@@ -760,6 +817,7 @@ func TestConnect(t *testing.T) {
 		token   string
 		chanID  string
 		thingID string
+		access  string
 		err     error
 	}{
 		{
@@ -767,13 +825,31 @@ func TestConnect(t *testing.T) {
 			token:   token,
 			chanID:  sch.ID,
 			thingID: sth.ID,
+			access:  things.PubSubAccess,
 			err:     nil,
 		},
+		{
+			desc:    "connect thing with publish access",
+			token:   token,
+			chanID:  sch.ID,
+			thingID: sth2.ID,
+			access:  things.PublishAccess,
+			err:     nil,
+		},
+		{
+			desc:    "connect thing with invalid access type",
+			token:   token,
+			chanID:  sch.ID,
+			thingID: sth.ID,
+			access:  wrongValue,
+			err:     things.ErrMalformedEntity,
+		},
 		{
 			desc:    "connect thing with wrong credentials",
 			token:   wrongValue,
 			chanID:  sch.ID,
 			thingID: sth.ID,
+			access:  things.PubSubAccess,
 			err:     things.ErrUnauthorizedAccess,
 		},
 		{
@@ -781,6 +857,7 @@ func TestConnect(t *testing.T) {
 			token:   token,
 			chanID:  wrongID,
 			thingID: sth.ID,
+			access:  things.PubSubAccess,
 			err:     things.ErrNotFound,
 		},
 		{
@@ -788,12 +865,13 @@ func TestConnect(t *testing.T) {
 			token:   token,
 			chanID:  sch.ID,
 			thingID: wrongID,
+			access:  things.PubSubAccess,
 			err:     things.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID}, tc.access)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -805,7 +883,7 @@ func TestDisconnect(t *testing.T) {
 	sth := sths[0]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.PubSubAccess)
 
 	cases := []struct {
 		desc    string
@@ -861,36 +939,59 @@ func TestDisconnect(t *testing.T) {
 func TestCanAccessByKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	sths, _ := svc.CreateThings(context.Background(), token, thing)
-	sth := sths[0]
+	sths, _ := svc.CreateThings(context.Background(), token, thing, thing)
+	sensor, actuator := sths[0], sths[1]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sensor.ID}, things.PublishAccess)
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{actuator.ID}, things.PubSubAccess)
 
 	cases := map[string]struct {
 		token   string
 		channel string
+		access  string
 		err     error
 	}{
 		"allowed access": {
-			token:   sth.Key,
+			token:   sensor.Key,
+			channel: sch.ID,
+			access:  things.PublishAccess,
+			err:     nil,
+		},
+		"subscribe using publish-only connection": {
+			token:   sensor.Key,
+			channel: sch.ID,
+			access:  things.SubscribeAccess,
+			err:     things.ErrUnauthorizedAccess,
+		},
+		"subscribe using publish and subscribe connection": {
+			token:   actuator.Key,
 			channel: sch.ID,
+			access:  things.SubscribeAccess,
 			err:     nil,
 		},
+		"access with invalid access type": {
+			token:   actuator.Key,
+			channel: sch.ID,
+			access:  wrongValue,
+			err:     things.ErrUnauthorizedAccess,
+		},
 		"not-connected cannot access": {
 			token:   wrongValue,
 			channel: sch.ID,
+			access:  things.PublishAccess,
 			err:     things.ErrUnauthorizedAccess,
 		},
 		"access to non-existing channel": {
-			token:   sth.Key,
+			token:   sensor.Key,
 			channel: wrongID,
+			access:  things.PublishAccess,
 			err:     things.ErrUnauthorizedAccess,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token)
+		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token, tc.access)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -898,36 +999,59 @@ func TestCanAccessByKey(t *testing.T) {
 func TestCanAccessByID(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	sths, _ := svc.CreateThings(context.Background(), token, thing)
-	sth := sths[0]
+	sths, _ := svc.CreateThings(context.Background(), token, thing, thing)
+	sensor, actuator := sths[0], sths[1]
 	schs, _ := svc.CreateChannels(context.Background(), token, channel)
 	sch := schs[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{sensor.ID}, things.PublishAccess)
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{actuator.ID}, things.PubSubAccess)
 
 	cases := map[string]struct {
 		thingID string
 		channel string
+		access  string
 		err     error
 	}{
 		"allowed access": {
-			thingID: sth.ID,
+			thingID: sensor.ID,
+			channel: sch.ID,
+			access:  things.PublishAccess,
+			err:     nil,
+		},
+		"subscribe using publish-only connection": {
+			thingID: sensor.ID,
+			channel: sch.ID,
+			access:  things.SubscribeAccess,
+			err:     things.ErrUnauthorizedAccess,
+		},
+		"subscribe using publish and subscribe connection": {
+			thingID: actuator.ID,
 			channel: sch.ID,
+			access:  things.SubscribeAccess,
 			err:     nil,
 		},
+		"access with invalid access type": {
+			thingID: actuator.ID,
+			channel: sch.ID,
+			access:  wrongValue,
+			err:     things.ErrUnauthorizedAccess,
+		},
 		"not-connected cannot access": {
 			thingID: wrongValue,
 			channel: sch.ID,
+			access:  things.PublishAccess,
 			err:     things.ErrUnauthorizedAccess,
 		},
 		"access to non-existing channel": {
-			thingID: sth.ID,
+			thingID: sensor.ID,
 			channel: wrongID,
+			access:  things.PublishAccess,
 			err:     things.ErrUnauthorizedAccess,
 		},
 	}
 
 	for desc, tc := range cases {
-		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID)
+		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID, tc.access)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -961,3 +1085,186 @@ func TestIdentify(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
+
+func TestRetrievePSK(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	psk := "9d6f0ba1c3e54e0f8a2b7d16e4c3f952"
+	sths, err := svc.CreateThings(context.Background(), token, thing, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdatePSK(context.Background(), token, sths[1].ID, psk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdatePSK(context.Background(), token, sths[2].ID, psk)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdatePSK(context.Background(), token, sths[2].ID, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id  string
+		psk string
+		err error
+	}{
+		"retrieve PSK of thing without PSK": {
+			id:  sths[0].ID,
+			psk: "",
+			err: things.ErrNotFound,
+		},
+		"retrieve PSK of provisioned thing": {
+			id:  sths[1].ID,
+			psk: psk,
+			err: nil,
+		},
+		"retrieve removed PSK": {
+			id:  sths[2].ID,
+			psk: "",
+			err: things.ErrNotFound,
+		},
+		"retrieve PSK of non-existing thing": {
+			id:  wrongID,
+			psk: "",
+			err: things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		psk, err := svc.RetrievePSK(context.Background(), tc.id)
+		assert.Equal(t, tc.psk, psk, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.psk, psk))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestChannelIDByName(t *testing.T) {
+	svc := newService(map[string]string{token: email, "other": "other@example.com"})
+
+	sths, _ := svc.CreateThings(context.Background(), token, thing)
+	sth := sths[0]
+	schs, _ := svc.CreateChannels(context.Background(), token, things.Channel{Name: "unique"}, things.Channel{Name: "duplicate"}, things.Channel{Name: "duplicate"})
+	sch := schs[0]
+	svc.CreateChannels(context.Background(), "other", things.Channel{Name: "foreign"})
+
+	cases := map[string]struct {
+		thingID string
+		name    string
+		id      string
+		err     error
+	}{
+		"retrieve channel ID by unique name": {
+			thingID: sth.ID,
+			name:    "unique",
+			id:      sch.ID,
+			err:     nil,
+		},
+		"retrieve channel ID by duplicate name": {
+			thingID: sth.ID,
+			name:    "duplicate",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+		"retrieve channel ID by name of other owner's channel": {
+			thingID: sth.ID,
+			name:    "foreign",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+		"retrieve channel ID with non-existing thing": {
+			thingID: wrongValue,
+			name:    "unique",
+			id:      "",
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := svc.ChannelIDByName(context.Background(), tc.thingID, tc.name)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestRetrieveLimits(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	limited := thing
+	limited.Metadata = things.Metadata{
+		things.LimitsKey: map[string]interface{}{"rate": float64(60), "burst": float64(10), "quota": float64(1000)},
+	}
+	malformed := thing
+	malformed.Metadata = things.Metadata{things.LimitsKey: "unlimited"}
+	sths, _ := svc.CreateThings(context.Background(), token, thing, limited, malformed)
+
+	cases := map[string]struct {
+		id     string
+		limits things.Limits
+		err    error
+	}{
+		"retrieve limits of thing without limits": {
+			id:     sths[0].ID,
+			limits: things.Limits{},
+			err:    nil,
+		},
+		"retrieve limits of limited thing": {
+			id:     sths[1].ID,
+			limits: things.Limits{Rate: 60, Burst: 10, Quota: 1000},
+			err:    nil,
+		},
+		"retrieve malformed limits": {
+			id:     sths[2].ID,
+			limits: things.Limits{},
+			err:    things.ErrMalformedEntity,
+		},
+		"retrieve limits of non-existing thing": {
+			id:     wrongID,
+			limits: things.Limits{},
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		limits, err := svc.RetrieveLimits(context.Background(), tc.id)
+		assert.Equal(t, tc.limits, limits, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.limits, limits))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestRetrieveSchema(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	senml := channel
+	senml.Metadata = things.Metadata{things.SchemaKey: "senml"}
+	malformed := channel
+	malformed.Metadata = things.Metadata{things.SchemaKey: "xml"}
+	schs, _ := svc.CreateChannels(context.Background(), token, channel, senml, malformed)
+
+	cases := map[string]struct {
+		id     string
+		schema string
+		err    error
+	}{
+		"retrieve schema of channel without schema": {
+			id:     schs[0].ID,
+			schema: "",
+			err:    nil,
+		},
+		"retrieve schema of channel with schema": {
+			id:     schs[1].ID,
+			schema: `"senml"`,
+			err:    nil,
+		},
+		"retrieve malformed schema": {
+			id:     schs[2].ID,
+			schema: "",
+			err:    things.ErrMalformedEntity,
+		},
+		"retrieve schema of non-existing channel": {
+			id:     wrongID,
+			schema: "",
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		schema, err := svc.RetrieveSchema(context.Background(), tc.id)
+		assert.Equal(t, tc.schema, schema, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.schema, schema))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
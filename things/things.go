@@ -43,6 +43,10 @@ type ThingRepository interface {
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, owner, id, key string) error
 
+	// UpdatePSK updates DTLS pre-shared key of the existing thing. A non-nil
+	// error is returned to indicate operation failure.
+	UpdatePSK(ctx context.Context, owner, id, psk string) error
+
 	// RetrieveByID retrieves the thing having the provided identifier, that is owned
 	// by the specified user.
 	RetrieveByID(ctx context.Context, owner, id string) (Thing, error)
@@ -50,6 +54,13 @@ type ThingRepository interface {
 	// RetrieveByKey returns thing ID for given thing key.
 	RetrieveByKey(ctx context.Context, key string) (string, error)
 
+	// RetrieveMetadataByID returns thing metadata for given thing ID.
+	RetrieveMetadataByID(ctx context.Context, id string) (Metadata, error)
+
+	// RetrievePSK returns thing DTLS pre-shared key for given thing ID, or
+	// an empty string if the thing has no pre-shared key.
+	RetrievePSK(ctx context.Context, id string) (string, error)
+
 	// RetrieveAll retrieves the subset of things owned by the specified user.
 	RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, m Metadata) (Page, error)
 
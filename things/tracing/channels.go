@@ -15,6 +15,8 @@ const (
 	saveChannelsOp            = "save_channels"
 	updateChannelOp           = "update_channel"
 	retrieveChannelByIDOp     = "retrieve_channel_by_id"
+	retrieveChannelIDByNameOp = "retrieve_channel_id_by_name"
+	retrieveChannelMetadataOp = "retrieve_channel_metadata_by_id"
 	retrieveAllChannelsOp     = "retrieve_all_channels"
 	retrieveChannelsByThingOp = "retrieve_channels_by_thing"
 	removeChannelOp           = "retrieve_channel"
@@ -67,6 +69,22 @@ func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, owner,
 	return crm.repo.RetrieveByID(ctx, owner, id)
 }
 
+func (crm channelRepositoryMiddleware) RetrieveIDByName(ctx context.Context, thingID, name string) (string, error) {
+	span := createSpan(ctx, crm.tracer, retrieveChannelIDByNameOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveIDByName(ctx, thingID, name)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveMetadataByID(ctx context.Context, id string) (things.Metadata, error) {
+	span := createSpan(ctx, crm.tracer, retrieveChannelMetadataOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveMetadataByID(ctx, id)
+}
+
 func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
@@ -91,12 +109,12 @@ func (crm channelRepositoryMiddleware) Remove(ctx context.Context, owner, id str
 	return crm.repo.Remove(ctx, owner, id)
 }
 
-func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string, access string) error {
 	span := createSpan(ctx, crm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Connect(ctx, owner, chIDs, thIDs)
+	return crm.repo.Connect(ctx, owner, chIDs, thIDs, access)
 }
 
 func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, chanID, thingID string) error {
@@ -107,20 +125,20 @@ func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, ch
 	return crm.repo.Disconnect(ctx, owner, chanID, thingID)
 }
 
-func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key string) (string, error) {
+func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key, access string) (string, error) {
 	span := createSpan(ctx, crm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThing(ctx, chanID, key)
+	return crm.repo.HasThing(ctx, chanID, key, access)
 }
 
-func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID string) error {
+func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID, access string) error {
 	span := createSpan(ctx, crm.tracer, hasThingByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThingByID(ctx, chanID, thingID)
+	return crm.repo.HasThingByID(ctx, chanID, thingID, access)
 }
 
 type channelCacheMiddleware struct {
@@ -137,20 +155,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID, access string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, access)
 }
 
-func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
+func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID, access string) bool {
 	span := createSpan(ctx, ccm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.HasThing(ctx, chanID, thingID)
+	return ccm.cache.HasThing(ctx, chanID, thingID, access)
 }
 
 func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingID string) error {
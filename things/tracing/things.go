@@ -15,8 +15,11 @@ const (
 	saveThingsOp              = "save_things"
 	updateThingOp             = "update_thing"
 	updateThingKeyOp          = "update_thing_by_key"
+	updateThingPSKOp          = "update_thing_psk"
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
+	retrieveThingMetadataOp   = "retrieve_thing_metadata_by_id"
+	retrieveThingPSKOp        = "retrieve_thing_psk_by_id"
 	retrieveAllThingsOp       = "retrieve_all_things"
 	retrieveThingsByChannelOp = "retrieve_things_by_chan"
 	removeThingOp             = "remove_thing"
@@ -66,6 +69,14 @@ func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, k
 	return trm.repo.UpdateKey(ctx, owner, id, key)
 }
 
+func (trm thingRepositoryMiddleware) UpdatePSK(ctx context.Context, owner, id, psk string) error {
+	span := createSpan(ctx, trm.tracer, updateThingPSKOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UpdatePSK(ctx, owner, id, psk)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByIDOp)
 	defer span.Finish()
@@ -82,6 +93,22 @@ func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key stri
 	return trm.repo.RetrieveByKey(ctx, key)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveMetadataByID(ctx context.Context, id string) (things.Metadata, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingMetadataOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveMetadataByID(ctx, id)
+}
+
+func (trm thingRepositoryMiddleware) RetrievePSK(ctx context.Context, id string) (string, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingPSKOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrievePSK(ctx, id)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
@@ -131,17 +131,96 @@ func passwordChangeEndpoint(svc users.Service) endpoint.Endpoint {
 
 func loginEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(userReq)
+		req := request.(loginReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		token, refresh, err := svc.Login(ctx, req.user, req.code)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: token, Refresh: refresh}, nil
+	}
+}
+
+func oidcLoginEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		u, state, err := svc.OIDCAuthURL(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return redirectRes{url: u, state: state}, nil
+	}
+}
+
+func oidcCallbackEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oidcCallbackReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		token, refresh, err := svc.OIDCLogin(ctx, req.code, req.state, req.totp)
+		if err != nil {
+			return nil, err
+		}
+
+		return oidcTokenRes{Token: token, Refresh: refresh}, nil
+	}
+}
 
+func enrollTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollTOTPReq)
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
 
-		token, err := svc.Login(ctx, req.user)
+		e, err := svc.EnrollTOTP(ctx, req.token)
 		if err != nil {
 			return nil, err
 		}
 
-		return tokenRes{token}, nil
+		res := enrollTOTPRes{
+			Secret:        e.Secret,
+			URI:           e.URI,
+			RecoveryCodes: e.RecoveryCodes,
+		}
+		return res, nil
+	}
+}
+
+func enableTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.EnableTOTP(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return totpRes{}, nil
+	}
+}
+
+func disableTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DisableTOTP(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return totpRes{}, nil
 	}
 }
@@ -27,6 +27,7 @@ import (
 const (
 	contentType  = "application/json"
 	invalidEmail = "userexample.com"
+	stateCookie  = "mf_oidc_state"
 	wrongID      = "123e4567-e89b-12d3-a456-000000000042"
 	id           = "123e4567-e89b-12d3-a456-000000000001"
 )
@@ -66,12 +67,16 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService() users.Service {
+	return newOIDCService(nil)
+}
+
+func newOIDCService(idp users.IdentityProvider) users.Service {
 	repo := mocks.NewUserRepository()
 	hasher := mocks.NewHasher()
 	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
 	email := mocks.NewEmailer()
 
-	return users.New(repo, hasher, auth, email)
+	return users.New(repo, hasher, mocks.NewTOTP(), auth, email, idp)
 }
 
 func newServer(svc users.Service) *httptest.Server {
@@ -427,6 +432,321 @@ func TestPasswordChange(t *testing.T) {
 	}
 }
 
+func TestOIDCLogin(t *testing.T) {
+	svc := newOIDCService(mocks.NewIdentityProvider(nil))
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	res, err := client.Get(fmt.Sprintf("%s/oidc/login", ts.URL))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusFound, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusFound, res.StatusCode))
+
+	location := res.Header.Get("Location")
+	assert.True(t, strings.HasPrefix(location, mocks.AuthURL), fmt.Sprintf("expected redirect to %s got %s", mocks.AuthURL, location))
+
+	var cookie *http.Cookie
+	for _, c := range res.Cookies() {
+		if c.Name == stateCookie {
+			cookie = c
+		}
+	}
+	assert.NotNil(t, cookie, "expected state cookie")
+	assert.True(t, cookie.HttpOnly, "expected HTTP only state cookie")
+	assert.Contains(t, location, "state="+cookie.Value, fmt.Sprintf("expected state %s in %s", cookie.Value, location))
+
+	disabled := newServer(newService())
+	defer disabled.Close()
+	res, err = client.Get(fmt.Sprintf("%s/oidc/login", disabled.URL))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusNotFound, res.StatusCode))
+}
+
+func TestOIDCCallback(t *testing.T) {
+	idp := mocks.NewIdentityProvider(map[string]users.Identity{
+		"valid": {Subject: "subject", Email: user.Email, Groups: []string{"admins"}},
+	})
+	svc := newOIDCService(idp)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	_, state, err := svc.OIDCAuthURL(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	tokenData := toJSON(map[string]string{"token": user.Email})
+
+	cases := []struct {
+		desc   string
+		code   string
+		state  string
+		cookie string
+		status int
+		res    string
+	}{
+		{"callback with valid code and state", "valid", state, state, http.StatusOK, tokenData},
+		{"callback with state not matching cookie", "valid", state, "other", http.StatusForbidden, unauthRes},
+		{"callback without cookie", "valid", state, "", http.StatusForbidden, unauthRes},
+		{"callback with invalid code", "invalid", state, state, http.StatusForbidden, unauthRes},
+		{"callback without code", "", state, state, http.StatusBadRequest, malformedRes},
+		{"callback without state", "valid", "", state, http.StatusBadRequest, malformedRes},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/oidc/callback?code=%s&state=%s", ts.URL, tc.code, tc.state), nil)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: stateCookie, Value: tc.cookie})
+		}
+		res, err := client.Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		token := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+	}
+}
+
+func TestOIDCCallbackTOTP(t *testing.T) {
+	idp := mocks.NewIdentityProvider(map[string]users.Identity{
+		"valid": {Subject: "subject", Email: user.Email},
+	})
+	svc := newOIDCService(idp)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	_, state, err := svc.OIDCAuthURL(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	token, _, err := svc.OIDCLogin(context.Background(), "valid", state, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = svc.EnrollTOTP(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	tokenData := toJSON(map[string]string{"token": user.Email})
+	totpRequiredRes := toJSON(errorRes{users.ErrTOTPRequired.Error()})
+
+	cases := []struct {
+		desc   string
+		totp   string
+		status int
+		res    string
+	}{
+		{"callback without TOTP code", "", http.StatusUnauthorized, totpRequiredRes},
+		{"callback with invalid TOTP code", "invalid", http.StatusForbidden, unauthRes},
+		{"callback with valid TOTP code", "123457", http.StatusOK, tokenData},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/oidc/callback?code=valid&state=%s&totp=%s", ts.URL, state, tc.totp), nil)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		req.AddCookie(&http.Cookie{Name: stateCookie, Value: state})
+		res, err := client.Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"enroll TOTP", token, http.StatusCreated},
+		{"enroll TOTP with invalid token", "invalid", http.StatusForbidden},
+		{"enroll TOTP with empty token", "", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/totp", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusCreated {
+			continue
+		}
+		var body struct {
+			Secret        string   `json:"secret"`
+			URI           string   `json:"uri"`
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, body.Secret, fmt.Sprintf("%s: expected non-empty secret", tc.desc))
+		assert.Contains(t, body.URI, body.Secret, fmt.Sprintf("%s: expected URI to contain the secret", tc.desc))
+		assert.Len(t, body.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(body.RecoveryCodes)))
+	}
+}
+
+func TestEnableTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = svc.EnrollTOTP(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	data := toJSON(map[string]string{"code": mocks.TOTPCode})
+	invalidData := toJSON(map[string]string{"code": "invalid"})
+
+	cases := []struct {
+		desc        string
+		token       string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"enable TOTP with invalid code", token, invalidData, contentType, http.StatusForbidden},
+		{"enable TOTP with empty code", token, "{}", contentType, http.StatusBadRequest},
+		{"enable TOTP with invalid request format", token, "{", contentType, http.StatusBadRequest},
+		{"enable TOTP with missing content type", token, data, "", http.StatusUnsupportedMediaType},
+		{"enable TOTP with invalid token", "invalid", data, contentType, http.StatusForbidden},
+		{"enable TOTP", token, data, contentType, http.StatusNoContent},
+		{"enable enabled TOTP", token, data, contentType, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/users/totp", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	data := toJSON(map[string]string{"code": "123457"})
+	invalidData := toJSON(map[string]string{"code": "invalid"})
+
+	disable := func(token, body string) (*http.Response, error) {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("%s/users/totp", ts.URL),
+			contentType: contentType,
+			token:       token,
+			body:        strings.NewReader(body),
+		}
+		return req.make()
+	}
+
+	res, err := disable(token, data)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, fmt.Sprintf("disable TOTP before enrollment: expected status code %d got %d", http.StatusNotFound, res.StatusCode))
+
+	_, err = svc.EnrollTOTP(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		req    string
+		status int
+	}{
+		{"disable TOTP with invalid code", token, invalidData, http.StatusForbidden},
+		{"disable TOTP with invalid token", "invalid", data, http.StatusForbidden},
+		{"disable TOTP", token, data, http.StatusNoContent},
+		{"disable disabled TOTP", token, data, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		res, err := disable(tc.token, tc.req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = svc.EnrollTOTP(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	tokenData := toJSON(map[string]string{"token": token})
+	totpRequiredRes := toJSON(errorRes{users.ErrTOTPRequired.Error()})
+
+	cases := []struct {
+		desc   string
+		req    string
+		status int
+		res    string
+	}{
+		{"login without TOTP code", toJSON(user), http.StatusUnauthorized, totpRequiredRes},
+		{"login with invalid TOTP code", toJSON(map[string]string{"email": user.Email, "password": user.Password, "totp": "invalid"}), http.StatusForbidden, unauthRes},
+		{"login with valid TOTP code", toJSON(map[string]string{"email": user.Email, "password": user.Password, "totp": "123457"}), http.StatusCreated, tokenData},
+		{"login with used TOTP code", toJSON(map[string]string{"email": user.Email, "password": user.Password, "totp": "123457"}), http.StatusForbidden, unauthRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			contentType: contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
@@ -38,7 +38,7 @@ func (lm *loggingMiddleware) Register(ctx context.Context, user users.User) (err
 	return lm.svc.Register(ctx, user)
 }
 
-func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token string, err error) {
+func (lm *loggingMiddleware) Login(ctx context.Context, user users.User, code string) (token, refresh string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method login for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
@@ -48,7 +48,7 @@ func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Login(ctx, user)
+	return lm.svc.Login(ctx, user, code)
 }
 
 func (lm *loggingMiddleware) ViewUser(ctx context.Context, token string) (u users.User, err error) {
@@ -128,3 +128,68 @@ func (lm *loggingMiddleware) SendPasswordReset(ctx context.Context, host, email,
 
 	return lm.svc.SendPasswordReset(ctx, host, email, token)
 }
+
+func (lm *loggingMiddleware) OIDCAuthURL(ctx context.Context) (u, state string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_auth_url took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCAuthURL(ctx)
+}
+
+func (lm *loggingMiddleware) OIDCLogin(ctx context.Context, code, state, totp string) (token, refresh string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_login took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCLogin(ctx, code, state, totp)
+}
+
+func (lm *loggingMiddleware) EnrollTOTP(ctx context.Context, token string) (e users.TOTPEnrollment, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enroll_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnrollTOTP(ctx, token)
+}
+
+func (lm *loggingMiddleware) EnableTOTP(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enable_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnableTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) DisableTOTP(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disable_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisableTOTP(ctx, token, code)
+}
@@ -38,13 +38,13 @@ func (ms *metricsMiddleware) Register(ctx context.Context, user users.User) erro
 	return ms.svc.Register(ctx, user)
 }
 
-func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string, error) {
+func (ms *metricsMiddleware) Login(ctx context.Context, user users.User, code string) (string, string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "login").Add(1)
 		ms.latency.With("method", "login").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Login(ctx, user)
+	return ms.svc.Login(ctx, user, code)
 }
 
 func (ms *metricsMiddleware) ViewUser(ctx context.Context, token string) (users.User, error) {
@@ -100,3 +100,48 @@ func (ms *metricsMiddleware) SendPasswordReset(ctx context.Context, host, email,
 
 	return ms.svc.SendPasswordReset(ctx, host, email, token)
 }
+
+func (ms *metricsMiddleware) OIDCAuthURL(ctx context.Context) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_auth_url").Add(1)
+		ms.latency.With("method", "oidc_auth_url").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCAuthURL(ctx)
+}
+
+func (ms *metricsMiddleware) OIDCLogin(ctx context.Context, code, state, totp string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_login").Add(1)
+		ms.latency.With("method", "oidc_login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCLogin(ctx, code, state, totp)
+}
+
+func (ms *metricsMiddleware) EnrollTOTP(ctx context.Context, token string) (users.TOTPEnrollment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_totp").Add(1)
+		ms.latency.With("method", "enroll_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnrollTOTP(ctx, token)
+}
+
+func (ms *metricsMiddleware) EnableTOTP(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enable_totp").Add(1)
+		ms.latency.With("method", "enable_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnableTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) DisableTOTP(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_totp").Add(1)
+		ms.latency.With("method", "disable_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisableTOTP(ctx, token, code)
+}
@@ -21,6 +21,17 @@ func (req userReq) validate() error {
 	return req.user.Validate()
 }
 
+// loginReq contains the credentials along with the TOTP or recovery code,
+// which is required only if the user enabled the two-factor authentication.
+type loginReq struct {
+	user users.User
+	code string
+}
+
+func (req loginReq) validate() error {
+	return req.user.Validate()
+}
+
 type viewUserReq struct {
 	token string
 }
@@ -93,3 +104,50 @@ func (req passwChangeReq) validate() error {
 	}
 	return nil
 }
+
+type oidcLoginReq struct{}
+
+type oidcCallbackReq struct {
+	code        string
+	state       string
+	totp        string
+	cookieState string
+}
+
+// State must match the one set in the cookie when the login started, so the
+// login can't be completed in the browser of the other user.
+func (req oidcCallbackReq) validate() error {
+	if req.code == "" || req.state == "" {
+		return users.ErrMalformedEntity
+	}
+	if req.state != req.cookieState {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type enrollTOTPReq struct {
+	token string
+}
+
+func (req enrollTOTPReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type totpReq struct {
+	token string
+	Code  string `json:"code"`
+}
+
+func (req totpReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.Code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
@@ -13,13 +13,18 @@ var (
 	_ mainflux.Response = (*tokenRes)(nil)
 	_ mainflux.Response = (*viewUserRes)(nil)
 	_ mainflux.Response = (*passwChangeRes)(nil)
+	_ mainflux.Response = (*redirectRes)(nil)
+	_ mainflux.Response = (*oidcTokenRes)(nil)
+	_ mainflux.Response = (*enrollTOTPRes)(nil)
+	_ mainflux.Response = (*totpRes)(nil)
 )
 
 // MailSent message response when link is sent
 const MailSent = "Email with reset link is sent"
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token   string `json:"token,omitempty"`
+	Refresh string `json:"refresh_token,omitempty"`
 }
 
 func (res tokenRes) Code() int {
@@ -85,3 +90,87 @@ func (res passwChangeRes) Headers() map[string]string {
 func (res passwChangeRes) Empty() bool {
 	return false
 }
+
+type redirectRes struct {
+	url   string
+	state string
+}
+
+func (res redirectRes) Code() int {
+	return http.StatusFound
+}
+
+func (res redirectRes) Headers() map[string]string {
+	return map[string]string{
+		"Location":   res.url,
+		"Set-Cookie": stateCookie(res.state, stateMaxAge),
+	}
+}
+
+func (res redirectRes) Empty() bool {
+	return true
+}
+
+type oidcTokenRes struct {
+	Token   string `json:"token,omitempty"`
+	Refresh string `json:"refresh_token,omitempty"`
+}
+
+func (res oidcTokenRes) Code() int {
+	return http.StatusOK
+}
+
+// State cookie is removed once the login is completed.
+func (res oidcTokenRes) Headers() map[string]string {
+	return map[string]string{
+		"Set-Cookie": stateCookie("", -1),
+	}
+}
+
+func (res oidcTokenRes) Empty() bool {
+	return res.Token == ""
+}
+
+type enrollTOTPRes struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res enrollTOTPRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollTOTPRes) Empty() bool {
+	return false
+}
+
+type totpRes struct{}
+
+func (res totpRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res totpRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res totpRes) Empty() bool {
+	return true
+}
+
+func stateCookie(state string, maxAge int) string {
+	c := http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     oidcPath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return c.String()
+}
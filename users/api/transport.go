@@ -22,7 +22,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-const contentType = "application/json"
+const (
+	contentType     = "application/json"
+	oidcPath        = "/oidc"
+	stateCookieName = "mf_oidc_state"
+	// stateMaxAge is the time in seconds the user has to log in with the
+	// identity provider.
+	stateMaxAge = 600
+)
 
 var (
 	// ErrUnsupportedContentType indicates unacceptable or lack of Content-Type
@@ -89,7 +96,42 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, l log.Logger) htt
 
 	mux.Post("/tokens", kithttp.NewServer(
 		kitot.TraceServer(tracer, "login")(loginEndpoint(svc)),
-		decodeCredentials,
+		decodeLogin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enroll_totp")(enrollTOTPEndpoint(svc)),
+		decodeEnrollTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enable_totp")(enableTOTPEndpoint(svc)),
+		decodeTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_totp")(disableTOTPEndpoint(svc)),
+		decodeTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get(oidcPath+"/login", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_login")(oidcLoginEndpoint(svc)),
+		decodeOIDCLogin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get(oidcPath+"/callback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_callback")(oidcCallbackEndpoint(svc)),
+		decodeOIDCCallback,
 		encodeResponse,
 		opts...,
 	))
@@ -130,6 +172,43 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	return userReq{user}, nil
 }
 
+func decodeLogin(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req struct {
+		users.User
+		Code string `json:"totp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return loginReq{user: req.User, code: req.Code}, nil
+}
+
+func decodeEnrollTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	req := enrollTOTPReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func decodeTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req totpReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(ErrFailedDecode, err)
+	}
+
+	req.token = r.Header.Get("Authorization")
+	return req, nil
+}
+
 func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, ErrUnsupportedContentType
@@ -186,6 +265,24 @@ func decodeToken(_ context.Context, r *http.Request) (interface{}, error) {
 	return t, nil
 
 }
+
+func decodeOIDCLogin(_ context.Context, r *http.Request) (interface{}, error) {
+	return oidcLoginReq{}, nil
+}
+
+func decodeOIDCCallback(_ context.Context, r *http.Request) (interface{}, error) {
+	req := oidcCallbackReq{
+		code:  r.URL.Query().Get("code"),
+		state: r.URL.Query().Get("state"),
+		totp:  r.URL.Query().Get("totp"),
+	}
+	if c, err := r.Cookie(stateCookieName); err == nil {
+		req.cookieState = c.Value
+	}
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	if ar, ok := response.(mainflux.Response); ok {
 		for k, v := range ar.Headers() {
@@ -209,9 +306,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		switch {
 		case errors.Contains(errorVal, users.ErrMalformedEntity):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, users.ErrTOTPRequired):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, users.ErrTOTPLocked):
+			w.WriteHeader(http.StatusTooManyRequests)
 		case errors.Contains(errorVal, users.ErrUnauthorizedAccess):
 			w.WriteHeader(http.StatusForbidden)
-		case errors.Contains(errorVal, users.ErrConflict):
+		case errors.Contains(errorVal, users.ErrConflict),
+			errors.Contains(errorVal, users.ErrTOTPEnabled):
 			w.WriteHeader(http.StatusConflict)
 		case errors.Contains(errorVal, ErrUnsupportedContentType):
 			w.WriteHeader(http.StatusUnsupportedMediaType)
@@ -225,6 +327,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, users.ErrRecoveryToken):
 			w.WriteHeader(http.StatusInternalServerError)
+		case errors.Contains(errorVal, users.ErrOIDCDisabled),
+			errors.Contains(errorVal, users.ErrTOTPNotEnrolled):
+			w.WriteHeader(http.StatusNotFound)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 		}
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+// AuthURL is the authorization endpoint URL of the mock identity provider.
+const AuthURL = "http://idp.example.com/auth"
+
+var _ users.IdentityProvider = (*identityProviderMock)(nil)
+
+type identityProviderMock struct {
+	mu         sync.Mutex
+	identities map[string]users.Identity
+	states     map[string]bool
+}
+
+// NewIdentityProvider creates mock of the identity provider which asserts the
+// identities registered for the authorization codes. Identity is asserted
+// only for the state returned in the authorization URL.
+func NewIdentityProvider(identities map[string]users.Identity) users.IdentityProvider {
+	return &identityProviderMock{
+		identities: identities,
+		states:     map[string]bool{},
+	}
+}
+
+func (idp *identityProviderMock) AuthURL(state string) string {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	idp.states[state] = true
+	return AuthURL + "?" + url.Values{"state": []string{state}}.Encode()
+}
+
+func (idp *identityProviderMock) Exchange(_ context.Context, code, nonce string) (users.Identity, error) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	id, ok := idp.identities[code]
+	if !ok || !idp.states[nonce] {
+		return users.Identity{}, users.ErrUnauthorizedAccess
+	}
+
+	return id, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"strconv"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+// TOTPCode is the first code the mock TOTP accepts for every secret. Since
+// the used codes are rejected, the mock treats each code as the time step of
+// its own, so TOTPCode is generated for the time step 1, "123457" for the time
+// step 2 and so on.
+const TOTPCode = "123456"
+
+const (
+	firstCode = 123456
+	maxSteps  = 1000
+)
+
+var _ users.TOTP = (*totpMock)(nil)
+
+type totpMock struct{}
+
+// NewTOTP creates TOTP mock which generates the constant secret and accepts
+// TOTPCode and the codes following it.
+func NewTOTP() users.TOTP {
+	return &totpMock{}
+}
+
+func (tm *totpMock) Secret() (string, error) {
+	return "JBSWY3DPEHPK3PXP", nil
+}
+
+func (tm *totpMock) URI(secret, account string) string {
+	return "otpauth://totp/Mainflux:" + account + "?secret=" + secret
+}
+
+func (tm *totpMock) Validate(secret, code string) (uint64, error) {
+	c, err := strconv.Atoi(code)
+	if secret == "" || err != nil || len(code) != len(TOTPCode) || c < firstCode || c >= firstCode+maxSteps {
+		return 0, users.ErrUnauthorizedAccess
+	}
+
+	return uint64(c-firstCode) + 1, nil
+}
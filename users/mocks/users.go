@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/users"
 )
@@ -13,14 +14,18 @@ import (
 var _ users.UserRepository = (*userRepositoryMock)(nil)
 
 type userRepositoryMock struct {
-	mu    sync.Mutex
-	users map[string]users.User
+	mu         sync.Mutex
+	users      map[string]users.User
+	twoFactors map[string]users.TwoFactor
+	attempts   map[string][]time.Time
 }
 
 // NewUserRepository creates in-memory user repository
 func NewUserRepository() users.UserRepository {
 	return &userRepositoryMock{
-		users: make(map[string]users.User),
+		users:      make(map[string]users.User),
+		twoFactors: make(map[string]users.TwoFactor),
+		attempts:   make(map[string][]time.Time),
 	}
 }
 
@@ -81,3 +86,74 @@ func (urm *userRepositoryMock) UpdatePassword(_ context.Context, token, password
 	}
 	return nil
 }
+
+func (urm *userRepositoryMock) RetrieveTwoFactor(_ context.Context, email string) (users.TwoFactor, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	if _, ok := urm.users[email]; !ok {
+		return users.TwoFactor{}, users.ErrNotFound
+	}
+
+	return urm.twoFactors[email], nil
+}
+
+func (urm *userRepositoryMock) UpdateTwoFactor(_ context.Context, email string, tf users.TwoFactor) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	if _, ok := urm.users[email]; !ok {
+		return users.ErrUserNotFound
+	}
+
+	urm.twoFactors[email] = tf
+	delete(urm.attempts, email)
+	return nil
+}
+
+func (urm *userRepositoryMock) SaveTOTPAttempt(_ context.Context, email string, at time.Time, max uint32, lockout time.Duration) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	attempts := urm.attempts[email]
+	if n := len(attempts); n >= int(max) && at.Before(attempts[n-1].Add(lockout)) {
+		return users.ErrTOTPLocked
+	}
+
+	urm.attempts[email] = append(attempts, at)
+	return nil
+}
+
+func (urm *userRepositoryMock) UseTOTPCounter(_ context.Context, email string, counter uint64) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	tf := urm.twoFactors[email]
+	if counter <= tf.Counter {
+		return users.ErrConflict
+	}
+
+	tf.Counter = counter
+	urm.twoFactors[email] = tf
+	delete(urm.attempts, email)
+	return nil
+}
+
+func (urm *userRepositoryMock) UseRecoveryCode(_ context.Context, email, hash string) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	tf := urm.twoFactors[email]
+	for i, h := range tf.RecoveryCodes {
+		if h != hash {
+			continue
+		}
+		codes := append([]string{}, tf.RecoveryCodes[:i]...)
+		tf.RecoveryCodes = append(codes, tf.RecoveryCodes[i+1:]...)
+		urm.twoFactors[email] = tf
+		delete(urm.attempts, email)
+		return nil
+	}
+
+	return users.ErrConflict
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "context"
+
+// GroupsKey is the user metadata key under which the groups the user is
+// member of at the identity provider are set.
+const GroupsKey = "groups"
+
+// Identity represents the user identity asserted by the identity provider.
+type Identity struct {
+	Subject string
+	Email   string
+	Groups  []string
+
+	// MFA indicates that the identity provider, trusted to do so, verified
+	// the second factor of the user, so the TOTP isn't required.
+	MFA bool
+}
+
+// IdentityProvider specifies the API of the external OpenID Connect identity
+// provider users can log in with.
+type IdentityProvider interface {
+	// AuthURL returns the URL of the identity provider the user is
+	// redirected to for authentication. State is passed back along with the
+	// authorization code, and it's used as the ID token nonce as well.
+	AuthURL(state string) string
+
+	// Exchange exchanges the authorization code for the ID token, and
+	// returns the identity of the authenticated user. ID token must be issued
+	// for the provided nonce.
+	Exchange(ctx context.Context, code, nonce string) (Identity, error)
+}
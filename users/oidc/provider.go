@@ -0,0 +1,368 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	kidHeader     = "kid"
+)
+
+var (
+	// ErrDiscovery indicates failure to discover the identity provider
+	// configuration.
+	ErrDiscovery = errors.New("failed to discover identity provider configuration")
+
+	// ErrExchange indicates failure to exchange the authorization code for
+	// the ID token.
+	ErrExchange = errors.New("failed to exchange authorization code")
+
+	// ErrInvalidToken indicates that the ID token is invalid or that it's not
+	// issued for this client.
+	ErrInvalidToken = errors.New("invalid ID token")
+
+	// ErrUnverifiedEmail indicates that the identity provider didn't verify
+	// the email of the user.
+	ErrUnverifiedEmail = errors.New("email is not verified")
+)
+
+var _ users.IdentityProvider = (*provider)(nil)
+
+// Config represents the identity provider configuration.
+type Config struct {
+	// Issuer is the issuer URL of the identity provider, used for the
+	// discovery of its endpoints.
+	Issuer string
+
+	// ClientID and ClientSecret are the credentials the service is
+	// registered with at the identity provider.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL the identity provider redirects the user to
+	// after the authentication.
+	RedirectURL string
+
+	// Scopes are the requested scopes. The openid scope is always requested.
+	Scopes []string
+
+	// GroupsClaim is the ID token claim which contains the groups the user
+	// is member of.
+	GroupsClaim string
+
+	// TrustMFA indicates whether the identity provider is trusted to verify
+	// the second factor. If it is, users who enabled the two-factor
+	// authentication aren't asked for the TOTP once the ID token amr claim
+	// reports the multi-factor authentication.
+	TrustMFA bool
+}
+
+type discovery struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+type provider struct {
+	cfg       Config
+	client    *http.Client
+	discovery discovery
+
+	mu   sync.Mutex
+	keys map[string]interface{}
+}
+
+// New returns OpenID Connect identity provider which uses the authorization
+// code flow. Provider endpoints are discovered from the issuer configuration.
+func New(ctx context.Context, cfg Config, client *http.Client) (users.IdentityProvider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	p := &provider{
+		cfg:    cfg,
+		client: client,
+		keys:   map[string]interface{}{},
+	}
+
+	u := strings.TrimSuffix(cfg.Issuer, "/") + discoveryPath
+	if err := p.get(ctx, u, &p.discovery); err != nil {
+		return nil, errors.Wrap(ErrDiscovery, err)
+	}
+	if p.discovery.Issuer != cfg.Issuer {
+		return nil, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer %s doesn't match %s", p.discovery.Issuer, cfg.Issuer))
+	}
+
+	return p, nil
+}
+
+func (p *provider) AuthURL(state string) string {
+	scopes := []string{"openid"}
+	for _, s := range p.cfg.Scopes {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", state)
+
+	sep := "?"
+	if strings.Contains(p.discovery.AuthURL, "?") {
+		sep = "&"
+	}
+	return p.discovery.AuthURL + sep + q.Encode()
+}
+
+func (p *provider) Exchange(ctx context.Context, code, nonce string) (users.Identity, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return users.Identity{}, errors.Wrap(ErrExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.do(req, &tokens); err != nil {
+		return users.Identity{}, errors.Wrap(ErrExchange, err)
+	}
+	if tokens.IDToken == "" {
+		return users.Identity{}, errors.Wrap(ErrExchange, errors.New("missing ID token"))
+	}
+
+	return p.verify(ctx, tokens.IDToken, nonce)
+}
+
+func (p *provider) verify(ctx context.Context, token, nonce string) (users.Identity, error) {
+	c := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, c, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method {
+		case jwt.SigningMethodRS256, jwt.SigningMethodES256:
+		default:
+			return nil, fmt.Errorf("unsupported signing method %s", t.Method.Alg())
+		}
+		kid, _ := t.Header[kidHeader].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, err)
+	}
+
+	if !c.VerifyExpiresAt(time.Now().Unix(), true) {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, errors.New("missing expiration time"))
+	}
+	if !c.VerifyIssuer(p.discovery.Issuer, true) {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, errors.New("invalid issuer"))
+	}
+	if !audience(c, p.cfg.ClientID) {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, errors.New("invalid audience"))
+	}
+	if n, _ := c["nonce"].(string); n == "" || n != nonce {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, errors.New("invalid nonce"))
+	}
+	// Missing claim is treated as unverified email, since the email is used
+	// to identify the user.
+	if v, _ := c["email_verified"].(bool); !v {
+		return users.Identity{}, ErrUnverifiedEmail
+	}
+
+	id := users.Identity{}
+	id.Subject, _ = c["sub"].(string)
+	if id.Subject == "" {
+		return users.Identity{}, errors.Wrap(ErrInvalidToken, errors.New("missing subject"))
+	}
+	id.Email, _ = c["email"].(string)
+	id.Groups = groups(c[p.cfg.GroupsClaim])
+	id.MFA = p.cfg.TrustMFA && mfa(c["amr"])
+
+	return id, nil
+}
+
+// key returns the identity provider key with the given ID. Keys are fetched
+// again once the key is unknown, since the provider may have rotated them.
+func (p *provider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.get(ctx, p.discovery.JWKSURL, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pk, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pk
+		}
+	}
+	p.keys = keys
+
+	k, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %s", kid)
+	}
+	return k, nil
+}
+
+func (p *provider) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	return p.do(req, v)
+}
+
+func (p *provider) do(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func audience(c jwt.MapClaims, clientID string) bool {
+	switch aud := c["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// mfa reports whether the authentication methods set in the amr claim
+// (RFC 8176) include the multi-factor authentication.
+func mfa(claim interface{}) bool {
+	methods, _ := claim.([]interface{})
+	for _, m := range methods {
+		if s, ok := m.(string); ok && s == "mfa" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// groups returns the groups set in the claim, which is either the array of
+// strings or the single string.
+func groups(claim interface{}) []string {
+	ret := []string{}
+	switch v := claim.(type) {
+	case string:
+		ret = append(ret, v)
+	case []interface{}:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+	}
+
+	return ret
+}
+
+// jwk is the public key in JSON Web Key format (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func decode(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,314 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "mainflux"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost/oidc/callback"
+	kid          = "key"
+	nonce        = "nonce"
+	email        = "user@example.com"
+)
+
+// idp is the mock identity provider which issues the ID token with the
+// claims registered for the authorization code.
+type idp struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	codes  map[string]jwt.MapClaims
+}
+
+func newIdP(t *testing.T) *idp {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+
+	p := &idp{
+		key:   key,
+		codes: map[string]jwt.MapClaims{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/auth",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		enc := base64.RawURLEncoding
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"use": "sig",
+					"kid": kid,
+					"n":   enc.EncodeToString(key.N.Bytes()),
+					"e":   enc.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != clientID || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		claims, ok := p.codes[r.FormValue("code")]
+		if !ok || r.FormValue("redirect_uri") != redirectURL {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+func (p *idp) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "subject",
+		"aud":            clientID,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          email,
+		"email_verified": true,
+		"groups":         []string{"admins", "operators"},
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	p := newIdP(t)
+	defer p.server.Close()
+
+	cfg := oidc.Config{
+		Issuer:      p.server.URL,
+		ClientID:    clientID,
+		RedirectURL: redirectURL,
+		Scopes:      []string{"openid", "email"},
+	}
+	provider, err := oidc.New(context.Background(), cfg, nil)
+	require.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+
+	u, err := url.Parse(provider.AuthURL("state"))
+	require.Nil(t, err, fmt.Sprintf("parsing URL expected to succeed: %s", err))
+
+	expected := map[string]string{
+		"response_type": "code",
+		"client_id":     clientID,
+		"redirect_uri":  redirectURL,
+		"scope":         "openid email",
+		"state":         "state",
+		"nonce":         "state",
+	}
+	assert.Equal(t, p.server.URL+"/auth", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), "expected authorization endpoint URL")
+	for k, v := range expected {
+		assert.Equal(t, v, u.Query().Get(k), fmt.Sprintf("expected %s %s got %s", k, v, u.Query().Get(k)))
+	}
+}
+
+func TestNew(t *testing.T) {
+	p := newIdP(t)
+	defer p.server.Close()
+
+	cases := []struct {
+		desc   string
+		issuer string
+		err    error
+	}{
+		{
+			desc:   "create provider",
+			issuer: p.server.URL,
+			err:    nil,
+		},
+		{
+			desc:   "create provider with mismatched issuer",
+			issuer: p.server.URL + "/",
+			err:    oidc.ErrDiscovery,
+		},
+		{
+			desc:   "create provider with invalid issuer",
+			issuer: p.server.URL + "/invalid",
+			err:    oidc.ErrDiscovery,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := oidc.New(context.Background(), oidc.Config{Issuer: tc.issuer}, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestExchange(t *testing.T) {
+	p := newIdP(t)
+	defer p.server.Close()
+
+	cfg := oidc.Config{
+		Issuer:       p.server.URL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		GroupsClaim:  "groups",
+		TrustMFA:     true,
+	}
+	provider, err := oidc.New(context.Background(), cfg, nil)
+	require.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+
+	p.codes["valid"] = p.claims()
+
+	mfa := p.claims()
+	mfa["amr"] = []string{"pwd", "mfa"}
+	p.codes["mfa"] = mfa
+
+	wrongAud := p.claims()
+	wrongAud["aud"] = []string{"other"}
+	p.codes["wrong-aud"] = wrongAud
+
+	wrongIss := p.claims()
+	wrongIss["iss"] = "http://other"
+	p.codes["wrong-iss"] = wrongIss
+
+	expired := p.claims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	p.codes["expired"] = expired
+
+	unverified := p.claims()
+	unverified["email_verified"] = false
+	p.codes["unverified"] = unverified
+
+	noVerified := p.claims()
+	delete(noVerified, "email_verified")
+	p.codes["no-verified"] = noVerified
+
+	noSubject := p.claims()
+	delete(noSubject, "sub")
+	p.codes["no-subject"] = noSubject
+
+	noGroups := p.claims()
+	delete(noGroups, "groups")
+	p.codes["no-groups"] = noGroups
+
+	cases := []struct {
+		desc  string
+		code  string
+		nonce string
+		id    users.Identity
+		err   error
+	}{
+		{
+			desc:  "exchange valid code",
+			code:  "valid",
+			nonce: nonce,
+			id:    users.Identity{Subject: "subject", Email: email, Groups: []string{"admins", "operators"}},
+			err:   nil,
+		},
+		{
+			desc:  "exchange valid code with multi-factor authentication",
+			code:  "mfa",
+			nonce: nonce,
+			id:    users.Identity{Subject: "subject", Email: email, Groups: []string{"admins", "operators"}, MFA: true},
+			err:   nil,
+		},
+		{
+			desc:  "exchange valid code without groups",
+			code:  "no-groups",
+			nonce: nonce,
+			id:    users.Identity{Subject: "subject", Email: email, Groups: []string{}},
+			err:   nil,
+		},
+		{
+			desc:  "exchange code with wrong nonce",
+			code:  "valid",
+			nonce: "wrong",
+			err:   oidc.ErrInvalidToken,
+		},
+		{
+			desc:  "exchange unknown code",
+			code:  "unknown",
+			nonce: nonce,
+			err:   oidc.ErrExchange,
+		},
+		{
+			desc:  "exchange code for token issued for other client",
+			code:  "wrong-aud",
+			nonce: nonce,
+			err:   oidc.ErrInvalidToken,
+		},
+		{
+			desc:  "exchange code for token issued by other issuer",
+			code:  "wrong-iss",
+			nonce: nonce,
+			err:   oidc.ErrInvalidToken,
+		},
+		{
+			desc:  "exchange code for expired token",
+			code:  "expired",
+			nonce: nonce,
+			err:   oidc.ErrInvalidToken,
+		},
+		{
+			desc:  "exchange code for token with unverified email",
+			code:  "unverified",
+			nonce: nonce,
+			err:   oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:  "exchange code for token without email verification",
+			code:  "no-verified",
+			nonce: nonce,
+			err:   oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:  "exchange code for token without subject",
+			code:  "no-subject",
+			nonce: nonce,
+			err:   oidc.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := provider.Exchange(context.Background(), tc.code, tc.nonce)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.id, id))
+	}
+}
@@ -68,6 +68,30 @@ func migrateDB(db *sqlx.DB) error {
 					id UUID NOT NULL DEFAULT gen_random_uuid()`,
 				},
 			},
+			{
+				Id: "users_4",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users
+					ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+					ADD COLUMN IF NOT EXISTS recovery_codes TEXT[]`,
+				},
+			},
+			{
+				Id: "users_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users
+					ADD COLUMN IF NOT EXISTS totp_counter BIGINT NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS totp_attempts INTEGER NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS totp_attempted_at TIMESTAMP`,
+				},
+			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS oidc_subject VARCHAR(255) UNIQUE`,
+				},
+			},
 		},
 	}
 
@@ -8,7 +8,9 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users"
 )
@@ -19,6 +21,7 @@ var (
 	errUpdateUserDB     = errors.New("Update user metadata to DB failed")
 	errRetrieveDB       = errors.New("Retreiving from DB failed")
 	errUpdatePasswordDB = errors.New("Update password to DB failed")
+	errUpdateTwoFactor  = errors.New("Update two-factor authentication to DB failed")
 )
 
 var _ users.UserRepository = (*userRepository)(nil)
@@ -38,7 +41,7 @@ func New(db Database) users.UserRepository {
 }
 
 func (ur userRepository) Save(ctx context.Context, user users.User) error {
-	q := `INSERT INTO users (id, email, password, metadata) VALUES (:id, :email, :password, :metadata)`
+	q := `INSERT INTO users (id, email, password, metadata, oidc_subject) VALUES (:id, :email, :password, :metadata, :oidc_subject)`
 
 	dbu := toDBUser(user)
 	if _, err := ur.db.NamedExecContext(ctx, q, dbu); err != nil {
@@ -71,7 +74,7 @@ func (ur userRepository) UpdateUser(ctx context.Context, user users.User) error
 }
 
 func (ur userRepository) RetrieveByEmail(ctx context.Context, email string) (users.User, error) {
-	q := `SELECT id, password, metadata FROM users WHERE email = $1`
+	q := `SELECT id, password, metadata, oidc_subject FROM users WHERE email = $1`
 
 	dbu := dbUser{
 		Email: email,
@@ -104,6 +107,98 @@ func (ur userRepository) UpdatePassword(ctx context.Context, email, password str
 	return nil
 }
 
+func (ur userRepository) RetrieveTwoFactor(ctx context.Context, email string) (users.TwoFactor, error) {
+	q := `SELECT totp_secret, totp_enabled, recovery_codes, totp_counter FROM users WHERE email = $1`
+
+	dbtf := dbTwoFactor{}
+	if err := ur.db.QueryRowxContext(ctx, q, email).StructScan(&dbtf); err != nil {
+		if err == sql.ErrNoRows {
+			return users.TwoFactor{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.TwoFactor{}, errors.Wrap(errRetrieveDB, err)
+	}
+
+	return users.TwoFactor{
+		Secret:        dbtf.Secret,
+		Enabled:       dbtf.Enabled,
+		RecoveryCodes: dbtf.RecoveryCodes,
+		Counter:       uint64(dbtf.Counter),
+	}, nil
+}
+
+func (ur userRepository) UpdateTwoFactor(ctx context.Context, email string, tf users.TwoFactor) error {
+	q := `UPDATE users SET totp_secret = :totp_secret, totp_enabled = :totp_enabled, recovery_codes = :recovery_codes,
+	      totp_counter = :totp_counter, totp_attempts = 0 WHERE email = :email`
+
+	dbtf := dbTwoFactor{
+		Email:         email,
+		Secret:        tf.Secret,
+		Enabled:       tf.Enabled,
+		RecoveryCodes: tf.RecoveryCodes,
+		Counter:       int64(tf.Counter),
+	}
+	if _, err := ur.db.NamedExecContext(ctx, q, dbtf); err != nil {
+		return errors.Wrap(errUpdateTwoFactor, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) SaveTOTPAttempt(ctx context.Context, email string, at time.Time, max uint32, lockout time.Duration) error {
+	// Attempt is recorded in the same statement the limit is checked in, so
+	// the concurrent attempts can't exceed it.
+	q := `UPDATE users SET totp_attempts = totp_attempts + 1, totp_attempted_at = :at
+	      WHERE email = :email AND (totp_attempts < :max OR totp_attempted_at IS NULL OR totp_attempted_at <= :unlocked)`
+
+	params := map[string]interface{}{
+		"email":    email,
+		"at":       at.UTC(),
+		"max":      max,
+		"unlocked": at.Add(-lockout).UTC(),
+	}
+	return ur.updateTwoFactor(ctx, q, params, users.ErrTOTPLocked)
+}
+
+func (ur userRepository) UseTOTPCounter(ctx context.Context, email string, counter uint64) error {
+	q := `UPDATE users SET totp_counter = :counter, totp_attempts = 0 WHERE email = :email AND totp_counter < :counter`
+
+	params := map[string]interface{}{
+		"email":   email,
+		"counter": int64(counter),
+	}
+	return ur.updateTwoFactor(ctx, q, params, users.ErrConflict)
+}
+
+func (ur userRepository) UseRecoveryCode(ctx context.Context, email, hash string) error {
+	q := `UPDATE users SET recovery_codes = array_remove(recovery_codes, :hash), totp_attempts = 0
+	      WHERE email = :email AND :hash = ANY(recovery_codes)`
+
+	params := map[string]interface{}{
+		"email": email,
+		"hash":  hash,
+	}
+	return ur.updateTwoFactor(ctx, q, params, users.ErrConflict)
+}
+
+// updateTwoFactor executes the conditional update of the two-factor
+// authentication settings, and returns the given error if no row is updated.
+func (ur userRepository) updateTwoFactor(ctx context.Context, q string, params map[string]interface{}, notUpdated error) error {
+	res, err := ur.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errUpdateTwoFactor, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateTwoFactor, err)
+	}
+	if cnt == 0 {
+		return notUpdated
+	}
+
+	return nil
+}
+
 // dbMetadata type for handling metadata properly in database/sql
 type dbMetadata map[string]interface{}
 
@@ -141,10 +236,19 @@ func (m dbMetadata) Value() (driver.Value, error) {
 }
 
 type dbUser struct {
-	ID       string     `db:"id"`
-	Email    string     `db:"email"`
-	Password string     `db:"password"`
-	Metadata dbMetadata `db:"metadata"`
+	ID          string         `db:"id"`
+	Email       string         `db:"email"`
+	Password    string         `db:"password"`
+	Metadata    dbMetadata     `db:"metadata"`
+	OIDCSubject sql.NullString `db:"oidc_subject"`
+}
+
+type dbTwoFactor struct {
+	Email         string         `db:"email"`
+	Secret        string         `db:"totp_secret"`
+	Enabled       bool           `db:"totp_enabled"`
+	RecoveryCodes pq.StringArray `db:"recovery_codes"`
+	Counter       int64          `db:"totp_counter"`
 }
 
 func toDBUser(u users.User) dbUser {
@@ -153,14 +257,19 @@ func toDBUser(u users.User) dbUser {
 		Email:    u.Email,
 		Password: u.Password,
 		Metadata: u.Metadata,
+		OIDCSubject: sql.NullString{
+			String: u.OIDCSubject,
+			Valid:  u.OIDCSubject != "",
+		},
 	}
 }
 
 func toUser(dbu dbUser) users.User {
 	return users.User{
-		ID:       dbu.ID,
-		Email:    dbu.Email,
-		Password: dbu.Password,
-		Metadata: dbu.Metadata,
+		ID:          dbu.ID,
+		Email:       dbu.Email,
+		Password:    dbu.Password,
+		Metadata:    dbu.Metadata,
+		OIDCSubject: dbu.OIDCSubject.String,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -87,3 +88,168 @@ func TestSingleUserRetrieval(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
+
+func TestOIDCSubject(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	cases := []struct {
+		desc    string
+		email   string
+		subject string
+		saved   bool
+	}{
+		{"save user with subject", "user-subject@example.com", "subject", true},
+		{"save user with subject of other user", "user-subject-other@example.com", "subject", false},
+		{"save user without subject", "user-no-subject@example.com", "", true},
+		{"save other user without subject", "user-no-subject-other@example.com", "", true},
+	}
+
+	for _, tc := range cases {
+		uid, err := uuid.New().ID()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = repo.Save(context.Background(), users.User{ID: uid, Email: tc.email, Password: "pass", OIDCSubject: tc.subject})
+		assert.Equal(t, tc.saved, err == nil, fmt.Sprintf("%s: expected saved %t got error %s\n", tc.desc, tc.saved, err))
+		if err != nil {
+			continue
+		}
+		u, err := repo.RetrieveByEmail(context.Background(), tc.email)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.subject, u.OIDCSubject, fmt.Sprintf("%s: expected subject %s got %s\n", tc.desc, tc.subject, u.OIDCSubject))
+	}
+}
+
+func TestTwoFactor(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-two-factor@example.com"
+
+	uid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	user := users.User{
+		ID:       uid,
+		Email:    email,
+		Password: "pass",
+	}
+
+	err = repo.Save(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	tf, err := repo.RetrieveTwoFactor(context.Background(), email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, users.TwoFactor{}, tf, fmt.Sprintf("expected empty two-factor settings got %v", tf))
+
+	cases := []struct {
+		desc string
+		tf   users.TwoFactor
+	}{
+		{
+			desc: "update two-factor settings",
+			tf: users.TwoFactor{
+				Secret:        "JBSWY3DPEHPK3PXP",
+				Enabled:       true,
+				RecoveryCodes: []string{"code1", "code2"},
+				Counter:       1,
+			},
+		},
+		{
+			desc: "reset two-factor settings",
+			tf:   users.TwoFactor{},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateTwoFactor(context.Background(), email, tc.tf)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		tf, err := repo.RetrieveTwoFactor(context.Background(), email)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.tf, tf, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.tf, tf))
+	}
+
+	_, err = repo.RetrieveTwoFactor(context.Background(), "non-existing@example.com")
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("retrieve non-existing user: expected %s got %s\n", users.ErrNotFound, err))
+}
+
+func TestUseTwoFactor(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-use-two-factor@example.com"
+
+	uid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Save(context.Background(), users.User{ID: uid, Email: email, Password: "pass"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	tf := users.TwoFactor{
+		Secret:        "JBSWY3DPEHPK3PXP",
+		Enabled:       true,
+		RecoveryCodes: []string{"code1", "code2"},
+		Counter:       10,
+	}
+	err = repo.UpdateTwoFactor(context.Background(), email, tf)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	counterCases := []struct {
+		desc    string
+		counter uint64
+		err     error
+	}{
+		{"use previous counter", 9, users.ErrConflict},
+		{"use current counter", 10, users.ErrConflict},
+		{"use next counter", 11, nil},
+		{"use next counter again", 11, users.ErrConflict},
+	}
+
+	for _, tc := range counterCases {
+		err := repo.UseTOTPCounter(context.Background(), email, tc.counter)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	codeCases := []struct {
+		desc  string
+		code  string
+		codes []string
+		err   error
+	}{
+		{"use recovery code", "code1", []string{"code2"}, nil},
+		{"use used recovery code", "code1", []string{"code2"}, users.ErrConflict},
+		{"use unknown recovery code", "unknown", []string{"code2"}, users.ErrConflict},
+	}
+
+	for _, tc := range codeCases {
+		err := repo.UseRecoveryCode(context.Background(), email, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		tf, err := repo.RetrieveTwoFactor(context.Background(), email)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.codes, []string(tf.RecoveryCodes), fmt.Sprintf("%s: expected recovery codes %v got %v", tc.desc, tc.codes, tf.RecoveryCodes))
+	}
+
+	now := time.Now()
+	lockout := time.Minute
+	attemptCases := []struct {
+		desc string
+		at   time.Time
+		err  error
+	}{
+		{"save first attempt", now, nil},
+		{"save second attempt", now, nil},
+		{"save attempt over limit", now, users.ErrTOTPLocked},
+		{"save attempt after lockout", now.Add(lockout), nil},
+		{"save attempt over limit after lockout", now.Add(lockout), users.ErrTOTPLocked},
+	}
+
+	for _, tc := range attemptCases {
+		err := repo.SaveTOTPAttempt(context.Background(), email, tc.at, 2, lockout)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = repo.UseTOTPCounter(context.Background(), email, 12)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.SaveTOTPAttempt(context.Background(), email, now.Add(lockout), 2, lockout)
+	assert.Nil(t, err, fmt.Sprintf("save attempt after successful one: unexpected error: %s", err))
+}
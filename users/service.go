@@ -5,6 +5,11 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"strings"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
@@ -49,8 +54,42 @@ var (
 
 	// ErrCreateUser indicates error in creating User
 	ErrCreateUser = errors.New("failed to create user")
+
+	// ErrOIDCDisabled indicates that the identity provider isn't configured.
+	ErrOIDCDisabled = errors.New("OpenID Connect login is not configured")
+
+	// ErrTOTPRequired indicates that the user enabled the two-factor
+	// authentication, and the login is missing the TOTP or recovery code.
+	ErrTOTPRequired = errors.New("two-factor authentication code required")
+
+	// ErrTOTPEnabled indicates that the two-factor authentication is
+	// already enabled.
+	ErrTOTPEnabled = errors.New("two-factor authentication already enabled")
+
+	// ErrTOTPNotEnrolled indicates that the user didn't enroll, or didn't
+	// enable the two-factor authentication.
+	ErrTOTPNotEnrolled = errors.New("two-factor authentication not enrolled")
+
+	// ErrTOTPLocked indicates that the two-factor authentication is
+	// temporarily locked after too many failed attempts.
+	ErrTOTPLocked = errors.New("too many two-factor authentication attempts")
+)
+
+const (
+	stateSize = 32
+
+	recoveryCodesNum = 10
+	recoveryCodeSize = 5
+
+	// maxTOTPAttempts is the number of two-factor authentication attempts
+	// allowed before the successful one, after which each attempt is allowed
+	// only once totpLockout passes since the previous one.
+	maxTOTPAttempts = 5
+	totpLockout     = 15 * time.Minute
 )
 
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
@@ -58,10 +97,13 @@ type Service interface {
 	// non-nil error value is returned.
 	Register(ctx context.Context, user User) error
 
-	// Login authenticates the user given its credentials. Successful
-	// authentication generates new access token. Failed invocations are
-	// identified by the non-nil error values in the response.
-	Login(ctx context.Context, user User) (string, error)
+	// Login authenticates the user given its credentials. Code is the TOTP
+	// or the recovery code, required only if the user enabled the two-factor
+	// authentication. Successful authentication generates new access token,
+	// along with the refresh token the new access token can be issued with
+	// once it expires. Failed invocations are identified by the non-nil
+	// error values in the response.
+	Login(ctx context.Context, user User, code string) (string, string, error)
 
 	// ViewUser authenticated user info for the given token.
 	ViewUser(ctx context.Context, token string) (User, error)
@@ -82,6 +124,36 @@ type Service interface {
 
 	//SendPasswordReset sends reset password link to email.
 	SendPasswordReset(ctx context.Context, host, email, token string) error
+
+	// OIDCAuthURL returns the URL of the identity provider the user is
+	// redirected to for authentication, along with the random state which
+	// must be presented on login.
+	OIDCAuthURL(ctx context.Context) (string, string, error)
+
+	// OIDCLogin authenticates the user with the authorization code issued by
+	// the identity provider for the given state. The user account is created
+	// on the first login, and the groups the user is member of are set in
+	// the user metadata on every login. Accounts which aren't created on the
+	// OpenID Connect login, or which are created for the other identity
+	// provider subject, are rejected with ErrConflict. TOTP is the TOTP or the recovery
+	// code, required if the user enabled the two-factor authentication,
+	// unless the identity provider is trusted to verify the second factor.
+	// Successful authentication generates new access token and refresh
+	// token, the same as Login does.
+	OIDCLogin(ctx context.Context, code, state, totp string) (string, string, error)
+
+	// EnrollTOTP generates the new TOTP secret and recovery codes of the
+	// authenticated user. Two-factor authentication isn't required on login
+	// until the enrollment is confirmed with EnableTOTP.
+	EnrollTOTP(ctx context.Context, token string) (TOTPEnrollment, error)
+
+	// EnableTOTP enables the two-factor authentication once the code
+	// generated by the enrolled authenticator app is verified.
+	EnableTOTP(ctx context.Context, token, code string) error
+
+	// DisableTOTP disables the two-factor authentication given the TOTP or
+	// the recovery code.
+	DisableTOTP(ctx context.Context, token, code string) error
 }
 
 var _ Service = (*usersService)(nil)
@@ -89,17 +161,22 @@ var _ Service = (*usersService)(nil)
 type usersService struct {
 	users  UserRepository
 	hasher Hasher
+	totp   TOTP
 	email  Emailer
 	auth   mainflux.AuthNServiceClient
+	idp    IdentityProvider
 }
 
-// New instantiates the users service implementation
-func New(users UserRepository, hasher Hasher, auth mainflux.AuthNServiceClient, m Emailer) Service {
+// New instantiates the users service implementation. Identity provider is
+// optional, and OpenID Connect login is disabled if it's nil.
+func New(users UserRepository, hasher Hasher, totp TOTP, auth mainflux.AuthNServiceClient, m Emailer, idp IdentityProvider) Service {
 	return &usersService{
 		users:  users,
 		hasher: hasher,
+		totp:   totp,
 		auth:   auth,
 		email:  m,
+		idp:    idp,
 	}
 }
 
@@ -110,6 +187,9 @@ func (svc usersService) Register(ctx context.Context, user User) error {
 	}
 
 	user.Password = hash
+	// Only the accounts provisioned on the OpenID Connect login are linked
+	// to the identity provider.
+	user.OIDCSubject = ""
 
 	uid, err := uuidProvider.New().ID()
 	if err != nil {
@@ -120,17 +200,30 @@ func (svc usersService) Register(ctx context.Context, user User) error {
 	return svc.users.Save(ctx, user)
 }
 
-func (svc usersService) Login(ctx context.Context, user User) (string, error) {
+func (svc usersService) Login(ctx context.Context, user User, code string) (string, string, error) {
 	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
 	if err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
 	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	tf, err := svc.users.RetrieveTwoFactor(ctx, dbUser.Email)
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if tf.Enabled {
+		if code == "" {
+			return "", "", ErrTOTPRequired
+		}
+		if err := svc.verifyTOTP(ctx, dbUser.Email, tf, code); err != nil {
+			return "", "", err
+		}
 	}
 
-	return svc.issue(ctx, dbUser.Email, authn.UserKey)
+	return svc.login(ctx, dbUser.Email)
 }
 
 func (svc usersService) ViewUser(ctx context.Context, token string) (User, error) {
@@ -203,18 +296,13 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	u := User{
-		Email:    email,
-		Password: oldPassword,
-	}
-	if _, err := svc.Login(ctx, u); err != nil {
-		return ErrUnauthorizedAccess
-	}
-
-	u, err = svc.users.RetrieveByEmail(ctx, email)
+	u, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil || u.Email == "" {
 		return ErrUserNotFound
 	}
+	if err := svc.hasher.Compare(oldPassword, u.Password); err != nil {
+		return ErrUnauthorizedAccess
+	}
 
 	password, err = svc.hasher.Hash(password)
 	if err != nil {
@@ -229,6 +317,239 @@ func (svc usersService) SendPasswordReset(_ context.Context, host, email, token
 	return svc.email.SendPasswordReset(to, host, token)
 }
 
+func (svc usersService) OIDCAuthURL(_ context.Context) (string, string, error) {
+	if svc.idp == nil {
+		return "", "", ErrOIDCDisabled
+	}
+
+	b := make([]byte, stateSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	state := base64.RawURLEncoding.EncodeToString(b)
+
+	return svc.idp.AuthURL(state), state, nil
+}
+
+func (svc usersService) OIDCLogin(ctx context.Context, code, state, totp string) (string, string, error) {
+	if svc.idp == nil {
+		return "", "", ErrOIDCDisabled
+	}
+
+	id, err := svc.idp.Exchange(ctx, code, state)
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if id.Subject == "" || !isEmail(id.Email) {
+		return "", "", ErrUnauthorizedAccess
+	}
+
+	groups := make([]interface{}, len(id.Groups))
+	for i, g := range id.Groups {
+		groups[i] = g
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, id.Email)
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		if err := svc.provision(ctx, id, groups); err != nil {
+			return "", "", err
+		}
+	case err != nil:
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	case user.OIDCSubject != id.Subject:
+		// Password accounts aren't linked automatically, since the identity
+		// provider could be used to take over them.
+		return "", "", ErrConflict
+	default:
+		tf, err := svc.users.RetrieveTwoFactor(ctx, id.Email)
+		if err != nil {
+			return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+		if tf.Enabled && !id.MFA {
+			if totp == "" {
+				return "", "", ErrTOTPRequired
+			}
+			if err := svc.verifyTOTP(ctx, id.Email, tf, totp); err != nil {
+				return "", "", err
+			}
+		}
+
+		metadata := map[string]interface{}{GroupsKey: groups}
+		for k, v := range user.Metadata {
+			if k != GroupsKey {
+				metadata[k] = v
+			}
+		}
+		user.Metadata = metadata
+		if err := svc.users.UpdateUser(ctx, user); err != nil {
+			return "", "", err
+		}
+	}
+
+	return svc.login(ctx, id.Email)
+}
+
+func (svc usersService) EnrollTOTP(ctx context.Context, token string) (TOTPEnrollment, error) {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	tf, err := svc.users.RetrieveTwoFactor(ctx, email)
+	if err != nil {
+		return TOTPEnrollment{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if tf.Enabled {
+		return TOTPEnrollment{}, ErrTOTPEnabled
+	}
+
+	secret, err := svc.totp.Secret()
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	codes := make([]string, recoveryCodesNum)
+	hashes := make([]string, recoveryCodesNum)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return TOTPEnrollment{}, err
+		}
+		codes[i] = strings.ToLower(recoveryEncoding.EncodeToString(b))
+		if hashes[i], err = svc.hasher.Hash(codes[i]); err != nil {
+			return TOTPEnrollment{}, err
+		}
+	}
+
+	tf = TwoFactor{
+		Secret:        secret,
+		RecoveryCodes: hashes,
+	}
+	if err := svc.users.UpdateTwoFactor(ctx, email, tf); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	return TOTPEnrollment{
+		Secret:        secret,
+		URI:           svc.totp.URI(secret, email),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (svc usersService) EnableTOTP(ctx context.Context, token, code string) error {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	tf, err := svc.users.RetrieveTwoFactor(ctx, email)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if tf.Enabled {
+		return ErrTOTPEnabled
+	}
+	if tf.Secret == "" {
+		return ErrTOTPNotEnrolled
+	}
+
+	if err := svc.users.SaveTOTPAttempt(ctx, email, time.Now(), maxTOTPAttempts, totpLockout); err != nil {
+		return err
+	}
+
+	// Recovery codes aren't accepted, since the code confirms that the
+	// authenticator app is enrolled.
+	counter, err := svc.totp.Validate(tf.Secret, code)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	tf.Enabled = true
+	tf.Counter = counter
+	return svc.users.UpdateTwoFactor(ctx, email, tf)
+}
+
+func (svc usersService) DisableTOTP(ctx context.Context, token, code string) error {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	tf, err := svc.users.RetrieveTwoFactor(ctx, email)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !tf.Enabled {
+		return ErrTOTPNotEnrolled
+	}
+
+	if err := svc.verifyTOTP(ctx, email, tf, code); err != nil {
+		return err
+	}
+
+	return svc.users.UpdateTwoFactor(ctx, email, TwoFactor{})
+}
+
+// verifyTOTP verifies the TOTP or the recovery code of the user. Each code
+// can be used only once, and the attempts are limited, so the codes can't be
+// guessed.
+func (svc usersService) verifyTOTP(ctx context.Context, email string, tf TwoFactor, code string) error {
+	if err := svc.users.SaveTOTPAttempt(ctx, email, time.Now(), maxTOTPAttempts, totpLockout); err != nil {
+		return err
+	}
+
+	if counter, err := svc.totp.Validate(tf.Secret, code); err == nil {
+		if err := svc.users.UseTOTPCounter(ctx, email, counter); err != nil {
+			return errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+		return nil
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, hash := range tf.RecoveryCodes {
+		if err := svc.hasher.Compare(code, hash); err != nil {
+			continue
+		}
+		if err := svc.users.UseRecoveryCode(ctx, email, hash); err != nil {
+			return errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+		return nil
+	}
+
+	return ErrUnauthorizedAccess
+}
+
+// provision creates the account of the user who logs in with the identity
+// provider for the first time. Account is created with the random password,
+// so the user can log in only with the identity provider, unless the password
+// is reset.
+func (svc usersService) provision(ctx context.Context, id Identity, groups []interface{}) error {
+	b := make([]byte, stateSize)
+	if _, err := rand.Read(b); err != nil {
+		return errors.Wrap(ErrCreateUser, err)
+	}
+
+	hash, err := svc.hasher.Hash(base64.RawURLEncoding.EncodeToString(b))
+	if err != nil {
+		return errors.Wrap(ErrCreateUser, err)
+	}
+
+	uid, err := uuidProvider.New().ID()
+	if err != nil {
+		return errors.Wrap(ErrCreateUser, err)
+	}
+
+	user := User{
+		ID:          uid,
+		Email:       id.Email,
+		Password:    hash,
+		Metadata:    map[string]interface{}{GroupsKey: groups},
+		OIDCSubject: id.Subject,
+	}
+	return svc.users.Save(ctx, user)
+}
+
 func (svc usersService) identify(ctx context.Context, token string) (string, error) {
 	email, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -237,6 +558,16 @@ func (svc usersService) identify(ctx context.Context, token string) (string, err
 	return email.GetValue(), nil
 }
 
+// login issues the access token and the refresh token of the new login
+// session of the user.
+func (svc usersService) login(ctx context.Context, email string) (string, string, error) {
+	key, err := svc.auth.Issue(ctx, &mainflux.IssueReq{Issuer: email, Type: authn.UserKey})
+	if err != nil {
+		return "", "", errors.Wrap(ErrUserNotFound, err)
+	}
+	return key.GetValue(), key.GetRefresh(), nil
+}
+
 func (svc usersService) issue(ctx context.Context, email string, keyType uint32) (string, error) {
 	key, err := svc.auth.Issue(ctx, &mainflux.IssueReq{Issuer: email, Type: keyType})
 	if err != nil {
@@ -22,16 +22,21 @@ const wrong string = "wrong-value"
 var (
 	user            = users.User{Email: "user@example.com", Password: "password", Metadata: map[string]interface{}{"role": "user"}}
 	nonExistingUser = users.User{Email: "non-ex-user@example.com", Password: "password", Metadata: map[string]interface{}{"role": "user"}}
+	oidcUser        = users.Identity{Subject: "subject", Email: "oidc@example.com", Groups: []string{"admins"}}
 	host            = "example.com"
 )
 
 func newService() users.Service {
+	return newOIDCService(nil)
+}
+
+func newOIDCService(idp users.IdentityProvider) users.Service {
 	repo := mocks.NewUserRepository()
 	hasher := mocks.NewHasher()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email, oidcUser.Email: oidcUser.Email})
 	e := mocks.NewEmailer()
 
-	return users.New(repo, hasher, auth, e)
+	return users.New(repo, hasher, mocks.NewTOTP(), auth, e, idp)
 }
 
 func TestRegister(t *testing.T) {
@@ -107,7 +112,7 @@ func TestLogin(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.Login(context.Background(), tc.user)
+		_, _, err := svc.Login(context.Background(), tc.user, "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -116,7 +121,7 @@ func TestViewUser(t *testing.T) {
 	svc := newService()
 	svc.Register(context.Background(), user)
 
-	token, err := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	u := user
@@ -148,7 +153,7 @@ func TestViewUser(t *testing.T) {
 func TestUpdateUser(t *testing.T) {
 	svc := newService()
 	svc.Register(context.Background(), user)
-	token, err := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	user.Metadata = map[string]interface{}{"role": "test"}
@@ -197,7 +202,7 @@ func TestGenerateResetToken(t *testing.T) {
 func TestChangePassword(t *testing.T) {
 	svc := newService()
 	svc.Register(context.Background(), user)
-	token, _ := svc.Login(context.Background(), user)
+	token, _, _ := svc.Login(context.Background(), user, "")
 
 	cases := map[string]struct {
 		token       string
@@ -241,7 +246,7 @@ func TestResetPassword(t *testing.T) {
 func TestSendPasswordReset(t *testing.T) {
 	svc := newService()
 	svc.Register(context.Background(), user)
-	token, _ := svc.Login(context.Background(), user)
+	token, _, _ := svc.Login(context.Background(), user, "")
 
 	cases := map[string]struct {
 		token string
@@ -257,3 +262,460 @@ func TestSendPasswordReset(t *testing.T) {
 
 	}
 }
+
+func TestOIDCAuthURL(t *testing.T) {
+	svc := newOIDCService(mocks.NewIdentityProvider(nil))
+
+	url, state, err := svc.OIDCAuthURL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEmpty(t, state, "expected non-empty state")
+	assert.Contains(t, url, mocks.AuthURL, fmt.Sprintf("expected URL of the identity provider got %s", url))
+
+	_, next, err := svc.OIDCAuthURL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEqual(t, state, next, "expected unique state")
+
+	_, _, err = newService().OIDCAuthURL(context.Background())
+	assert.True(t, errors.Contains(err, users.ErrOIDCDisabled), fmt.Sprintf("expected %s got %s\n", users.ErrOIDCDisabled, err))
+}
+
+func TestOIDCLogin(t *testing.T) {
+	returning := users.Identity{Subject: oidcUser.Subject, Email: oidcUser.Email, Groups: []string{"operators"}}
+	idp := mocks.NewIdentityProvider(map[string]users.Identity{
+		"new":             oidcUser,
+		"returning":       returning,
+		"other-subject":   {Subject: "other", Email: oidcUser.Email},
+		"password":        {Subject: "password", Email: user.Email},
+		"invalid-email":   {Subject: "invalid", Email: "invalid"},
+		"missing-subject": {Email: "missing@example.com"},
+	})
+	svc := newOIDCService(idp)
+	err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, state, err := svc.OIDCAuthURL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		code     string
+		state    string
+		email    string
+		metadata map[string]interface{}
+		err      error
+	}{
+		{
+			desc:     "login new user",
+			code:     "new",
+			state:    state,
+			email:    oidcUser.Email,
+			metadata: map[string]interface{}{users.GroupsKey: []interface{}{"admins"}},
+			err:      nil,
+		},
+		{
+			desc:     "login returning user",
+			code:     "returning",
+			state:    state,
+			email:    oidcUser.Email,
+			metadata: map[string]interface{}{users.GroupsKey: []interface{}{"operators"}},
+			err:      nil,
+		},
+		{
+			desc:  "login user with email of other subject",
+			code:  "other-subject",
+			state: state,
+			err:   users.ErrConflict,
+		},
+		{
+			desc:  "login user with email of password account",
+			code:  "password",
+			state: state,
+			err:   users.ErrConflict,
+		},
+		{
+			desc:  "login user without subject",
+			code:  "missing-subject",
+			state: state,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "login with invalid code",
+			code:  wrong,
+			state: state,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "login with invalid state",
+			code:  "new",
+			state: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "login user with invalid email",
+			code:  "invalid-email",
+			state: state,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		token, _, err := svc.OIDCLogin(context.Background(), tc.code, tc.state, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		u, err := svc.ViewUser(context.Background(), token)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.email, u.Email, fmt.Sprintf("%s: expected email %s got %s\n", tc.desc, tc.email, u.Email))
+		assert.Equal(t, tc.metadata, u.Metadata, fmt.Sprintf("%s: expected metadata %v got %v\n", tc.desc, tc.metadata, u.Metadata))
+	}
+
+	_, _, err = newService().OIDCLogin(context.Background(), "new", state, "")
+	assert.True(t, errors.Contains(err, users.ErrOIDCDisabled), fmt.Sprintf("expected %s got %s\n", users.ErrOIDCDisabled, err))
+}
+
+func TestOIDCLoginTOTP(t *testing.T) {
+	mfa := oidcUser
+	mfa.MFA = true
+	idp := mocks.NewIdentityProvider(map[string]users.Identity{
+		"user": oidcUser,
+		"mfa":  mfa,
+	})
+	svc := newOIDCService(idp)
+
+	_, state, err := svc.OIDCAuthURL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token, _, err := svc.OIDCLogin(context.Background(), "user", state, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		code string
+		totp string
+		err  error
+	}{
+		{
+			desc: "login without TOTP",
+			code: "user",
+			totp: "",
+			err:  users.ErrTOTPRequired,
+		},
+		{
+			desc: "login with invalid TOTP",
+			code: "user",
+			totp: wrong,
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with valid TOTP",
+			code: "user",
+			totp: "123457",
+			err:  nil,
+		},
+		{
+			desc: "login with used TOTP",
+			code: "user",
+			totp: "123457",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with recovery code",
+			code: "user",
+			totp: e.RecoveryCodes[0],
+			err:  nil,
+		},
+		{
+			desc: "login with identity provider verified second factor",
+			code: "mfa",
+			totp: "",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.OIDCLogin(context.Background(), tc.code, state, tc.totp)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "enroll TOTP",
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "enroll TOTP again before it's enabled",
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "enroll TOTP with invalid token",
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		e, err := svc.EnrollTOTP(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.NotEmpty(t, e.Secret, fmt.Sprintf("%s: expected non-empty secret", tc.desc))
+		assert.Contains(t, e.URI, e.Secret, fmt.Sprintf("%s: expected URI to contain the secret", tc.desc))
+		assert.Len(t, e.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(e.RecoveryCodes)))
+	}
+
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.EnrollTOTP(context.Background(), token)
+	assert.True(t, errors.Contains(err, users.ErrTOTPEnabled), fmt.Sprintf("enroll enabled TOTP: expected %s got %s\n", users.ErrTOTPEnabled, err))
+}
+
+func TestEnableTOTP(t *testing.T) {
+	svc := newService()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	assert.True(t, errors.Contains(err, users.ErrTOTPNotEnrolled), fmt.Sprintf("enable TOTP before enrollment: expected %s got %s\n", users.ErrTOTPNotEnrolled, err))
+
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "enable TOTP with invalid token",
+			token: wrong,
+			code:  mocks.TOTPCode,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "enable TOTP with invalid code",
+			token: token,
+			code:  wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "enable TOTP with recovery code",
+			token: token,
+			code:  e.RecoveryCodes[0],
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "enable TOTP",
+			token: token,
+			code:  mocks.TOTPCode,
+			err:   nil,
+		},
+		{
+			desc:  "enable enabled TOTP",
+			token: token,
+			code:  mocks.TOTPCode,
+			err:   users.ErrTOTPEnabled,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.EnableTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	svc := newService()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login with enrolled TOTP expected to succeed: %s", err))
+
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		user users.User
+		code string
+		err  error
+	}{
+		{
+			desc: "login without code",
+			user: user,
+			code: "",
+			err:  users.ErrTOTPRequired,
+		},
+		{
+			desc: "login with invalid code",
+			user: user,
+			code: wrong,
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with wrong password and valid code",
+			user: users.User{Email: user.Email, Password: wrong},
+			code: mocks.TOTPCode,
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with valid code",
+			user: user,
+			code: "123457",
+			err:  nil,
+		},
+		{
+			desc: "login with used code",
+			user: user,
+			code: "123457",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with code preceding used one",
+			user: user,
+			code: mocks.TOTPCode,
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with recovery code",
+			user: user,
+			code: e.RecoveryCodes[0],
+			err:  nil,
+		},
+		{
+			desc: "login with used recovery code",
+			user: user,
+			code: e.RecoveryCodes[0],
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with other recovery code",
+			user: user,
+			code: e.RecoveryCodes[1],
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Login(context.Background(), tc.user, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginTOTPLocked(t *testing.T) {
+	svc := newService()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 0; i < 5; i++ {
+		_, _, err := svc.Login(context.Background(), user, wrong)
+		assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("login with invalid code: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	}
+
+	cases := []struct {
+		desc string
+		code string
+	}{
+		{
+			desc: "login with invalid code",
+			code: wrong,
+		},
+		{
+			desc: "login with valid code",
+			code: "123457",
+		},
+		{
+			desc: "login with recovery code",
+			code: e.RecoveryCodes[0],
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Login(context.Background(), user, tc.code)
+		assert.True(t, errors.Contains(err, users.ErrTOTPLocked), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, users.ErrTOTPLocked, err))
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	svc := newService()
+	svc.Register(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.DisableTOTP(context.Background(), token, mocks.TOTPCode)
+	assert.True(t, errors.Contains(err, users.ErrTOTPNotEnrolled), fmt.Sprintf("disable TOTP before enrollment: expected %s got %s\n", users.ErrTOTPNotEnrolled, err))
+
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.EnableTOTP(context.Background(), token, mocks.TOTPCode)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "disable TOTP with invalid token",
+			token: wrong,
+			code:  mocks.TOTPCode,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable TOTP with invalid code",
+			token: token,
+			code:  wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable TOTP with recovery code",
+			token: token,
+			code:  e.RecoveryCodes[0],
+			err:   nil,
+		},
+		{
+			desc:  "disable disabled TOTP",
+			token: token,
+			code:  mocks.TOTPCode,
+			err:   users.ErrTOTPNotEnrolled,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.DisableTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login with disabled TOTP expected to succeed: %s", err))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+// TwoFactor represents the two-factor authentication settings of the user.
+type TwoFactor struct {
+	// Secret is the TOTP secret shared with the authenticator app of the
+	// user.
+	Secret string
+
+	// Enabled indicates whether the enrollment is confirmed, i.e. whether
+	// the code is required on login.
+	Enabled bool
+
+	// RecoveryCodes are the hashes of the unused recovery codes.
+	RecoveryCodes []string
+
+	// Counter is the time step of the last used TOTP code. Codes of the same
+	// or the earlier time steps are rejected, so the code can't be replayed.
+	Counter uint64
+}
+
+// TOTPEnrollment represents the TOTP secret the user enrolls the
+// authenticator app with, along with the recovery codes which can be used
+// instead of the TOTP codes once the app is lost.
+type TOTPEnrollment struct {
+	Secret        string
+	URI           string
+	RecoveryCodes []string
+}
+
+// TOTP specifies an API for the time-based one-time passwords (RFC 6238)
+// used as the second authentication factor.
+type TOTP interface {
+	// Secret generates the new random secret.
+	Secret() (string, error)
+
+	// URI returns the provisioning URI of the secret, which authenticator
+	// apps enroll the account with, usually by scanning it as a QR code.
+	URI(secret, account string) string
+
+	// Validate returns the time step the code is generated for, or an error
+	// if the code isn't valid for the secret at the current time.
+	Validate(secret, code string) (uint64, error)
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package totp provides the time-based one-time password (RFC 6238)
+// implementation compatible with the common authenticator apps.
+package totp
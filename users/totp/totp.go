@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+const (
+	secretSize = 20
+	digits     = 6
+	period     = 30
+	// skew is the number of periods the code is accepted for before and
+	// after the current one, to allow for the clock drift.
+	skew = 1
+)
+
+var (
+	errInvalidSecret = errors.New("invalid TOTP secret")
+	errInvalidCode   = errors.New("invalid TOTP code")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var _ users.TOTP = (*totp)(nil)
+
+type totp struct {
+	issuer string
+}
+
+// New instantiates the TOTP implementation which uses HMAC-SHA1, 6 digit
+// codes and 30 seconds period, as expected by the authenticator apps.
+// Issuer is the name the accounts are labeled with in the app.
+func New(issuer string) users.TOTP {
+	return &totp{issuer: issuer}
+}
+
+func (t *totp) Secret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+func (t *totp) URI(secret, account string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", t.issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(digits))
+	q.Set("period", fmt.Sprint(period))
+
+	label := url.PathEscape(t.issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+func (t *totp) Validate(secret, code string) (uint64, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	now := uint64(time.Now().Unix() / period)
+	for counter := now - skew; counter <= now+skew; counter++ {
+		c := generate(key, counter)
+		if subtle.ConstantTimeCompare([]byte(c), []byte(code)) == 1 {
+			return counter, nil
+		}
+	}
+
+	return 0, errInvalidCode
+}
+
+// Code returns the code of the secret at the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return generate(key, uint64(t.Unix()/period)), nil
+}
+
+func decode(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, errors.Wrap(errInvalidSecret, err)
+	}
+
+	return key, nil
+}
+
+// generate returns the HOTP code (RFC 4226) of the key for the counter.
+func generate(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, bin%mod)
+}
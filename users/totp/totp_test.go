@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/users/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secret is the RFC 6238 test vectors secret "12345678901234567890".
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// RFC 6238 test vectors, truncated to 6 digits.
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for ts, expected := range cases {
+		code, err := totp.Code(secret, time.Unix(ts, 0))
+		require.Nil(t, err, fmt.Sprintf("generating code expected to succeed: %s", err))
+		assert.Equal(t, expected, code, fmt.Sprintf("%d: expected code %s got %s\n", ts, expected, code))
+	}
+
+	_, err := totp.Code("invalid secret", time.Now())
+	assert.NotNil(t, err, "generating code with invalid secret expected to fail")
+}
+
+func TestValidate(t *testing.T) {
+	svc := totp.New("Mainflux")
+	s, err := svc.Secret()
+	require.Nil(t, err, fmt.Sprintf("generating secret expected to succeed: %s", err))
+
+	now := time.Now()
+	step := uint64(now.Unix() / 30)
+	code := func(t time.Time) string {
+		c, _ := totp.Code(s, t)
+		return c
+	}
+
+	cases := []struct {
+		desc   string
+		secret string
+		code   string
+		step   uint64
+		valid  bool
+	}{
+		{
+			desc:   "validate current code",
+			secret: s,
+			code:   code(now),
+			step:   step,
+			valid:  true,
+		},
+		{
+			desc:   "validate previous code",
+			secret: s,
+			code:   code(now.Add(-30 * time.Second)),
+			step:   step - 1,
+			valid:  true,
+		},
+		{
+			desc:   "validate expired code",
+			secret: s,
+			code:   code(now.Add(-90 * time.Second)),
+			valid:  false,
+		},
+		{
+			desc:   "validate code of other secret",
+			secret: secret,
+			code:   code(now),
+			valid:  false,
+		},
+		{
+			desc:   "validate empty code",
+			secret: s,
+			code:   "",
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid secret",
+			secret: "invalid secret",
+			code:   code(now),
+			valid:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		step, err := svc.Validate(tc.secret, tc.code)
+		assert.Equal(t, tc.valid, err == nil, fmt.Sprintf("%s: expected valid %t got error %s\n", tc.desc, tc.valid, err))
+		assert.Equal(t, tc.step, step, fmt.Sprintf("%s: expected time step %d got %d\n", tc.desc, tc.step, step))
+	}
+}
+
+func TestURI(t *testing.T) {
+	svc := totp.New("Mainflux")
+	u, err := url.Parse(svc.URI(secret, "user@example.com"))
+	require.Nil(t, err, fmt.Sprintf("parsing URI expected to succeed: %s", err))
+
+	assert.Equal(t, "otpauth", u.Scheme, fmt.Sprintf("expected scheme otpauth got %s", u.Scheme))
+	assert.Equal(t, "totp", u.Host, fmt.Sprintf("expected type totp got %s", u.Host))
+	assert.Equal(t, "/Mainflux:user@example.com", u.Path, fmt.Sprintf("expected label /Mainflux:user@example.com got %s", u.Path))
+
+	expected := map[string]string{
+		"secret":    secret,
+		"issuer":    "Mainflux",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	for k, v := range expected {
+		assert.Equal(t, v, u.Query().Get(k), fmt.Sprintf("expected %s %s got %s", k, v, u.Query().Get(k)))
+	}
+}
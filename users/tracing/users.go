@@ -7,6 +7,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/users"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -18,6 +19,11 @@ const (
 	generateResetToken = "generate_reset_token"
 	updatePassword     = "update_password"
 	sendPasswordReset  = "send_reset_password"
+	retrieveTwoFactor  = "retrieve_two_factor"
+	updateTwoFactor    = "update_two_factor"
+	saveTOTPAttempt    = "save_totp_attempt"
+	useTOTPCounter     = "use_totp_counter"
+	useRecoveryCode    = "use_recovery_code"
 )
 
 var _ users.UserRepository = (*userRepositoryMiddleware)(nil)
@@ -68,6 +74,46 @@ func (urm userRepositoryMiddleware) UpdatePassword(ctx context.Context, email, p
 	return urm.repo.UpdatePassword(ctx, email, password)
 }
 
+func (urm userRepositoryMiddleware) RetrieveTwoFactor(ctx context.Context, email string) (users.TwoFactor, error) {
+	span := createSpan(ctx, urm.tracer, retrieveTwoFactor)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveTwoFactor(ctx, email)
+}
+
+func (urm userRepositoryMiddleware) UpdateTwoFactor(ctx context.Context, email string, tf users.TwoFactor) error {
+	span := createSpan(ctx, urm.tracer, updateTwoFactor)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateTwoFactor(ctx, email, tf)
+}
+
+func (urm userRepositoryMiddleware) SaveTOTPAttempt(ctx context.Context, email string, at time.Time, max uint32, lockout time.Duration) error {
+	span := createSpan(ctx, urm.tracer, saveTOTPAttempt)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.SaveTOTPAttempt(ctx, email, at, max, lockout)
+}
+
+func (urm userRepositoryMiddleware) UseTOTPCounter(ctx context.Context, email string, counter uint64) error {
+	span := createSpan(ctx, urm.tracer, useTOTPCounter)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UseTOTPCounter(ctx, email, counter)
+}
+
+func (urm userRepositoryMiddleware) UseRecoveryCode(ctx context.Context, email, hash string) error {
+	span := createSpan(ctx, urm.tracer, useRecoveryCode)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UseRecoveryCode(ctx, email, hash)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
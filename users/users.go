@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/net/idna"
 )
@@ -35,6 +36,11 @@ type User struct {
 	Email    string
 	Password string
 	Metadata map[string]interface{}
+
+	// OIDCSubject is the identifier of the user at the OpenID Connect
+	// identity provider, set only for the accounts provisioned on the
+	// OpenID Connect login.
+	OIDCSubject string `json:"-"`
 }
 
 // Validate returns an error if user representation is invalid.
@@ -64,6 +70,29 @@ type UserRepository interface {
 
 	// UpdatePassword updates password for user with given email
 	UpdatePassword(ctx context.Context, email, password string) error
+
+	// RetrieveTwoFactor retrieves the two-factor authentication settings of
+	// the user with the given email.
+	RetrieveTwoFactor(ctx context.Context, email string) (TwoFactor, error)
+
+	// UpdateTwoFactor updates the two-factor authentication settings of the
+	// user with the given email, and resets the recorded attempts.
+	UpdateTwoFactor(ctx context.Context, email string, tf TwoFactor) error
+
+	// SaveTOTPAttempt records the two-factor authentication attempt of the
+	// user with the given email, made at the given time. Once max attempts
+	// are recorded since the last successful one, the attempts are rejected
+	// with ErrTOTPLocked until the lockout passes since the last attempt.
+	SaveTOTPAttempt(ctx context.Context, email string, at time.Time, max uint32, lockout time.Duration) error
+
+	// UseTOTPCounter sets the time step of the last used TOTP code and
+	// resets the recorded attempts. ErrConflict is returned unless the
+	// counter is greater than the stored one, i.e. if the code is replayed.
+	UseTOTPCounter(ctx context.Context, email string, counter uint64) error
+
+	// UseRecoveryCode removes the recovery code hash and resets the recorded
+	// attempts. ErrConflict is returned if the code is already used.
+	UseRecoveryCode(ctx context.Context, email, hash string) error
 }
 
 func isEmail(email string) bool {